@@ -1,6 +1,7 @@
 package core
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -53,21 +54,29 @@ func (a Allotment) String() string {
 	return out + " }"
 }
 
-func (a Allotment) Allocate(amount uint64) []uint64 {
-	parts := make([]uint64, len(a))
-	total_allocated := uint64(0)
+func (a Allotment) MarshalJSON() ([]byte, error) {
+	ratios := make([]string, len(a))
+	for i := range a {
+		ratios[i] = a[i].String()
+	}
+	return json.Marshal(ratios)
+}
+
+func (a Allotment) Allocate(amount *MonetaryInt) []*MonetaryInt {
+	parts := make([]*MonetaryInt, len(a))
+	total_allocated := NewMonetaryInt(0)
 	// for every part in the allotment, calculate the floored value
 	for i, allot := range a {
 		var res big.Int
-		res.Mul(new(big.Int).SetUint64(amount), allot.Num())
+		res.Mul(amount.bigInt(), allot.Num())
 		res.Div(&res, allot.Denom())
-		parts[i] = res.Uint64()
-		total_allocated += res.Uint64()
+		parts[i] = (*MonetaryInt)(&res)
+		total_allocated = total_allocated.Add(parts[i])
 	}
 	for i := range parts {
-		if total_allocated < uint64(amount) {
-			parts[i] += 1
-			total_allocated += 1
+		if total_allocated.Lt(amount) {
+			parts[i] = parts[i].Add(NewMonetaryInt(1))
+			total_allocated = total_allocated.Add(NewMonetaryInt(1))
 		}
 	}
 	return parts
@@ -5,15 +5,31 @@ import (
 	"fmt"
 )
 
+var ErrInsufficientFunding = errors.New("insufficient funding")
+
 type FundingPart struct {
-	Amount  uint64
-	Account Account
+	Amount  *MonetaryInt `json:"amount"`
+	Account Account      `json:"account"`
+}
+
+func (lhs FundingPart) Equals(rhs FundingPart) bool {
+	return lhs.Account == rhs.Account && lhs.Amount.Equal(rhs.Amount)
 }
 
 type Funding struct {
-	Asset    Asset
-	Parts    []FundingPart
-	Infinite bool
+	Asset    Asset         `json:"asset"`
+	Parts    []FundingPart `json:"parts"`
+	Infinite bool          `json:"infinite"`
+	// Account with an unbounded overdraft that an infinite funding draws on past its parts,
+	// @world if empty
+	InfiniteAccount Account `json:"infinite_account,omitempty"`
+}
+
+func (f Funding) infiniteAccount() Account {
+	if f.InfiniteAccount == "" {
+		return "world"
+	}
+	return f.InfiniteAccount
 }
 
 func (lhs *Funding) Equals(rhs *Funding) bool {
@@ -24,7 +40,7 @@ func (lhs *Funding) Equals(rhs *Funding) bool {
 		return false
 	}
 	for i := range lhs.Parts {
-		if lhs.Parts[i] != rhs.Parts[i] {
+		if !lhs.Parts[i].Equals(rhs.Parts[i]) {
 			return false
 		}
 	}
@@ -37,12 +53,12 @@ func (f Funding) String() string {
 		out += fmt.Sprintf(" %v %v", part.Account, part.Amount)
 	}
 	if f.Infinite {
-		out += " @world *"
+		out += fmt.Sprintf(" %v *", f.infiniteAccount())
 	}
 	return out + "]"
 }
 
-func (f Funding) Take(amount uint64) (Funding, Funding, error) {
+func (f Funding) Take(amount *MonetaryInt) (Funding, Funding, error) {
 	result := Funding{
 		Asset: f.Asset,
 	}
@@ -51,18 +67,18 @@ func (f Funding) Take(amount uint64) (Funding, Funding, error) {
 	}
 	remaining_to_withdraw := amount
 	i := 0
-	for remaining_to_withdraw > 0 && i < len(f.Parts) {
+	for remaining_to_withdraw.Gt(NewMonetaryInt(0)) && i < len(f.Parts) {
 		amt_to_withdraw := f.Parts[i].Amount
 		// if this part has excess balance, put it in the remainder & only take what's needed
-		if amt_to_withdraw > remaining_to_withdraw {
-			rem := amt_to_withdraw - remaining_to_withdraw
+		if amt_to_withdraw.Gt(remaining_to_withdraw) {
+			rem := amt_to_withdraw.Sub(remaining_to_withdraw)
 			amt_to_withdraw = remaining_to_withdraw
 			remainder.Parts = append(remainder.Parts, FundingPart{
 				Account: f.Parts[i].Account,
 				Amount:  rem,
 			})
 		}
-		remaining_to_withdraw -= amt_to_withdraw
+		remaining_to_withdraw = remaining_to_withdraw.Sub(amt_to_withdraw)
 		result.Parts = append(result.Parts, FundingPart{
 			Account: f.Parts[i].Account,
 			Amount:  amt_to_withdraw,
@@ -78,21 +94,19 @@ func (f Funding) Take(amount uint64) (Funding, Funding, error) {
 	}
 	if f.Infinite {
 		remainder.Infinite = true
+		remainder.InfiniteAccount = f.InfiniteAccount
 	}
-	if remaining_to_withdraw != 0 {
+	if !remaining_to_withdraw.IsZero() {
 		if f.Infinite {
-			result.Parts = append(result.Parts, FundingPart{
-				Account: "world",
-				Amount:  remaining_to_withdraw,
-			})
+			result.Parts = appendPart(result.Parts, f.infiniteAccount(), remaining_to_withdraw)
 		} else {
-			return Funding{}, Funding{}, errors.New("insufficient funding")
+			return Funding{}, Funding{}, ErrInsufficientFunding
 		}
 	}
 	return result, remainder, nil
 }
 
-func (f Funding) TakeMax(amount uint64) (Funding, Funding) {
+func (f Funding) TakeMax(amount *MonetaryInt) (Funding, Funding) {
 	result := Funding{
 		Asset: f.Asset,
 	}
@@ -101,18 +115,18 @@ func (f Funding) TakeMax(amount uint64) (Funding, Funding) {
 	}
 	remaining_to_withdraw := amount
 	i := 0
-	for remaining_to_withdraw > 0 && i < len(f.Parts) {
+	for remaining_to_withdraw.Gt(NewMonetaryInt(0)) && i < len(f.Parts) {
 		amt_to_withdraw := f.Parts[i].Amount
 		// if this part has excess balance, put it in the remainder & only take what's needed
-		if amt_to_withdraw > remaining_to_withdraw {
-			rem := amt_to_withdraw - remaining_to_withdraw
+		if amt_to_withdraw.Gt(remaining_to_withdraw) {
+			rem := amt_to_withdraw.Sub(remaining_to_withdraw)
 			amt_to_withdraw = remaining_to_withdraw
 			remainder.Parts = append(remainder.Parts, FundingPart{
 				Account: f.Parts[i].Account,
 				Amount:  rem,
 			})
 		}
-		remaining_to_withdraw -= amt_to_withdraw
+		remaining_to_withdraw = remaining_to_withdraw.Sub(amt_to_withdraw)
 		result.Parts = append(result.Parts, FundingPart{
 			Account: f.Parts[i].Account,
 			Amount:  amt_to_withdraw,
@@ -128,16 +142,54 @@ func (f Funding) TakeMax(amount uint64) (Funding, Funding) {
 	}
 	if f.Infinite {
 		remainder.Infinite = true
+		remainder.InfiniteAccount = f.InfiniteAccount
 	}
-	if remaining_to_withdraw != 0 && f.Infinite {
-		result.Parts = append(result.Parts, FundingPart{
-			Account: "world",
-			Amount:  remaining_to_withdraw,
-		})
+	if !remaining_to_withdraw.IsZero() && f.Infinite {
+		result.Parts = appendPart(result.Parts, f.infiniteAccount(), remaining_to_withdraw)
 	}
 	return result, remainder
 }
 
+// Appends a part, merged with the last one if it is taken from the same account
+func appendPart(parts []FundingPart, account Account, amount *MonetaryInt) []FundingPart {
+	if l := len(parts); l > 0 && parts[l-1].Account == account {
+		parts[l-1].Amount = parts[l-1].Amount.Add(amount)
+		return parts
+	}
+	return append(parts, FundingPart{
+		Account: account,
+		Amount:  amount,
+	})
+}
+
+// Returns how much taking amount from the funding draws past its parts,
+// i.e. what the account with an unbounded overdraft of an infinite funding is overdrawn by
+func (f Funding) Overdrawn(amount *MonetaryInt) *MonetaryInt {
+	if !f.Infinite {
+		return NewMonetaryInt(0)
+	}
+	for _, part := range f.Parts {
+		amount = amount.Sub(part.Amount)
+	}
+	if amount.Ltz() {
+		return NewMonetaryInt(0)
+	}
+	return amount
+}
+
+// Returns the accounts the funding is made of, in order and without duplicates
+func (f Funding) Accounts() []Account {
+	accounts := []Account{}
+	seen := map[Account]struct{}{}
+	for _, part := range f.Parts {
+		if _, ok := seen[part.Account]; !ok {
+			seen[part.Account] = struct{}{}
+			accounts = append(accounts, part.Account)
+		}
+	}
+	return accounts
+}
+
 func (f Funding) Concat(other Funding) (Funding, error) {
 	if f.Asset != other.Asset {
 		return Funding{}, errors.New("tried to concat different assets")
@@ -147,9 +199,12 @@ func (f Funding) Concat(other Funding) (Funding, error) {
 		Parts:    f.Parts,
 		Infinite: f.Infinite || other.Infinite,
 	}
-	if !f.Infinite {
+	if f.Infinite {
+		res.InfiniteAccount = f.InfiniteAccount
+	} else {
+		res.InfiniteAccount = other.InfiniteAccount
 		if len(res.Parts) > 0 && len(other.Parts) > 0 && res.Parts[len(res.Parts)-1].Account == other.Parts[0].Account {
-			res.Parts[len(res.Parts)-1].Amount += other.Parts[0].Amount
+			res.Parts[len(res.Parts)-1].Amount = res.Parts[len(res.Parts)-1].Amount.Add(other.Parts[0].Amount)
 			res.Parts = append(res.Parts, other.Parts[1:]...)
 		} else {
 			res.Parts = append(res.Parts, other.Parts...)
@@ -158,13 +213,16 @@ func (f Funding) Concat(other Funding) (Funding, error) {
 	return res, nil
 }
 
-func (f Funding) Total() (uint64, error) {
+func (f Funding) Total() (*MonetaryInt, error) {
 	if f.Infinite {
-		return 0, errors.New("tried to calculate total of infinite funding")
+		return nil, errors.New("tried to calculate total of infinite funding")
 	}
-	total := uint64(0)
+	total := NewMonetaryInt(0)
 	for _, part := range f.Parts {
-		total += part.Amount
+		if part.Amount.Ltz() {
+			return nil, fmt.Errorf("%w in funding part of %v: %v", ErrNegativeAmount, part.Account, part.Amount)
+		}
+		total = total.Add(part.Amount)
 	}
 	return total, nil
 }
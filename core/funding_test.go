@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -10,19 +11,19 @@ func TestFundingTake(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  70,
+				Amount:  NewMonetaryInt(70),
 			},
 			{
 				Account: Account("bbb"),
-				Amount:  30,
+				Amount:  NewMonetaryInt(30),
 			},
 			{
 				Account: Account("ccc"),
-				Amount:  50,
+				Amount:  NewMonetaryInt(50),
 			},
 		},
 	}
-	result, remainder, err := f.Take(80)
+	result, remainder, err := f.Take(NewMonetaryInt(80))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -31,11 +32,11 @@ func TestFundingTake(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  70,
+				Amount:  NewMonetaryInt(70),
 			},
 			{
 				Account: Account("bbb"),
-				Amount:  10,
+				Amount:  NewMonetaryInt(10),
 			},
 		},
 	}
@@ -47,11 +48,11 @@ func TestFundingTake(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("bbb"),
-				Amount:  20,
+				Amount:  NewMonetaryInt(20),
 			},
 			{
 				Account: Account("ccc"),
-				Amount:  50,
+				Amount:  NewMonetaryInt(50),
 			},
 		},
 	}
@@ -66,12 +67,12 @@ func TestFundingTakeInfinite1(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  70,
+				Amount:  NewMonetaryInt(70),
 			},
 		},
 		Infinite: true,
 	}
-	result, remainder, err := f.Take(80)
+	result, remainder, err := f.Take(NewMonetaryInt(80))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -80,11 +81,11 @@ func TestFundingTakeInfinite1(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  70,
+				Amount:  NewMonetaryInt(70),
 			},
 			{
 				Account: Account("world"),
-				Amount:  10,
+				Amount:  NewMonetaryInt(10),
 			},
 		},
 	}
@@ -106,12 +107,12 @@ func TestFundingTakeInfinite2(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  70,
+				Amount:  NewMonetaryInt(70),
 			},
 		},
 		Infinite: true,
 	}
-	result, remainder, err := f.Take(30)
+	result, remainder, err := f.Take(NewMonetaryInt(30))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -120,7 +121,7 @@ func TestFundingTakeInfinite2(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  30,
+				Amount:  NewMonetaryInt(30),
 			},
 		},
 	}
@@ -132,7 +133,7 @@ func TestFundingTakeInfinite2(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  40,
+				Amount:  NewMonetaryInt(40),
 			},
 		},
 		Infinite: true,
@@ -142,23 +143,62 @@ func TestFundingTakeInfinite2(t *testing.T) {
 	}
 }
 
+func TestFundingTakeUnboundedOverdraft(t *testing.T) {
+	f := Funding{
+		Asset: Asset("COIN"),
+		Parts: []FundingPart{
+			{
+				Account: Account("aaa"),
+				Amount:  NewMonetaryInt(70),
+			},
+		},
+		Infinite:        true,
+		InfiniteAccount: Account("aaa"),
+	}
+	result, remainder, err := f.Take(NewMonetaryInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected_result := Funding{
+		Asset: Asset("COIN"),
+		Parts: []FundingPart{
+			{
+				Account: Account("aaa"),
+				Amount:  NewMonetaryInt(100),
+			},
+		},
+	}
+	if !ValueEquals(result, expected_result) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+	if !remainder.Infinite || remainder.InfiniteAccount != Account("aaa") || len(remainder.Parts) != 0 {
+		t.Fatalf("unexpected remainder: %v", remainder)
+	}
+	if overdrawn := f.Overdrawn(NewMonetaryInt(100)); !overdrawn.Equal(NewMonetaryInt(30)) {
+		t.Fatalf("unexpected overdrawn amount: %v", overdrawn)
+	}
+	if overdrawn := f.Overdrawn(NewMonetaryInt(50)); !overdrawn.IsZero() {
+		t.Fatalf("unexpected overdrawn amount: %v", overdrawn)
+	}
+}
+
 func TestFundingTakeMaxUnder(t *testing.T) {
 	f := Funding{
 		Asset: Asset("COIN"),
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  30,
+				Amount:  NewMonetaryInt(30),
 			},
 		},
 	}
-	result, remainder := f.TakeMax(80)
+	result, remainder := f.TakeMax(NewMonetaryInt(80))
 	if !ValueEquals(result, Funding{
 		Asset: Asset("COIN"),
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  30,
+				Amount:  NewMonetaryInt(30),
 			},
 		},
 	}) {
@@ -177,17 +217,17 @@ func TestFundingTakeMaxAbove(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  90,
+				Amount:  NewMonetaryInt(90),
 			},
 		},
 	}
-	result, remainder := f.TakeMax(80)
+	result, remainder := f.TakeMax(NewMonetaryInt(80))
 	if !ValueEquals(result, Funding{
 		Asset: Asset("COIN"),
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  80,
+				Amount:  NewMonetaryInt(80),
 			},
 		},
 	}) {
@@ -198,7 +238,7 @@ func TestFundingTakeMaxAbove(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  10,
+				Amount:  NewMonetaryInt(10),
 			},
 		},
 	}) {
@@ -212,22 +252,22 @@ func TestFundingTakeMaxInfinite(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  20,
+				Amount:  NewMonetaryInt(20),
 			},
 		},
 		Infinite: true,
 	}
-	result, remainder := f.TakeMax(80)
+	result, remainder := f.TakeMax(NewMonetaryInt(80))
 	if !ValueEquals(result, Funding{
 		Asset: Asset("COIN"),
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  20,
+				Amount:  NewMonetaryInt(20),
 			},
 			{
 				Account: Account("world"),
-				Amount:  60,
+				Amount:  NewMonetaryInt(60),
 			},
 		},
 	}) {
@@ -248,15 +288,15 @@ func TestFundingReversal(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("aaa"),
-				Amount:  10,
+				Amount:  NewMonetaryInt(10),
 			},
 			{
 				Account: Account("bbb"),
-				Amount:  20,
+				Amount:  NewMonetaryInt(20),
 			},
 			{
 				Account: Account("ccc"),
-				Amount:  30,
+				Amount:  NewMonetaryInt(30),
 			},
 		},
 	}
@@ -269,15 +309,15 @@ func TestFundingReversal(t *testing.T) {
 		Parts: []FundingPart{
 			{
 				Account: Account("ccc"),
-				Amount:  30,
+				Amount:  NewMonetaryInt(30),
 			},
 			{
 				Account: Account("bbb"),
-				Amount:  20,
+				Amount:  NewMonetaryInt(20),
 			},
 			{
 				Account: Account("aaa"),
-				Amount:  10,
+				Amount:  NewMonetaryInt(10),
 			},
 		},
 	}) {
@@ -295,3 +335,18 @@ func TestInfiniteFundingReversal(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestFundingTotalNegative(t *testing.T) {
+	_, err := Funding{
+		Asset: Asset("COIN"),
+		Parts: []FundingPart{
+			{
+				Account: Account("aaa"),
+				Amount:  NewMonetaryInt(-10),
+			},
+		},
+	}.Total()
+	if !errors.Is(err, ErrNegativeAmount) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
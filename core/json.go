@@ -15,10 +15,22 @@ func TypenameToType(name string) (Type, bool) {
 	switch name {
 	case "account":
 		return TYPE_ACCOUNT, true
+	case "asset":
+		return TYPE_ASSET, true
+	case "number":
+		return TYPE_NUMBER, true
+	case "string":
+		return TYPE_STRING, true
 	case "portion":
 		return TYPE_PORTION, true
 	case "monetary":
 		return TYPE_MONETARY, true
+	case "allotment":
+		return TYPE_ALLOTMENT, true
+	case "funding":
+		return TYPE_FUNDING, true
+	case "bool":
+		return TYPE_BOOL, true
 	default:
 		return 0, false
 	}
@@ -32,8 +44,6 @@ func NewValueFromTypedJSON(raw_input json.RawMessage) (*Value, error) {
 		return nil, err
 	}
 
-	fmt.Println(input.Type)
-
 	typ, ok := TypenameToType(input.Type)
 	if !ok {
 		return nil, fmt.Errorf("unknown type: %v", input.Type)
@@ -68,13 +78,19 @@ func NewValueFromJSON(typ Type, data json.RawMessage) (*Value, error) {
 		value = number
 	case TYPE_MONETARY:
 		var mon struct {
-			Asset  string `json:"asset"`
-			Amount uint64 `json:"amount"`
+			Asset  string       `json:"asset"`
+			Amount *MonetaryInt `json:"amount"`
 		}
 		err := json.Unmarshal(data, &mon)
 		if err != nil {
 			return nil, err
 		}
+		if mon.Amount == nil {
+			return nil, errors.New("missing amount")
+		}
+		if mon.Amount.Ltz() {
+			return nil, ErrNegativeAmount
+		}
 		value = Monetary{
 			Asset:  Asset(mon.Asset),
 			Amount: mon.Amount,
@@ -85,11 +101,40 @@ func NewValueFromJSON(typ Type, data json.RawMessage) (*Value, error) {
 		if err != nil {
 			return nil, err
 		}
+		if s == "remaining" {
+			value = NewPortionRemaining()
+			break
+		}
 		res, err := ParsePortionSpecific(s)
 		if err != nil {
 			return nil, err
 		}
 		value = *res
+	case TYPE_ALLOTMENT:
+		var ratios []string
+		err := json.Unmarshal(data, &ratios)
+		if err != nil {
+			return nil, err
+		}
+		allotment := make(Allotment, len(ratios))
+		for i, s := range ratios {
+			if _, ok := allotment[i].SetString(s); !ok {
+				return nil, fmt.Errorf("invalid ratio: %q", s)
+			}
+		}
+		value = allotment
+	case TYPE_FUNDING:
+		var funding Funding
+		err := json.Unmarshal(data, &funding)
+		if err != nil {
+			return nil, err
+		}
+		for _, part := range funding.Parts {
+			if part.Amount == nil {
+				return nil, errors.New("missing amount")
+			}
+		}
+		value = funding
 	case TYPE_STRING:
 		var s String
 		err := json.Unmarshal(data, &s)
@@ -97,6 +142,13 @@ func NewValueFromJSON(typ Type, data json.RawMessage) (*Value, error) {
 			return nil, err
 		}
 		value = s
+	case TYPE_BOOL:
+		var b Bool
+		err := json.Unmarshal(data, &b)
+		if err != nil {
+			return nil, err
+		}
+		value = b
 	default:
 		return nil, errors.New("invalid type")
 	}
@@ -2,6 +2,8 @@ package core
 
 import (
 	"encoding/json"
+	"errors"
+	"math/big"
 	"testing"
 )
 
@@ -19,6 +21,20 @@ func TestAccountTypedJSON(t *testing.T) {
 	}
 }
 
+func TestBoolTypedJSON(t *testing.T) {
+	j := json.RawMessage(`{
+		"type": "bool",
+		"value": true
+	}`)
+	value, err := NewValueFromTypedJSON(j)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ValueEquals(*value, Bool(true)) {
+		t.Fatalf("unexpected value: %v", *value)
+	}
+}
+
 func TestMonetaryTypedJSON(t *testing.T) {
 	j := json.RawMessage(`{
 		"type": "monetary",
@@ -33,7 +49,7 @@ func TestMonetaryTypedJSON(t *testing.T) {
 	}
 	if !ValueEquals(*value, Monetary{
 		Asset:  Asset("EUR/2"),
-		Amount: 123456,
+		Amount: NewMonetaryInt(123456),
 	}) {
 		t.Fatalf("unexpected value: %v", *value)
 	}
@@ -51,3 +67,57 @@ func TestInvalidTypedJSON(t *testing.T) {
 		t.Fatalf("error expected but got none")
 	}
 }
+
+func TestMonetaryTypedJSONBigAmount(t *testing.T) {
+	for _, amount := range []string{`123456789012345678901234567890`, `"123456789012345678901234567890"`} {
+		j := json.RawMessage(`{
+			"type": "monetary",
+			"value": {
+				"asset": "ETH/18",
+				"amount": ` + amount + `
+			}
+		}`)
+		value, err := NewValueFromTypedJSON(j)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected_amount, _ := ParseMonetaryInt("123456789012345678901234567890")
+		if !ValueEquals(*value, Monetary{
+			Asset:  Asset("ETH/18"),
+			Amount: expected_amount,
+		}) {
+			t.Fatalf("unexpected value: %v", *value)
+		}
+	}
+}
+
+func TestNegativeMonetaryJSON(t *testing.T) {
+	_, err := NewValueFromJSON(TYPE_MONETARY, json.RawMessage(`{
+		"asset": "EUR/2",
+		"amount": -1
+	}`))
+	if !errors.Is(err, ErrNegativeAmount) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPortionAndAllotmentJSON(t *testing.T) {
+	specific, _ := ParsePortionSpecific("12.5%")
+	for _, v := range []Value{
+		*specific,
+		NewPortionRemaining(),
+		Allotment{*big.NewRat(1, 3), *big.NewRat(2, 3)},
+	} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := NewValueFromJSON(v.GetType(), data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ValueEquals(*value, v) {
+			t.Fatalf("unexpected value: %v", *value)
+		}
+	}
+}
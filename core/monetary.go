@@ -0,0 +1,92 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var ErrNegativeAmount = errors.New("negative amount")
+
+// Arbitrary-precision amount of an asset.
+// Operations never mutate their operands and always return a new value.
+type MonetaryInt big.Int
+
+func NewMonetaryInt(i int64) *MonetaryInt {
+	return (*MonetaryInt)(big.NewInt(i))
+}
+
+func NewMonetaryIntFromBigInt(i *big.Int) *MonetaryInt {
+	return (*MonetaryInt)(new(big.Int).Set(i))
+}
+
+func ParseMonetaryInt(s string) (*MonetaryInt, error) {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount: %q", s)
+	}
+	return (*MonetaryInt)(i), nil
+}
+
+func (a *MonetaryInt) bigInt() *big.Int {
+	if a == nil {
+		return new(big.Int)
+	}
+	return (*big.Int)(a)
+}
+
+// Returns a copy of the underlying big.Int
+func (a *MonetaryInt) BigInt() *big.Int {
+	return new(big.Int).Set(a.bigInt())
+}
+
+func (a *MonetaryInt) Add(b *MonetaryInt) *MonetaryInt {
+	return (*MonetaryInt)(new(big.Int).Add(a.bigInt(), b.bigInt()))
+}
+
+func (a *MonetaryInt) Sub(b *MonetaryInt) *MonetaryInt {
+	return (*MonetaryInt)(new(big.Int).Sub(a.bigInt(), b.bigInt()))
+}
+
+func (a *MonetaryInt) Neg() *MonetaryInt {
+	return (*MonetaryInt)(new(big.Int).Neg(a.bigInt()))
+}
+
+func (a *MonetaryInt) Cmp(b *MonetaryInt) int {
+	return a.bigInt().Cmp(b.bigInt())
+}
+
+func (a *MonetaryInt) Equal(b *MonetaryInt) bool { return a.Cmp(b) == 0 }
+func (a *MonetaryInt) Lt(b *MonetaryInt) bool    { return a.Cmp(b) == -1 }
+func (a *MonetaryInt) Lte(b *MonetaryInt) bool   { return a.Cmp(b) != 1 }
+func (a *MonetaryInt) Gt(b *MonetaryInt) bool    { return a.Cmp(b) == 1 }
+func (a *MonetaryInt) Gte(b *MonetaryInt) bool   { return a.Cmp(b) != -1 }
+func (a *MonetaryInt) Ltz() bool                 { return a.bigInt().Sign() == -1 }
+func (a *MonetaryInt) IsZero() bool              { return a.bigInt().Sign() == 0 }
+
+func (a *MonetaryInt) String() string {
+	return a.bigInt().String()
+}
+
+// Encoded as a JSON string, since most JSON implementations
+// can't represent large integers as numbers
+func (a *MonetaryInt) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + a.bigInt().String() + `"`), nil
+}
+
+// Accepts both JSON numbers and strings
+func (a *MonetaryInt) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return errors.New("amount cannot be null")
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	i, err := ParseMonetaryInt(s)
+	if err != nil {
+		return err
+	}
+	*a = *i
+	return nil
+}
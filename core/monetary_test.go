@@ -0,0 +1,55 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMonetaryIntImmutable(t *testing.T) {
+	a := NewMonetaryInt(10)
+	b := NewMonetaryInt(3)
+	sum := a.Add(b)
+	diff := a.Sub(b)
+	if !a.Equal(NewMonetaryInt(10)) || !b.Equal(NewMonetaryInt(3)) {
+		t.Fatalf("operands were mutated: %v %v", a, b)
+	}
+	if !sum.Equal(NewMonetaryInt(13)) {
+		t.Fatalf("unexpected sum: %v", sum)
+	}
+	if !diff.Equal(NewMonetaryInt(7)) {
+		t.Fatalf("unexpected difference: %v", diff)
+	}
+}
+
+func TestMonetaryIntBeyondUint64(t *testing.T) {
+	max, err := ParseMonetaryInt("18446744073709551615")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := max.Add(NewMonetaryInt(1))
+	if res.String() != "18446744073709551616" {
+		t.Fatalf("unexpected result: %v", res)
+	}
+}
+
+func TestMonetaryIntJSON(t *testing.T) {
+	a, _ := ParseMonetaryInt("1000000000000000000000")
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"1000000000000000000000"` {
+		t.Fatalf("unexpected json: %v", string(data))
+	}
+	// numbers are accepted as well
+	for _, data := range []string{string(data), "1000000000000000000000"} {
+		var b MonetaryInt
+		err = json.Unmarshal([]byte(data), &b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.Equal(&b) {
+			t.Fatalf("unexpected value: %v", &b)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -77,6 +78,10 @@ func ParsePortionSpecific(input string) (*Portion, error) {
 	return portion, nil
 }
 
+func (p Portion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
 func (p Portion) String() string {
 	if p.Remaining {
 		return "remaining"
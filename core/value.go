@@ -17,6 +17,7 @@ const (
 	TYPE_ALLOTMENT                  // list of portions
 	TYPE_AMOUNT                     // either ALL or a SPECIFIC number
 	TYPE_FUNDING                    // (asset, []{amount, account})
+	TYPE_BOOL                       // true or false
 )
 
 func (t Type) String() string {
@@ -37,6 +38,10 @@ func (t Type) String() string {
 		return "allotment"
 	case TYPE_AMOUNT:
 		return "amount"
+	case TYPE_FUNDING:
+		return "funding"
+	case TYPE_BOOL:
+		return "bool"
 	default:
 		return "invalid type"
 	}
@@ -79,9 +84,17 @@ func (s String) String() string {
 	return fmt.Sprintf("\"%v\"", string(s))
 }
 
+type Bool bool
+
+func (Bool) isValue()      {}
+func (Bool) GetType() Type { return TYPE_BOOL }
+func (b Bool) String() string {
+	return fmt.Sprintf("%v", bool(b))
+}
+
 type Monetary struct {
-	Asset  Asset  `json:"asset"`
-	Amount uint64 `json:"amount"`
+	Asset  Asset        `json:"asset"`
+	Amount *MonetaryInt `json:"amount"`
 }
 
 func (a Monetary) String() string {
@@ -128,6 +141,9 @@ func ValueEquals(lhs, rhs Value) bool {
 	} else if lhsf, ok := lhs.(Funding); ok {
 		rhsf := rhs.(Funding)
 		return lhsf.Equals(&rhsf)
+	} else if lhsm, ok := lhs.(Monetary); ok {
+		rhsm := rhs.(Monetary)
+		return lhsm.Asset == rhsm.Asset && lhsm.Amount.Equal(rhsm.Amount)
 	} else if lhs != rhs {
 		return false
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/numary/machine/core"
@@ -8,6 +9,21 @@ import (
 	"github.com/numary/machine/vm"
 )
 
+type store struct {
+	balances map[string]map[string]*core.MonetaryInt
+}
+
+func (s store) GetBalance(ctx context.Context, account core.Account, asset core.Asset) (*core.MonetaryInt, error) {
+	if balance, ok := s.balances[string(account)][string(asset)]; ok {
+		return balance, nil
+	}
+	return core.NewMonetaryInt(0), nil
+}
+
+func (s store) GetAccountMetadata(ctx context.Context, account core.Account, key string) (core.Value, error) {
+	return nil, fmt.Errorf("missing metadata %q of %v", key, account)
+}
+
 func main() {
 	program, err := compiler.Compile(`
 	send [COIN 99] (
@@ -26,49 +42,20 @@ func main() {
 	}
 	fmt.Print(program)
 
-	m := vm.NewMachine(program)
-
-	m.SetVars(map[string]core.Value{})
-
-	{
-		ch, err := m.ResolveResources()
-		if err != nil {
-			panic(err)
-		}
-		for range ch {
-
-		}
-	}
-
-	{
-		balances := map[string]map[string]uint64{
+	res, err := vm.Run(context.Background(), program, map[string]core.Value{}, store{
+		balances: map[string]map[string]*core.MonetaryInt{
 			"a": {
-				"COIN": 500000,
+				"COIN": core.NewMonetaryInt(500000),
 			},
 			"b": {
-				"COIN": 3500000,
+				"COIN": core.NewMonetaryInt(3500000),
 			},
-		}
-
-		ch, err := m.ResolveBalances()
-		if err != nil {
-			panic(err)
-		}
-		for req := range ch {
-			val := balances[req.Account][req.Asset]
-			if req.Error != nil {
-				panic(req.Error)
-			}
-			req.Response <- val
-		}
-	}
-
-	m.Debug = true
-	exit_code, err := m.Execute()
+		},
+	})
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println("Exit code:", exit_code)
-	fmt.Println(m.Postings)
-	fmt.Println(m.TxMeta)
+	fmt.Println("Exit code:", res.ExitCode)
+	fmt.Println(res.Postings)
+	fmt.Println(res.TxMeta)
 }
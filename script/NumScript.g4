@@ -7,9 +7,14 @@ MULTILINE_COMMENT: '/*' (MULTILINE_COMMENT|.)*? '*/' -> skip;
 LINE_COMMENT: '//' .*? NEWLINE -> skip;
 VARS: 'vars';
 META: 'meta';
+BALANCE: 'balance';
 SET_TX_META: 'set_tx_meta';
+SET_ACCOUNT_META: 'set_account_meta';
 PRINT: 'print';
 FAIL: 'fail';
+ASSERT: 'assert';
+IF: 'if';
+ELSE: 'else';
 SEND: 'send';
 SOURCE: 'source';
 FROM: 'from';
@@ -19,6 +24,16 @@ TO: 'to';
 ALLOCATE: 'allocate';
 OP_ADD: '+';
 OP_SUB: '-';
+OP_MUL: '*';
+OP_EQ: '==';
+OP_NEQ: '!=';
+OP_LT: '<';
+OP_LTE: '<=';
+OP_GT: '>';
+OP_GTE: '>=';
+OP_AND: '&&';
+OP_OR: '||';
+OP_NOT: '!';
 LPAREN: '(';
 RPAREN: ')';
 LBRACK: '[';
@@ -32,6 +47,9 @@ TY_NUMBER: 'number';
 TY_MONETARY: 'monetary';
 TY_PORTION: 'portion';
 TY_STRING: 'string';
+TY_BOOL: 'bool';
+TRUE: 'true';
+FALSE: 'false';
 STRING: '"' [a-zA-Z0-9_\- ]* '"';
 PORTION:
   ( [0-9]+ [ ]? '/' [ ]? [0-9]+
@@ -39,6 +57,12 @@ PORTION:
   );
 REMAINING: 'remaining';
 KEPT: 'kept';
+ALLOWING: 'allowing';
+UNBOUNDED: 'unbounded';
+OVERDRAFT: 'overdraft';
+UP: 'up';
+DOWN: 'down';
+ROUNDED: 'rounded';
 NUMBER: [0-9]+;
 PERCENT: '%';
 VARIABLE_NAME: '$' [a-z_]+ [a-z0-9_]*;
@@ -47,22 +71,33 @@ ASSET: [A-Z/0-9]+;
 
 monetary: LBRACK asset=ASSET amt=NUMBER RBRACK;
 
-monetaryAll: LBRACK asset=ASSET '*' RBRACK;
+monetaryAll: LBRACK asset=ASSET OP_MUL RBRACK;
 
 literal
   : ACCOUNT # LitAccount
   | ASSET # LitAsset
   | NUMBER # LitNumber
   | STRING # LitString
+  | (TRUE | FALSE) # LitBool
+  | PORTION # LitPortion
   | monetary # LitMonetary
   ;
 
 variable: VARIABLE_NAME;
 
+balance: BALANCE LPAREN acc=expression ',' asset=expression RPAREN;
+
 expression
-  : lhs=expression op=(OP_ADD|OP_SUB) rhs=expression # ExprAddSub
+  : OP_NOT expr=expression # ExprNot
+  | lhs=expression op=OP_MUL rhs=expression (ROUNDED rounding=(UP|DOWN))? # ExprMul
+  | lhs=expression op=(OP_ADD|OP_SUB) rhs=expression # ExprAddSub
+  | lhs=expression op=(OP_EQ|OP_NEQ|OP_LT|OP_LTE|OP_GT|OP_GTE) rhs=expression # ExprComparison
+  | lhs=expression op=OP_AND rhs=expression # ExprAnd
+  | lhs=expression op=OP_OR rhs=expression # ExprOr
+  | LPAREN expr=expression RPAREN # ExprParens
   | lit=literal # ExprLiteral
   | var_=variable # ExprVariable
+  | bal=balance # ExprBalance
   ;
 
 allotmentPortion
@@ -92,8 +127,13 @@ destination
 
 sourceInOrder: LBRACE NEWLINE (sources+=source NEWLINE)+ RBRACE;
 sourceMaxed: MAX max=expression FROM src=source;
+sourceAccountOverdraft
+  : ALLOWING OVERDRAFT UP TO specific=expression # SrcAccountOverdraftSpecific
+  | ALLOWING UNBOUNDED OVERDRAFT # SrcAccountOverdraftUnbounded
+  ;
+sourceAccount: account=expression (overdraft=sourceAccountOverdraft)?;
 source
-  : expression # SrcAccount
+  : sourceAccount # SrcAccount
   | sourceMaxed # SrcMaxed
   | sourceInOrder # SrcInOrder
   ;
@@ -104,19 +144,27 @@ valueAwareSource
   | sourceAllotment # SrcAllotment
   ;
 
+statementBlock: LBRACE NEWLINE+ (stmts+=statement NEWLINE+)* RBRACE;
+
+ifStatement: IF cond=expression then=statementBlock (ELSE (otherwise=statementBlock | elseIf=ifStatement))?;
+
 statement
   : PRINT expr=expression # Print
   | SET_TX_META '(' key=STRING ',' value=expression ')' #SetTxMeta
-  | FAIL # Fail
+  | SET_ACCOUNT_META '(' acc=expression ',' key=STRING ',' value=expression ')' #SetAccountMeta
+  | FAIL (reason=STRING)? # Fail
+  | ASSERT cond=expression ',' reason=STRING # Assert
   | SEND (mon=expression | monAll=monetaryAll) LPAREN NEWLINE
       ( SOURCE '=' src=valueAwareSource NEWLINE DESTINATION '=' dest=destination
       | DESTINATION '=' dest=destination NEWLINE SOURCE '=' src=valueAwareSource) NEWLINE RPAREN # Send
+  | stmt=ifStatement # If
   ;
 
-type_: TY_ACCOUNT | TY_ASSET | TY_NUMBER | TY_STRING | TY_MONETARY | TY_PORTION;
+type_: TY_ACCOUNT | TY_ASSET | TY_NUMBER | TY_STRING | TY_MONETARY | TY_PORTION | TY_BOOL;
 
 origin
-  : META '(' acc=expression ',' key=STRING ')'
+  : META '(' acc=expression ',' key=STRING ')' # OriginAccountMeta
+  | bal=balance # OriginAccountBalance
   ;
 
 
@@ -0,0 +1,147 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/script/parser"
+	"github.com/numary/machine/vm/program"
+)
+
+// addition or subtraction of two numbers, or of two monetaries of the same asset
+func (p *parseVisitor) VisitAddSub(c *parser.ExprAddSubContext, push bool) (core.Type, *core.Address, *CompileError) {
+	lhs, _, err := p.VisitExpr(c.GetLhs(), push)
+	if err != nil {
+		return 0, nil, err
+	}
+	rhs, _, err := p.VisitExpr(c.GetRhs(), push)
+	if err != nil {
+		return 0, nil, err
+	}
+	is_add := c.GetOp().GetTokenType() == parser.NumScriptLexerOP_ADD
+	switch {
+	case lhs == core.TYPE_NUMBER && rhs == core.TYPE_NUMBER:
+		if push && is_add {
+			p.instructions = append(p.instructions, program.OP_IADD)
+		} else if push {
+			p.instructions = append(p.instructions, program.OP_ISUB)
+		}
+		return core.TYPE_NUMBER, nil, nil
+	case lhs == core.TYPE_MONETARY && rhs == core.TYPE_MONETARY:
+		err := p.checkSameAsset(c)
+		if err != nil {
+			return 0, nil, err
+		}
+		if push && is_add {
+			p.instructions = append(p.instructions, program.OP_MONETARY_ADD)
+		} else if push {
+			p.instructions = append(p.instructions, program.OP_MONETARY_SUB)
+		}
+		return core.TYPE_MONETARY, nil, nil
+	default:
+		return 0, nil, LogicError(c, fmt.Errorf("wrong type: cannot do arithmetic with %v and %v", lhs, rhs))
+	}
+}
+
+// multiplication of a monetary by a number, or by a portion with an explicit rounding mode
+func (p *parseVisitor) VisitMul(c *parser.ExprMulContext, push bool) (core.Type, *core.Address, *CompileError) {
+	lhs, _, err := p.VisitExpr(c.GetLhs(), push)
+	if err != nil {
+		return 0, nil, err
+	}
+	if lhs != core.TYPE_MONETARY {
+		return 0, nil, LogicError(c, fmt.Errorf("wrong type: cannot multiply %v, expected monetary on the left", lhs))
+	}
+	rhs, _, err := p.VisitExpr(c.GetRhs(), push)
+	if err != nil {
+		return 0, nil, err
+	}
+	var op byte
+	switch rhs {
+	case core.TYPE_NUMBER:
+		if c.GetRounding() != nil {
+			return 0, nil, LogicError(c, errors.New("a rounding mode is only allowed when multiplying by a portion"))
+		}
+		op = program.OP_MONETARY_MUL
+	case core.TYPE_PORTION:
+		if c.GetRounding() == nil {
+			return 0, nil, LogicError(c, errors.New("missing rounding mode: multiplying by a portion must be followed by 'rounded up' or 'rounded down'"))
+		}
+		if c.GetRounding().GetTokenType() == parser.NumScriptLexerUP {
+			op = program.OP_MONETARY_MUL_UP
+		} else {
+			op = program.OP_MONETARY_MUL_DOWN
+		}
+	default:
+		return 0, nil, LogicError(c, fmt.Errorf("wrong type: cannot multiply monetary by %v", rhs))
+	}
+	if push {
+		p.instructions = append(p.instructions, op)
+	}
+	return core.TYPE_MONETARY, nil, nil
+}
+
+// Fails if both monetary operands have an asset known at compile time, and they differ.
+// Otherwise, a mismatch is only detected during execution.
+func (p *parseVisitor) checkSameAsset(c *parser.ExprAddSubContext) *CompileError {
+	lhs_addr, err := p.monetaryAssetAddress(c.GetLhs())
+	if err != nil {
+		return err
+	}
+	rhs_addr, err := p.monetaryAssetAddress(c.GetRhs())
+	if err != nil {
+		return err
+	}
+	lhs_asset, lhs_known := p.constantAsset(*lhs_addr)
+	rhs_asset, rhs_known := p.constantAsset(*rhs_addr)
+	if lhs_known && rhs_known && lhs_asset != rhs_asset {
+		if c.GetOp().GetTokenType() == parser.NumScriptLexerOP_SUB {
+			return LogicError(c, fmt.Errorf("asset mismatch: cannot subtract %v from %v", rhs_asset, lhs_asset))
+		}
+		return LogicError(c, fmt.Errorf("asset mismatch: cannot add %v and %v", lhs_asset, rhs_asset))
+	}
+	return nil
+}
+
+// Returns the address of a resource with the same asset as the monetary expression,
+// which is known before the expression is computed
+func (p *parseVisitor) monetaryAssetAddress(c parser.IExpressionContext) (*core.Address, *CompileError) {
+	switch c := c.(type) {
+	case *parser.ExprParensContext:
+		return p.monetaryAssetAddress(c.GetExpr())
+	case *parser.ExprAddSubContext:
+		return p.monetaryAssetAddress(c.GetLhs())
+	case *parser.ExprMulContext:
+		return p.monetaryAssetAddress(c.GetLhs())
+	default:
+		_, addr, err := p.VisitExpr(c, false)
+		if err != nil {
+			return nil, err
+		}
+		if addr == nil {
+			return nil, InternalError(c)
+		}
+		return addr, nil
+	}
+}
+
+// Returns the asset of a monetary or asset resource if it is known at compile time
+func (p *parseVisitor) constantAsset(addr core.Address) (core.Asset, bool) {
+	switch res := p.resources[addr].(type) {
+	case program.Constant:
+		switch inner := res.Inner.(type) {
+		case core.Monetary:
+			return inner.Asset, true
+		case core.Asset:
+			return inner, true
+		}
+	case program.AccountBalance:
+		if c, ok := p.resources[res.Asset].(program.Constant); ok {
+			if asset, ok := c.Inner.(core.Asset); ok {
+				return asset, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,105 @@
+package compiler
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"strings"
+	"sync"
+
+	"github.com/numary/machine/vm/program"
+)
+
+// Hit and miss counts of a Cache
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Size      int    `json:"size"`
+}
+
+type cacheEntry struct {
+	key     [sha256.Size]byte
+	program *program.Program
+	err     error
+}
+
+// Keeps the results of the most recently compiled scripts, errors included.
+// Scripts are keyed by a hash of their normalized source, and the programs
+// returned are shared, see program.Program. Safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[[sha256.Size]byte]*list.Element
+	lru     *list.List // most recently used first
+	stats   CacheStats
+}
+
+// Returns a cache holding at most size compiled scripts
+func NewCache(size int) *Cache {
+	if size < 1 {
+		size = 1
+	}
+	return &Cache{
+		size:    size,
+		entries: make(map[[sha256.Size]byte]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Normalizes line endings and strips trailing whitespace on each line,
+// which doesn't change what a script compiles to. Line breaks are kept,
+// since a line comment must end with one.
+func normalizeSource(source string) string {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Like Compile, but returns the cached result when the script was compiled before
+func (c *Cache) Compile(source string) (*program.Program, error) {
+	// the source is only normalized for the key, errors must show what was written
+	key := sha256.Sum256([]byte(normalizeSource(source)))
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(elem)
+		c.stats.Hits++
+		entry := elem.Value.(*cacheEntry)
+		c.mu.Unlock()
+		return entry.program, entry.err
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	p, err := Compile(source)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		// compiled concurrently, keep the cached program so that it stays shared
+		entry := elem.Value.(*cacheEntry)
+		return entry.program, entry.err
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{
+		key:     key,
+		program: p,
+		err:     err,
+	})
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+		c.stats.Evictions++
+	}
+	return p, err
+}
+
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Size = c.lru.Len()
+	return stats
+}
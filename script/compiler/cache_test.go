@@ -0,0 +1,95 @@
+package compiler
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	c := NewCache(2)
+
+	p1, err := c.Compile("print 1 + 2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, err := c.Compile("print 1 + 2  \r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p1 != p2 {
+		t.Fatal("expected the normalized script to hit the cache")
+	}
+
+	_, err1 := c.Compile("print fail")
+	_, err2 := c.Compile("print fail")
+	if err1 == nil || err1 != err2 {
+		t.Fatalf("expected the compile error to be cached: %v, %v", err1, err2)
+	}
+
+	expected := CacheStats{Hits: 2, Misses: 2, Size: 2}
+	if stats := c.Stats(); stats != expected {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	// evicts the least recently used script, "print 1 + 2"
+	_, err = c.Compile("print 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p3, err := c.Compile("print 1 + 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p3 == p1 {
+		t.Fatal("expected the script to be evicted")
+	}
+	expected = CacheStats{Hits: 2, Misses: 4, Evictions: 2, Size: 2}
+	if stats := c.Stats(); stats != expected {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestCacheTrailingComment(t *testing.T) {
+	c := NewCache(2)
+	_, err := c.Compile("print 1\n// done\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.Compile("print 1\n// done  \r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCacheErrorSource(t *testing.T) {
+	c := NewCache(2)
+	_, err := c.Compile("print fail  \r\n")
+	_, expected := Compile("print fail  \r\n")
+	if err == nil || expected == nil || err.Error() != expected.Error() {
+		t.Fatalf("unexpected error: %v, expected %v", err, expected)
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := NewCache(4)
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, err := c.Compile(fmt.Sprintf("print %d", (i+j)%6))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	stats := c.Stats()
+	if stats.Hits+stats.Misses != 16*50 || stats.Size != 4 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
@@ -19,6 +19,8 @@ type parseVisitor struct {
 	resources       []program.Resource                         // must not exceed 65536 elements
 	var_idx         map[string]core.Address                    // maps name to resource index
 	needed_balances map[core.Address]map[core.Address]struct{} // for each account, set of assets needed
+	source_map      program.SourceMap
+	source_stack    []program.SourceSpan // spans of the nodes being compiled, innermost last
 }
 
 // Allocates constants if it hasn't already been,
@@ -42,6 +44,14 @@ func (p *parseVisitor) AllocateResource(res program.Resource) (*core.Address, er
 			return idx, nil
 		}
 	}
+	if b, ok := res.(program.AccountBalance); ok {
+		for i := 0; i < len(p.resources); i++ {
+			if other, ok := p.resources[i].(program.AccountBalance); ok && other == b {
+				addr := core.Address(i)
+				return &addr, nil
+			}
+		}
+	}
 	if len(p.resources) >= 65536 {
 		return nil, errors.New("number of unique constants exceeded 65536")
 	}
@@ -63,6 +73,42 @@ func (p *parseVisitor) PushInteger(val core.Number) {
 	p.instructions = append(p.instructions, bytes...)
 }
 
+func (p *parseVisitor) mapSource(span program.SourceSpan) {
+	offset := len(p.instructions)
+	if l := len(p.source_map); l > 0 && p.source_map[l-1].Offset == offset {
+		p.source_map[l-1].Span = span
+		return
+	}
+	if l := len(p.source_map); l > 0 && p.source_map[l-1].Span == span {
+		return
+	}
+	p.source_map = append(p.source_map, program.SourceMapEntry{
+		Offset: offset,
+		Span:   span,
+	})
+}
+
+// Maps the instructions emitted from now on to the source code of c,
+// until the matching PopSource
+func (p *parseVisitor) PushSource(c antlr.ParserRuleContext) {
+	span := program.SourceSpan{
+		Startl: c.GetStart().GetLine(),
+		Startc: c.GetStart().GetColumn(),
+		Endl:   c.GetStop().GetLine(),
+		Endc:   c.GetStop().GetColumn() + len(c.GetStop().GetText()),
+	}
+	p.source_stack = append(p.source_stack, span)
+	p.mapSource(span)
+}
+
+// Maps the instructions emitted from now on back to the enclosing source code
+func (p *parseVisitor) PopSource() {
+	p.source_stack = p.source_stack[:len(p.source_stack)-1]
+	if l := len(p.source_stack); l > 0 {
+		p.mapSource(p.source_stack[l-1])
+	}
+}
+
 func (p *parseVisitor) isWorld(addr core.Address) bool {
 	idx := int(addr)
 	if idx < len(p.resources) {
@@ -77,6 +123,16 @@ func (p *parseVisitor) isWorld(addr core.Address) bool {
 	return false
 }
 
+func (p *parseVisitor) addNeededBalance(acc core.Address, asset core.Address) {
+	if b, ok := p.needed_balances[acc]; ok {
+		b[asset] = struct{}{}
+	} else {
+		p.needed_balances[acc] = map[core.Address]struct{}{
+			asset: {},
+		}
+	}
+}
+
 func (p *parseVisitor) VisitVariable(c parser.IVariableContext, push bool) (core.Type, *core.Address, *CompileError) {
 	name := c.GetText()[1:] // strip '$' prefix
 	if idx, ok := p.var_idx[name]; ok {
@@ -95,29 +151,29 @@ func (p *parseVisitor) VisitVariable(c parser.IVariableContext, push bool) (core
 func (p *parseVisitor) VisitExpr(c parser.IExpressionContext, push bool) (core.Type, *core.Address, *CompileError) {
 	switch c := c.(type) {
 	case *parser.ExprAddSubContext:
-		ty, _, err := p.VisitExpr(c.GetLhs(), push)
-		if err != nil {
-			return 0, nil, err
-		}
-		if ty != core.TYPE_NUMBER {
-			return 0, nil, LogicError(c, errors.New("tried to do arithmetic with wrong type"))
-		}
-		ty, _, err = p.VisitExpr(c.GetRhs(), push)
+		return p.VisitAddSub(c, push)
+	case *parser.ExprMulContext:
+		return p.VisitMul(c, push)
+	case *parser.ExprComparisonContext:
+		return p.VisitComparison(c, push)
+	case *parser.ExprAndContext:
+		return p.VisitBoolOp(c, c.GetLhs(), c.GetRhs(), program.OP_AND, push)
+	case *parser.ExprOrContext:
+		return p.VisitBoolOp(c, c.GetLhs(), c.GetRhs(), program.OP_OR, push)
+	case *parser.ExprNotContext:
+		ty, _, err := p.VisitExpr(c.GetExpr(), push)
 		if err != nil {
 			return 0, nil, err
 		}
-		if ty != core.TYPE_NUMBER {
-			return 0, nil, LogicError(c, errors.New("tried to do arithmetic with wrong type"))
+		if ty != core.TYPE_BOOL {
+			return 0, nil, LogicError(c, errors.New("wrong type: expected bool for negation"))
 		}
 		if push {
-			switch c.GetOp().GetTokenType() {
-			case parser.NumScriptLexerOP_ADD:
-				p.instructions = append(p.instructions, program.OP_IADD)
-			case parser.NumScriptLexerOP_SUB:
-				p.instructions = append(p.instructions, program.OP_ISUB)
-			}
+			p.instructions = append(p.instructions, program.OP_NOT)
 		}
-		return core.TYPE_NUMBER, nil, nil
+		return core.TYPE_BOOL, nil, nil
+	case *parser.ExprParensContext:
+		return p.VisitExpr(c.GetExpr(), push)
 	case *parser.ExprLiteralContext:
 		ty, addr, err := p.VisitLit(c.GetLit(), push)
 		if err != nil {
@@ -127,11 +183,47 @@ func (p *parseVisitor) VisitExpr(c parser.IExpressionContext, push bool) (core.T
 	case *parser.ExprVariableContext:
 		ty, addr, err := p.VisitVariable(c.GetVar_(), push)
 		return ty, addr, err
+	case *parser.ExprBalanceContext:
+		addr, err := p.VisitBalance(c.GetBal())
+		if err != nil {
+			return 0, nil, err
+		}
+		if push {
+			p.PushAddress(*addr)
+		}
+		return core.TYPE_MONETARY, addr, nil
 	default:
 		return 0, nil, InternalError(c)
 	}
 }
 
+// allocates the balance of an account, which is then needed before execution
+func (p *parseVisitor) VisitBalance(c parser.IBalanceContext) (*core.Address, *CompileError) {
+	acc_ty, acc, err := p.VisitExpr(c.GetAcc(), false)
+	if err != nil {
+		return nil, err
+	}
+	if acc_ty != core.TYPE_ACCOUNT {
+		return nil, LogicError(c, errors.New("wrong type: expected account for balance"))
+	}
+	if p.isWorld(*acc) {
+		return nil, LogicError(c, errors.New("cannot read the balance of world"))
+	}
+	asset_ty, asset, err := p.VisitExpr(c.GetAsset(), false)
+	if err != nil {
+		return nil, err
+	}
+	if asset_ty != core.TYPE_ASSET {
+		return nil, LogicError(c, errors.New("wrong type: expected asset for balance"))
+	}
+	addr, rerr := p.AllocateResource(program.AccountBalance{Account: *acc, Asset: *asset})
+	if rerr != nil {
+		return nil, LogicError(c, rerr)
+	}
+	p.addNeededBalance(*acc, *asset)
+	return addr, nil
+}
+
 // pushes a value from a literal onto the stack
 func (p *parseVisitor) VisitLit(c parser.ILiteralContext, push bool) (core.Type, *core.Address, *CompileError) {
 	switch c := c.(type) {
@@ -176,9 +268,33 @@ func (p *parseVisitor) VisitLit(c parser.ILiteralContext, push bool) (core.Type,
 			p.PushAddress(*addr)
 		}
 		return core.TYPE_STRING, addr, nil
+	case *parser.LitBoolContext:
+		addr, err := p.AllocateResource(program.Constant{
+			Inner: core.Bool(c.GetText() == "true"),
+		})
+		if err != nil {
+			return 0, nil, LogicError(c, err)
+		}
+		if push {
+			p.PushAddress(*addr)
+		}
+		return core.TYPE_BOOL, addr, nil
+	case *parser.LitPortionContext:
+		portion, err := core.ParsePortionSpecific(c.GetText())
+		if err != nil {
+			return 0, nil, LogicError(c, err)
+		}
+		addr, err := p.AllocateResource(program.Constant{Inner: *portion})
+		if err != nil {
+			return 0, nil, LogicError(c, err)
+		}
+		if push {
+			p.PushAddress(*addr)
+		}
+		return core.TYPE_PORTION, addr, nil
 	case *parser.LitMonetaryContext:
 		asset := c.Monetary().GetAsset().GetText()
-		amt, err := strconv.ParseUint(c.Monetary().GetAmt().GetText(), 10, 64)
+		amt, err := core.ParseMonetaryInt(c.Monetary().GetAmt().GetText())
 		if err != nil {
 			return 0, nil, LogicError(c, err)
 		}
@@ -210,7 +326,7 @@ func (p *parseVisitor) VisitSend(c *parser.SendContext) *CompileError {
 			return LogicError(c, err)
 		}
 		asset_addr = *addr
-		accounts, cerr := p.VisitValueAwareSource(c.GetSrc(), func() {
+		accounts, cerr := p.VisitValueAwareSource(c.GetSrc(), asset_addr, func() {
 			p.PushAddress(*addr)
 		}, nil)
 		if cerr != nil {
@@ -226,25 +342,43 @@ func (p *parseVisitor) VisitSend(c *parser.SendContext) *CompileError {
 		if ty != core.TYPE_MONETARY {
 			return LogicError(c, errors.New("wrong type for monetary value"))
 		}
-		asset_addr = *mon_addr
-		accounts, err := p.VisitValueAwareSource(c.GetSrc(), func() {
+		push_mon := func() {
 			p.PushAddress(*mon_addr)
+		}
+		if mon_addr == nil {
+			// a computed amount is compiled once, and its code emitted wherever it is needed
+			start := len(p.instructions)
+			_, _, err = p.VisitExpr(c.GetMon(), true)
+			if err != nil {
+				return err
+			}
+			mon_code := append([]byte{}, p.instructions[start:]...)
+			p.instructions = p.instructions[:start]
+			push_mon = func() {
+				p.instructions = append(p.instructions, mon_code...)
+			}
+			mon_addr, err = p.monetaryAssetAddress(c.GetMon())
+			if err != nil {
+				return err
+			}
+		}
+		asset_addr = *mon_addr
+		accounts, err := p.VisitValueAwareSource(c.GetSrc(), asset_addr, func() {
+			push_mon()
 			p.instructions = append(p.instructions, program.OP_ASSET)
-		}, mon_addr)
+		}, push_mon)
 		if err != nil {
 			return err
 		}
 		needed_accounts = accounts
 	}
+	// balances are resolved before the asset of a balance is known
+	if bal, ok := p.resources[asset_addr].(program.AccountBalance); ok {
+		asset_addr = bal.Asset
+	}
 	// add source accounts to the needed balances
 	for acc := range needed_accounts {
-		if b, ok := p.needed_balances[acc]; ok {
-			b[asset_addr] = struct{}{}
-		} else {
-			p.needed_balances[acc] = map[core.Address]struct{}{
-				asset_addr: {},
-			}
-		}
+		p.addNeededBalance(acc, asset_addr)
 	}
 	err := p.VisitDestination(c.GetDest())
 	if err != nil {
@@ -270,6 +404,64 @@ func (p *parseVisitor) VisitSetTxMeta(ctx *parser.SetTxMetaContext) *CompileErro
 	return nil
 }
 
+// set_account_meta statement
+func (p *parseVisitor) VisitSetAccountMeta(ctx *parser.SetAccountMetaContext) *CompileError {
+	_, _, err := p.VisitExpr(ctx.GetValue(), true)
+	if err != nil {
+		return err
+	}
+
+	ty, _, err := p.VisitExpr(ctx.GetAcc(), true)
+	if err != nil {
+		return err
+	}
+	if ty != core.TYPE_ACCOUNT {
+		return LogicError(ctx, errors.New("wrong type: expected account for metadata"))
+	}
+
+	keyAddr, _ := p.AllocateResource(program.Constant{
+		Inner: core.String(strings.Trim(ctx.GetKey().GetText(), `"`)),
+	})
+	p.PushAddress(*keyAddr)
+
+	p.instructions = append(p.instructions, program.OP_ACCOUNT_META)
+
+	return nil
+}
+
+// fail statement, with an optional reason
+func (p *parseVisitor) VisitFail(ctx *parser.FailContext) {
+	if ctx.GetReason() == nil {
+		p.instructions = append(p.instructions, program.OP_FAIL)
+		return
+	}
+	reasonAddr, _ := p.AllocateResource(program.Constant{
+		Inner: core.String(strings.Trim(ctx.GetReason().GetText(), `"`)),
+	})
+	p.PushAddress(*reasonAddr)
+	p.instructions = append(p.instructions, program.OP_FAIL_REASON)
+}
+
+// assert statement
+func (p *parseVisitor) VisitAssert(ctx *parser.AssertContext) *CompileError {
+	ty, _, err := p.VisitExpr(ctx.GetCond(), true)
+	if err != nil {
+		return err
+	}
+	if ty != core.TYPE_BOOL {
+		return LogicError(ctx, errors.New("wrong type: expected bool for assertion"))
+	}
+
+	reasonAddr, _ := p.AllocateResource(program.Constant{
+		Inner: core.String(strings.Trim(ctx.GetReason().GetText(), `"`)),
+	})
+	p.PushAddress(*reasonAddr)
+
+	p.instructions = append(p.instructions, program.OP_ASSERT)
+
+	return nil
+}
+
 // print statement
 func (p *parseVisitor) VisitPrint(ctx *parser.PrintContext) *CompileError {
 	_, _, err := p.VisitExpr(ctx.GetExpr(), true)
@@ -304,13 +496,15 @@ func (p *parseVisitor) VisitVars(c *parser.VarListDeclContext) *CompileError {
 			ty = core.TYPE_MONETARY
 		case "portion":
 			ty = core.TYPE_PORTION
+		case "bool":
+			ty = core.TYPE_BOOL
 		default:
 			return InternalError(c)
 		}
 
 		var addr core.Address
-		c_orig := v.GetOrig()
-		if c_orig != nil {
+		switch c_orig := v.GetOrig().(type) {
+		case *parser.OriginAccountMetaContext:
 			src_ty, src, cerr := p.VisitExpr(c_orig.GetAcc(), false)
 			if cerr != nil {
 				return cerr
@@ -324,18 +518,72 @@ func (p *parseVisitor) VisitVars(c *parser.VarListDeclContext) *CompileError {
 				return LogicError(c_orig, err)
 			}
 			addr = *a
-		} else {
+		case *parser.OriginAccountBalanceContext:
+			if ty != core.TYPE_MONETARY {
+				return LogicError(c_orig, errors.New("wrong type: a balance is monetary"))
+			}
+			a, cerr := p.VisitBalance(c_orig.GetBal())
+			if cerr != nil {
+				return cerr
+			}
+			addr = *a
+		case nil:
 			a, err := p.AllocateResource(program.Parameter{Typ: ty, Name: name})
 			if err != nil {
-				return LogicError(c_orig, err)
+				return LogicError(v, err)
 			}
 			addr = *a
+		default:
+			return InternalError(c_orig)
 		}
 		p.var_idx[name] = addr
 	}
 	return nil
 }
 
+// compiles a statement, mapping its instructions to its source code
+func (p *parseVisitor) VisitStatement(stmt parser.IStatementContext) *CompileError {
+	p.PushSource(stmt)
+	switch c := stmt.(type) {
+	case *parser.PrintContext:
+		err := p.VisitPrint(c)
+		if err != nil {
+			return err
+		}
+	case *parser.FailContext:
+		p.VisitFail(c)
+	case *parser.AssertContext:
+		err := p.VisitAssert(c)
+		if err != nil {
+			return err
+		}
+	case *parser.SendContext:
+		err := p.VisitSend(c)
+		if err != nil {
+			return err
+		}
+	case *parser.SetTxMetaContext:
+		err := p.VisitSetTxMeta(c)
+		if err != nil {
+			return err
+		}
+	case *parser.SetAccountMetaContext:
+		err := p.VisitSetAccountMeta(c)
+		if err != nil {
+			return err
+		}
+	case *parser.IfContext:
+		err := p.VisitIf(c.GetStmt())
+		if err != nil {
+			return err
+		}
+	default:
+		return InternalError(c)
+	}
+	p.PopSource()
+	return nil
+}
+
 func (p *parseVisitor) VisitScript(c parser.IScriptContext) *CompileError {
 	switch c := c.(type) {
 	case *parser.ScriptContext:
@@ -352,26 +600,9 @@ func (p *parseVisitor) VisitScript(c parser.IScriptContext) *CompileError {
 			}
 		}
 		for _, stmt := range c.GetStmts() {
-			switch c := stmt.(type) {
-			case *parser.PrintContext:
-				err := p.VisitPrint(c)
-				if err != nil {
-					return err
-				}
-			case *parser.FailContext:
-				p.instructions = append(p.instructions, program.OP_FAIL)
-			case *parser.SendContext:
-				err := p.VisitSend(c)
-				if err != nil {
-					return err
-				}
-			case *parser.SetTxMetaContext:
-				err := p.VisitSetTxMeta(c)
-				if err != nil {
-					return err
-				}
-			default:
-				return InternalError(c)
+			err := p.VisitStatement(stmt)
+			if err != nil {
+				return err
 			}
 		}
 	default:
@@ -434,6 +665,7 @@ func CompileFull(input string) CompileArtifacts {
 		Instructions:   visitor.instructions,
 		Resources:      visitor.resources,
 		NeededBalances: visitor.needed_balances,
+		SourceMap:      visitor.source_map,
 	}
 
 	return artifacts
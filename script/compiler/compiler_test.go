@@ -85,6 +85,8 @@ func checkResourceEquals(t *testing.T, res program.Resource, expected program.Re
 		return res.SourceAccount == e.SourceAccount &&
 			res.Key == e.Key &&
 			res.Typ == e.Typ
+	case program.AccountBalance:
+		return res == expected.(program.AccountBalance)
 	default:
 		panic("invalid resource")
 	}
@@ -122,6 +124,101 @@ func TestCompositeExpr(t *testing.T) {
 	})
 }
 
+func TestIf(t *testing.T) {
+	test(t, TestCase{
+		Case: `vars {
+			number $n
+		}
+		if $n >= 10 {
+			print "big"
+		} else {
+			fail
+		}`,
+		Expected: CaseResult{
+			Instructions: []byte{
+				program.OP_APUSH, 00, 00,
+				program.OP_IPUSH, 10, 00, 00, 00, 00, 00, 00, 00,
+				program.OP_LT,
+				program.OP_NOT,
+				program.OP_JUMP_IF_FALSE, 24, 00,
+				program.OP_APUSH, 01, 00,
+				program.OP_PRINT,
+				program.OP_JUMP, 25, 00,
+				program.OP_FAIL,
+			},
+			Resources: []program.Resource{
+				program.Parameter{Typ: core.TYPE_NUMBER, Name: "n"},
+				program.Constant{Inner: core.String("big")},
+			},
+			Error: "",
+		},
+	})
+}
+
+func TestIfElseIf(t *testing.T) {
+	test(t, TestCase{
+		Case: `vars {
+			bool $a
+			bool $b
+		}
+		if $a && !$b {
+			print 1
+		} else if ($a || $b) == true {
+			print 2
+		}
+		print 3`,
+		Expected: CaseResult{
+			Instructions: []byte{
+				program.OP_APUSH, 00, 00,
+				program.OP_APUSH, 01, 00,
+				program.OP_NOT,
+				program.OP_AND,
+				program.OP_JUMP_IF_FALSE, 24, 00,
+				program.OP_IPUSH, 01, 00, 00, 00, 00, 00, 00, 00,
+				program.OP_PRINT,
+				program.OP_JUMP, 48, 00,
+				program.OP_APUSH, 00, 00,
+				program.OP_APUSH, 01, 00,
+				program.OP_OR,
+				program.OP_APUSH, 02, 00,
+				program.OP_EQ,
+				program.OP_JUMP_IF_FALSE, 48, 00,
+				program.OP_IPUSH, 02, 00, 00, 00, 00, 00, 00, 00,
+				program.OP_PRINT,
+				program.OP_IPUSH, 03, 00, 00, 00, 00, 00, 00, 00,
+				program.OP_PRINT,
+			},
+			Resources: []program.Resource{
+				program.Parameter{Typ: core.TYPE_BOOL, Name: "a"},
+				program.Parameter{Typ: core.TYPE_BOOL, Name: "b"},
+				program.Constant{Inner: core.Bool(true)},
+			},
+			Error: "",
+		},
+	})
+}
+
+func TestIfWrongType(t *testing.T) {
+	for _, cond := range []string{
+		`1 + 1`,
+		`1 == [GEM 1]`,
+		`@a < @b`,
+		`!1`,
+		`true && 1`,
+	} {
+		test(t, TestCase{
+			Case: "if " + cond + ` {
+				print 1
+			}`,
+			Expected: CaseResult{
+				Instructions: nil,
+				Resources:    nil,
+				Error:        "wrong type",
+			},
+		})
+	}
+}
+
 func TestFail(t *testing.T) {
 	test(t, TestCase{
 		Case: "fail",
@@ -133,6 +230,152 @@ func TestFail(t *testing.T) {
 	})
 }
 
+func TestFailReason(t *testing.T) {
+	test(t, TestCase{
+		Case: `fail "out of stock"`,
+		Expected: CaseResult{
+			Instructions: []byte{
+				program.OP_APUSH, 00, 00,
+				program.OP_FAIL_REASON,
+			},
+			Resources: []program.Resource{
+				program.Constant{Inner: core.String("out of stock")},
+			},
+			Error: "",
+		},
+	})
+}
+
+func TestAssert(t *testing.T) {
+	test(t, TestCase{
+		Case: `vars {
+			monetary $amount
+		}
+		assert $amount <= [USD/2 100000], "amount above limit"`,
+		Expected: CaseResult{
+			Instructions: []byte{
+				program.OP_APUSH, 00, 00,
+				program.OP_APUSH, 01, 00,
+				program.OP_GT,
+				program.OP_NOT,
+				program.OP_APUSH, 02, 00,
+				program.OP_ASSERT,
+			},
+			Resources: []program.Resource{
+				program.Parameter{Typ: core.TYPE_MONETARY, Name: "amount"},
+				program.Constant{Inner: core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(100000)}},
+				program.Constant{Inner: core.String("amount above limit")},
+			},
+			Error: "",
+		},
+	})
+}
+
+func TestAssertWrongType(t *testing.T) {
+	test(t, TestCase{
+		Case: `assert [USD/2 100], "not a condition"`,
+		Expected: CaseResult{
+			Instructions: nil,
+			Resources:    nil,
+			Error:        "wrong type: expected bool for assertion",
+		},
+	})
+}
+
+func TestMonetaryArithmetic(t *testing.T) {
+	test(t, TestCase{
+		Case: `vars {
+			monetary $amount
+		}
+		print ($amount - [USD/2 100]) * 3 + $amount * 2.5% rounded up`,
+		Expected: CaseResult{
+			Instructions: []byte{
+				program.OP_APUSH, 00, 00,
+				program.OP_APUSH, 01, 00,
+				program.OP_MONETARY_SUB,
+				program.OP_IPUSH, 03, 00, 00, 00, 00, 00, 00, 00,
+				program.OP_MONETARY_MUL,
+				program.OP_APUSH, 00, 00,
+				program.OP_APUSH, 02, 00,
+				program.OP_MONETARY_MUL_UP,
+				program.OP_MONETARY_ADD,
+				program.OP_PRINT,
+			},
+			Resources: []program.Resource{
+				program.Parameter{Typ: core.TYPE_MONETARY, Name: "amount"},
+				program.Constant{Inner: core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(100)}},
+				program.Constant{Inner: core.Portion{Specific: big.NewRat(1, 40)}},
+			},
+			Error: "",
+		},
+	})
+}
+
+func TestSendComputedAmount(t *testing.T) {
+	test(t, TestCase{
+		Case: `vars {
+			monetary $amount
+		}
+		send $amount * 1/2 rounded down (
+			source = @a
+			destination = @b
+		)`,
+		Expected: CaseResult{
+			Instructions: []byte{
+				program.OP_APUSH, 02, 00,
+				program.OP_APUSH, 00, 00,
+				program.OP_APUSH, 01, 00,
+				program.OP_MONETARY_MUL_DOWN,
+				program.OP_ASSET,
+				program.OP_TAKE_ALL,
+				program.OP_APUSH, 00, 00,
+				program.OP_APUSH, 01, 00,
+				program.OP_MONETARY_MUL_DOWN,
+				program.OP_TAKE,
+				program.OP_IPUSH, 01, 00, 00, 00, 00, 00, 00, 00,
+				program.OP_BUMP,
+				program.OP_REPAY,
+				program.OP_FUNDING_SUM,
+				program.OP_TAKE,
+				program.OP_APUSH, 03, 00,
+				program.OP_SEND,
+				program.OP_REPAY,
+			},
+			Resources: []program.Resource{
+				program.Parameter{Typ: core.TYPE_MONETARY, Name: "amount"},
+				program.Constant{Inner: core.Portion{Specific: big.NewRat(1, 2)}},
+				program.Constant{Inner: core.Account("a")},
+				program.Constant{Inner: core.Account("b")},
+			},
+			Error: "",
+		},
+	})
+}
+
+func TestMonetaryArithmeticErrors(t *testing.T) {
+	for _, c := range []struct {
+		Expr  string
+		Error string
+	}{
+		{`[USD/2 10] + [EUR/2 10]`, "asset mismatch: cannot add USD/2 and EUR/2"},
+		{`balance(@a, USD/2) - [EUR/2 10]`, "asset mismatch: cannot subtract EUR/2 from USD/2"},
+		{`[USD/2 10] + 1`, "wrong type"},
+		{`2 * [USD/2 10]`, "wrong type"},
+		{`[USD/2 10] * @a`, "wrong type"},
+		{`[USD/2 10] * 10%`, "missing rounding mode"},
+		{`[USD/2 10] * 2 rounded up`, "rounding mode is only allowed"},
+	} {
+		test(t, TestCase{
+			Case: "print " + c.Expr,
+			Expected: CaseResult{
+				Instructions: nil,
+				Resources:    nil,
+				Error:        c.Error,
+			},
+		})
+	}
+}
+
 func TestCRLF(t *testing.T) {
 	test(t, TestCase{
 		Case: "print @a\r\nprint @b",
@@ -150,6 +393,27 @@ func TestCRLF(t *testing.T) {
 	})
 }
 
+func TestSourceMap(t *testing.T) {
+	p, err := Compile("print 1\nsend [COIN 10] (\n\tsource = @a\n\tdestination = @b\n)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := program.SourceMap{
+		{Offset: 0, Span: program.SourceSpan{Startl: 1, Startc: 0, Endl: 1, Endc: 7}},
+		{Offset: 10, Span: program.SourceSpan{Startl: 3, Startc: 10, Endl: 3, Endc: 12}},
+		{Offset: 33, Span: program.SourceSpan{Startl: 4, Startc: 15, Endl: 4, Endc: 17}},
+		{Offset: 39, Span: program.SourceSpan{Startl: 2, Startc: 0, Endl: 5, Endc: 1}},
+	}
+	if len(p.SourceMap) != len(expected) {
+		t.Fatalf("unexpected source map: %v", p.SourceMap)
+	}
+	for i, entry := range expected {
+		if p.SourceMap[i] != entry {
+			t.Fatalf("unexpected source map entry %d: %v", i, p.SourceMap[i])
+		}
+	}
+}
+
 func TestConstant(t *testing.T) {
 	user := core.Account("user:U001")
 	test(t, TestCase{
@@ -214,6 +478,47 @@ func TestSetTxMetaVars(t *testing.T) {
 	})
 }
 
+func TestSetAccountMeta(t *testing.T) {
+	test(t, TestCase{
+		Case: `
+		set_account_meta(@orders:123, "status", "paid")
+		`,
+		Expected: CaseResult{
+			Instructions: []byte{
+				program.OP_APUSH, 00, 00,
+				program.OP_APUSH, 01, 00,
+				program.OP_APUSH, 02, 00,
+				program.OP_ACCOUNT_META,
+			},
+			Resources: []program.Resource{
+				program.Constant{
+					Inner: core.String("paid"),
+				},
+				program.Constant{
+					Inner: core.Account("orders:123"),
+				},
+				program.Constant{
+					Inner: core.String("status"),
+				},
+			},
+			Error: "",
+		},
+	})
+}
+
+func TestSetAccountMetaWrongType(t *testing.T) {
+	test(t, TestCase{
+		Case: `
+		set_account_meta(GEM, "status", "paid")
+		`,
+		Expected: CaseResult{
+			Instructions: nil,
+			Resources:    nil,
+			Error:        "wrong type",
+		},
+	})
+}
+
 func TestComments(t *testing.T) {
 	test(t, TestCase{
 		Case: `
@@ -335,7 +640,7 @@ func TestDestinationAllotment(t *testing.T) {
 			Resources: []program.Resource{
 				program.Constant{Inner: core.Monetary{
 					Asset:  "EUR/2",
-					Amount: 43,
+					Amount: core.NewMonetaryInt(43),
 				}},
 				program.Constant{Inner: core.Account("foo")},
 				program.Constant{Inner: core.Portion{Specific: big.NewRat(7, 8)}},
@@ -412,12 +717,12 @@ func TestDestinationInOrder(t *testing.T) {
 			Resources: []program.Resource{
 				program.Constant{Inner: core.Monetary{
 					Asset:  "COIN",
-					Amount: 50,
+					Amount: core.NewMonetaryInt(50),
 				}},
 				program.Constant{Inner: core.Account("a")},
 				program.Constant{Inner: core.Monetary{
 					Asset:  "COIN",
-					Amount: 10,
+					Amount: core.NewMonetaryInt(10),
 				}},
 				program.Constant{Inner: core.Account("b")},
 				program.Constant{Inner: core.Account("c")},
@@ -442,7 +747,7 @@ func TestAllocationPercentages(t *testing.T) {
 			Resources: []program.Resource{
 				program.Constant{Inner: core.Monetary{
 					Asset:  "EUR/2",
-					Amount: 43,
+					Amount: core.NewMonetaryInt(43),
 				}},
 				program.Constant{Inner: core.Account("foo")},
 				program.Constant{Inner: core.Portion{Specific: big.NewRat(1, 2)}},
@@ -482,7 +787,7 @@ func TestSend(t *testing.T) {
 				program.OP_SEND,  // [EUR/2]
 				program.OP_REPAY, //
 			}, Resources: []program.Resource{
-				program.Constant{Inner: core.Monetary{Asset: "EUR/2", Amount: 99}},
+				program.Constant{Inner: core.Monetary{Asset: "EUR/2", Amount: core.NewMonetaryInt(99)}},
 				program.Constant{Inner: alice},
 				program.Constant{Inner: bob}},
 			Error: "",
@@ -535,7 +840,7 @@ func TestMetadata(t *testing.T) {
 				program.Parameter{Typ: core.TYPE_ACCOUNT, Name: "sale"},
 				program.Metadata{Typ: core.TYPE_ACCOUNT, SourceAccount: core.NewAddress(0), Key: "seller"},
 				program.Metadata{Typ: core.TYPE_PORTION, SourceAccount: core.NewAddress(1), Key: "commission"},
-				program.Constant{Inner: core.Monetary{Asset: "EUR/2", Amount: 53}},
+				program.Constant{Inner: core.Monetary{Asset: "EUR/2", Amount: core.NewMonetaryInt(53)}},
 				program.Constant{Inner: core.NewPortionRemaining()},
 				program.Constant{Inner: core.Account("platform")},
 			},
@@ -644,6 +949,189 @@ func TestPreventSourceAlreadyEmptied(t *testing.T) {
 	})
 }
 
+func TestOverdraft(t *testing.T) {
+	test(t, TestCase{
+		Case: `send [GEM 1000] (
+			source = {
+				@a allowing overdraft up to [GEM 100]
+				@b allowing unbounded overdraft
+			}
+			destination = @out
+		)`,
+		Expected: CaseResult{
+			Resources: []program.Resource{
+				program.Constant{Inner: core.Monetary{Asset: "GEM", Amount: core.NewMonetaryInt(1000)}},
+				program.Constant{Inner: core.Account("a")},
+				program.Constant{Inner: core.Monetary{Asset: "GEM", Amount: core.NewMonetaryInt(100)}},
+				program.Constant{Inner: core.Account("b")},
+				program.Constant{Inner: core.Account("out")},
+			},
+		},
+	})
+}
+
+func TestPreventOverdraftOfWorld(t *testing.T) {
+	test(t, TestCase{
+		Case: `send [GEM 1000] (
+			source = @world allowing overdraft up to [GEM 100]
+			destination = @out
+		)`,
+		Expected: CaseResult{
+			Instructions: nil,
+			Resources:    nil,
+			Error:        "world",
+		},
+	})
+}
+
+func TestPreventAddToUnboundedOverdraft(t *testing.T) {
+	test(t, TestCase{
+		Case: `send [GEM 1000] (
+			source = {
+				@a allowing unbounded overdraft
+				@b
+			}
+			destination = @out
+		)`,
+		Expected: CaseResult{
+			Instructions: nil,
+			Resources:    nil,
+			Error:        "last position",
+		},
+	})
+}
+
+func TestPreventTakeAllFromUnboundedOverdraft(t *testing.T) {
+	test(t, TestCase{
+		Case: `send [GEM *] (
+			source = @a allowing unbounded overdraft
+			destination = @out
+		)`,
+		Expected: CaseResult{
+			Instructions: nil,
+			Resources:    nil,
+			Error:        "cannot",
+		},
+	})
+}
+
+func TestWrongTypeOverdraft(t *testing.T) {
+	test(t, TestCase{
+		Case: `send [GEM 1000] (
+			source = @a allowing overdraft up to @b
+			destination = @out
+		)`,
+		Expected: CaseResult{
+			Instructions: nil,
+			Resources:    nil,
+			Error:        "wrong type",
+		},
+	})
+}
+
+func TestOverdraftAssetMismatch(t *testing.T) {
+	for _, c := range []string{
+		`send [GEM 1000] (
+			source = @a allowing overdraft up to [COIN 10]
+			destination = @out
+		)`,
+		`send [GEM *] (
+			source = {
+				@a allowing overdraft up to [COIN 10]
+				@b
+			}
+			destination = @out
+		)`,
+	} {
+		test(t, TestCase{
+			Case: c,
+			Expected: CaseResult{
+				Instructions: nil,
+				Resources:    nil,
+				Error:        "asset mismatch: overdraft of COIN on GEM",
+			},
+		})
+	}
+	// the asset of a variable is only checked during execution
+	_, err := Compile(`vars {
+		monetary $max
+	}
+	send [GEM 1000] (
+		source = @a allowing overdraft up to $max
+		destination = @out
+	)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBalance(t *testing.T) {
+	script := `vars {
+		monetary $bal = balance(@a, GEM)
+	}
+	send [GEM *] (
+		source = max balance(@b, GEM) from @c
+		destination = @out
+	)
+	print $bal`
+	test(t, TestCase{
+		Case: script,
+		Expected: CaseResult{
+			Resources: []program.Resource{
+				program.Constant{Inner: core.Account("a")},
+				program.Constant{Inner: core.Asset("GEM")},
+				program.AccountBalance{Account: 0, Asset: 1},
+				program.Constant{Inner: core.Account("c")},
+				program.Constant{Inner: core.Account("b")},
+				program.AccountBalance{Account: 4, Asset: 1},
+				program.Constant{Inner: core.Account("out")},
+			},
+		},
+	})
+	p, err := Compile(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, acc := range []core.Address{0, 3, 4} {
+		if _, ok := p.NeededBalances[acc][1]; !ok {
+			t.Errorf("balance of #%d is not needed: %v", acc, p.NeededBalances)
+		}
+	}
+}
+
+func TestBalanceOfWorld(t *testing.T) {
+	test(t, TestCase{
+		Case: `print balance(@world, GEM)`,
+		Expected: CaseResult{
+			Instructions: nil,
+			Resources:    nil,
+			Error:        "world",
+		},
+	})
+}
+
+func TestBalanceWrongType(t *testing.T) {
+	test(t, TestCase{
+		Case: `print balance(@a, [GEM 10])`,
+		Expected: CaseResult{
+			Instructions: nil,
+			Resources:    nil,
+			Error:        "wrong type",
+		},
+	})
+	test(t, TestCase{
+		Case: `vars {
+			number $n = balance(@a, GEM)
+		}
+		print $n`,
+		Expected: CaseResult{
+			Instructions: nil,
+			Resources:    nil,
+			Error:        "wrong type",
+		},
+	})
+}
+
 func TestPreventTakeAllFromAllocation(t *testing.T) {
 	test(t, TestCase{
 		Case: `send [GEM *] (
@@ -0,0 +1,127 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/script/parser"
+	"github.com/numary/machine/vm/program"
+)
+
+// Appends a jump whose target is set later with SetJumpTarget,
+// and returns the position of its operand.
+func (p *parseVisitor) PushJump(op byte) int {
+	p.instructions = append(p.instructions, op, 0, 0)
+	return len(p.instructions) - 2
+}
+
+// Makes the jump whose operand is at the given position go to the next instruction
+func (p *parseVisitor) SetJumpTarget(c antlr.ParserRuleContext, operand int) *CompileError {
+	target := len(p.instructions)
+	if target > 0xffff {
+		return LogicError(c, fmt.Errorf("program exceeded %v bytes of instructions", 0xffff))
+	}
+	copy(p.instructions[operand:], core.Address(target).ToBytes())
+	return nil
+}
+
+// comparison of two values of the same type, only numbers and monetaries being ordered
+func (p *parseVisitor) VisitComparison(c *parser.ExprComparisonContext, push bool) (core.Type, *core.Address, *CompileError) {
+	lhs, _, err := p.VisitExpr(c.GetLhs(), push)
+	if err != nil {
+		return 0, nil, err
+	}
+	rhs, _, err := p.VisitExpr(c.GetRhs(), push)
+	if err != nil {
+		return 0, nil, err
+	}
+	if lhs != rhs {
+		return 0, nil, LogicError(c, fmt.Errorf("wrong type: cannot compare %v with %v", lhs, rhs))
+	}
+	op := c.GetOp().GetTokenType()
+	if op != parser.NumScriptLexerOP_EQ && op != parser.NumScriptLexerOP_NEQ &&
+		lhs != core.TYPE_NUMBER && lhs != core.TYPE_MONETARY {
+		return 0, nil, LogicError(c, fmt.Errorf("wrong type: cannot order values of type %v", lhs))
+	}
+	if push {
+		switch op {
+		case parser.NumScriptLexerOP_EQ:
+			p.instructions = append(p.instructions, program.OP_EQ)
+		case parser.NumScriptLexerOP_NEQ:
+			p.instructions = append(p.instructions, program.OP_EQ, program.OP_NOT)
+		case parser.NumScriptLexerOP_LT:
+			p.instructions = append(p.instructions, program.OP_LT)
+		case parser.NumScriptLexerOP_LTE:
+			p.instructions = append(p.instructions, program.OP_GT, program.OP_NOT)
+		case parser.NumScriptLexerOP_GT:
+			p.instructions = append(p.instructions, program.OP_GT)
+		case parser.NumScriptLexerOP_GTE:
+			p.instructions = append(p.instructions, program.OP_LT, program.OP_NOT)
+		}
+	}
+	return core.TYPE_BOOL, nil, nil
+}
+
+// boolean operator, both operands being evaluated
+func (p *parseVisitor) VisitBoolOp(c antlr.ParserRuleContext, lhs parser.IExpressionContext, rhs parser.IExpressionContext, op byte, push bool) (core.Type, *core.Address, *CompileError) {
+	for _, operand := range []parser.IExpressionContext{lhs, rhs} {
+		ty, _, err := p.VisitExpr(operand, push)
+		if err != nil {
+			return 0, nil, err
+		}
+		if ty != core.TYPE_BOOL {
+			return 0, nil, LogicError(c, errors.New("wrong type: expected bool for boolean operator"))
+		}
+	}
+	if push {
+		p.instructions = append(p.instructions, op)
+	}
+	return core.TYPE_BOOL, nil, nil
+}
+
+// if statement: jumps over the branches which aren't taken
+func (p *parseVisitor) VisitIf(c parser.IIfStatementContext) *CompileError {
+	ty, _, err := p.VisitExpr(c.GetCond(), true)
+	if err != nil {
+		return err
+	}
+	if ty != core.TYPE_BOOL {
+		return LogicError(c.GetCond(), errors.New("wrong type: expected bool for condition"))
+	}
+	to_else := p.PushJump(program.OP_JUMP_IF_FALSE)
+	err = p.VisitStatementBlock(c.GetThen())
+	if err != nil {
+		return err
+	}
+	if c.GetOtherwise() == nil && c.GetElseIf() == nil {
+		return p.SetJumpTarget(c, to_else)
+	}
+	to_end := p.PushJump(program.OP_JUMP)
+	err = p.SetJumpTarget(c, to_else)
+	if err != nil {
+		return err
+	}
+	if otherwise := c.GetOtherwise(); otherwise != nil {
+		err = p.VisitStatementBlock(otherwise)
+	} else {
+		p.PushSource(c.GetElseIf())
+		err = p.VisitIf(c.GetElseIf())
+		p.PopSource()
+	}
+	if err != nil {
+		return err
+	}
+	return p.SetJumpTarget(c, to_end)
+}
+
+func (p *parseVisitor) VisitStatementBlock(c parser.IStatementBlockContext) *CompileError {
+	for _, stmt := range c.GetStmts() {
+		err := p.VisitStatement(stmt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
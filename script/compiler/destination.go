@@ -18,6 +18,8 @@ func (p *parseVisitor) VisitDestination(c parser.IDestinationContext) *CompileEr
 }
 
 func (p *parseVisitor) VisitDestinationRecursive(c parser.IDestinationContext) *CompileError {
+	p.PushSource(c)
+	defer p.PopSource()
 	switch c := c.(type) {
 	case *parser.DestAccountContext:
 		p.instructions = append(p.instructions, program.OP_FUNDING_SUM)
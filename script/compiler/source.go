@@ -9,13 +9,17 @@ import (
 	"github.com/numary/machine/vm/program"
 )
 
-// Returns the resource addresses of all the accounts
-func (p *parseVisitor) VisitValueAwareSource(c parser.IValueAwareSourceContext, push_asset func(), mon_addr *core.Address) (map[core.Address]struct{}, *CompileError) {
+// Returns the resource addresses of all the accounts.
+// asset_addr is the address of a resource with the asset being sent,
+// push_mon pushes the amount to take, and is nil when taking all the balance.
+func (p *parseVisitor) VisitValueAwareSource(c parser.IValueAwareSourceContext, asset_addr core.Address, push_asset func(), push_mon func()) (map[core.Address]struct{}, *CompileError) {
+	p.PushSource(c)
+	defer p.PopSource()
 	needed_accounts := map[core.Address]struct{}{}
-	is_all := mon_addr == nil
+	is_all := push_mon == nil
 	switch c := c.(type) {
 	case *parser.SrcContext:
-		accounts, _, _, err := p.VisitSource(c.Source(), push_asset, is_all)
+		accounts, _, _, err := p.VisitSource(c.Source(), asset_addr, push_asset, is_all)
 		if err != nil {
 			return nil, err
 		}
@@ -23,7 +27,7 @@ func (p *parseVisitor) VisitValueAwareSource(c parser.IValueAwareSourceContext,
 			needed_accounts[k] = v
 		}
 		if !is_all {
-			p.PushAddress(*mon_addr)
+			push_mon()
 			p.instructions = append(p.instructions, program.OP_TAKE)
 			p.PushInteger(core.Number(1))
 			p.instructions = append(p.instructions, program.OP_BUMP)
@@ -33,14 +37,14 @@ func (p *parseVisitor) VisitValueAwareSource(c parser.IValueAwareSourceContext,
 		if is_all {
 			return nil, LogicError(c, errors.New("cannot take all balance of an allotment source"))
 		}
-		p.PushAddress(*mon_addr)
+		push_mon()
 		p.VisitAllotment(c.SourceAllotment(), c.SourceAllotment().GetPortions())
 		p.instructions = append(p.instructions, program.OP_ALLOC)
 
 		sources := c.SourceAllotment().GetSources()
 		n := len(sources)
 		for i := 0; i < n; i++ {
-			accounts, _, _, err := p.VisitSource(sources[i], push_asset, is_all)
+			accounts, _, _, err := p.VisitSource(sources[i], asset_addr, push_asset, is_all)
 			if err != nil {
 				return nil, err
 			}
@@ -62,31 +66,70 @@ func (p *parseVisitor) VisitValueAwareSource(c parser.IValueAwareSourceContext,
 
 // Returns the resource addresses of all the accounts,
 // the addresses of accounts already emptied,
-// and true if the source is bottomless (contains @world)
-func (p *parseVisitor) VisitSource(c parser.ISourceContext, push_asset func(), is_all bool) (map[core.Address]struct{}, map[core.Address]struct{}, bool, *CompileError) {
+// and true if the source is bottomless (contains @world or an account with an unbounded overdraft)
+func (p *parseVisitor) VisitSource(c parser.ISourceContext, asset_addr core.Address, push_asset func(), is_all bool) (map[core.Address]struct{}, map[core.Address]struct{}, bool, *CompileError) {
+	p.PushSource(c)
+	defer p.PopSource()
 	needed_accounts := map[core.Address]struct{}{}
 	emptied_accounts := map[core.Address]struct{}{}
 	bottomless := false
 	switch c := c.(type) {
 	case *parser.SrcAccountContext:
-		ty, acc_addr, err := p.VisitExpr(c.Expression(), true)
+		ty, acc_addr, err := p.VisitExpr(c.SourceAccount().GetAccount(), true)
 		if err != nil {
 			return nil, nil, false, err
 		}
 		if ty != core.TYPE_ACCOUNT {
 			return nil, nil, false, LogicError(c, errors.New("wrong type: expected account or allocation as destination"))
 		}
-		bottomless = bottomless || p.isWorld(*acc_addr)
-		if p.isWorld(*acc_addr) && is_all {
-			return nil, nil, false, LogicError(c, errors.New("cannot take all balance of world"))
-		} else {
+		overdraft := c.SourceAccount().GetOverdraft()
+		if p.isWorld(*acc_addr) {
+			if overdraft != nil {
+				return nil, nil, false, LogicError(c, errors.New("world already allows an unbounded overdraft"))
+			}
+			if is_all {
+				return nil, nil, false, LogicError(c, errors.New("cannot take all balance of world"))
+			}
+			bottomless = true
+			push_asset()
+			p.instructions = append(p.instructions, program.OP_TAKE_ALL_UNBOUNDED)
+			break
+		}
+		needed_accounts[*acc_addr] = struct{}{}
+		emptied_accounts[*acc_addr] = struct{}{}
+		switch overdraft := overdraft.(type) {
+		case nil:
 			push_asset()
 			p.instructions = append(p.instructions, program.OP_TAKE_ALL)
-			needed_accounts[*acc_addr] = struct{}{}
-			emptied_accounts[*acc_addr] = struct{}{}
+		case *parser.SrcAccountOverdraftSpecificContext:
+			push_asset()
+			ty, _, err := p.VisitExpr(overdraft.GetSpecific(), true)
+			if err != nil {
+				return nil, nil, false, err
+			}
+			if ty != core.TYPE_MONETARY {
+				return nil, nil, false, LogicError(overdraft, errors.New("wrong type: expected monetary as overdraft"))
+			}
+			overdraft_addr, err := p.monetaryAssetAddress(overdraft.GetSpecific())
+			if err != nil {
+				return nil, nil, false, err
+			}
+			overdraft_asset, overdraft_known := p.constantAsset(*overdraft_addr)
+			asset, asset_known := p.constantAsset(asset_addr)
+			if overdraft_known && asset_known && overdraft_asset != asset {
+				return nil, nil, false, LogicError(overdraft, fmt.Errorf("asset mismatch: overdraft of %v on %v", overdraft_asset, asset))
+			}
+			p.instructions = append(p.instructions, program.OP_TAKE_ALL_OVERDRAFT)
+		case *parser.SrcAccountOverdraftUnboundedContext:
+			if is_all {
+				return nil, nil, false, LogicError(c, errors.New("cannot take all balance of an account with an unbounded overdraft"))
+			}
+			bottomless = true
+			push_asset()
+			p.instructions = append(p.instructions, program.OP_TAKE_ALL_UNBOUNDED)
 		}
 	case *parser.SrcMaxedContext:
-		accounts, _, _, err := p.VisitSource(c.SourceMaxed().GetSrc(), push_asset, false)
+		accounts, _, _, err := p.VisitSource(c.SourceMaxed().GetSrc(), asset_addr, push_asset, false)
 		if err != nil {
 			return nil, nil, false, err
 		}
@@ -108,13 +151,13 @@ func (p *parseVisitor) VisitSource(c parser.ISourceContext, push_asset func(), i
 		sources := c.SourceInOrder().GetSources()
 		n := len(sources)
 		for i := 0; i < n; i++ {
-			accounts, emptied, subsource_bottomless, err := p.VisitSource(sources[i], push_asset, is_all)
+			accounts, emptied, subsource_bottomless, err := p.VisitSource(sources[i], asset_addr, push_asset, is_all)
 			if err != nil {
 				return nil, nil, false, err
 			}
 			bottomless = bottomless || subsource_bottomless
 			if subsource_bottomless && i != n-1 {
-				return nil, nil, false, LogicError(c, errors.New("world can only be in last position of source"))
+				return nil, nil, false, LogicError(c, errors.New("world or an account with an unbounded overdraft can only be in last position of source"))
 			}
 			for k, v := range accounts {
 				needed_accounts[k] = v
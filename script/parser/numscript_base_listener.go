@@ -57,6 +57,18 @@ func (s *BaseNumScriptListener) EnterLitString(ctx *LitStringContext) {}
 // ExitLitString is called when production LitString is exited.
 func (s *BaseNumScriptListener) ExitLitString(ctx *LitStringContext) {}
 
+// EnterLitBool is called when production LitBool is entered.
+func (s *BaseNumScriptListener) EnterLitBool(ctx *LitBoolContext) {}
+
+// ExitLitBool is called when production LitBool is exited.
+func (s *BaseNumScriptListener) ExitLitBool(ctx *LitBoolContext) {}
+
+// EnterLitPortion is called when production LitPortion is entered.
+func (s *BaseNumScriptListener) EnterLitPortion(ctx *LitPortionContext) {}
+
+// ExitLitPortion is called when production LitPortion is exited.
+func (s *BaseNumScriptListener) ExitLitPortion(ctx *LitPortionContext) {}
+
 // EnterLitMonetary is called when production LitMonetary is entered.
 func (s *BaseNumScriptListener) EnterLitMonetary(ctx *LitMonetaryContext) {}
 
@@ -69,24 +81,72 @@ func (s *BaseNumScriptListener) EnterVariable(ctx *VariableContext) {}
 // ExitVariable is called when production variable is exited.
 func (s *BaseNumScriptListener) ExitVariable(ctx *VariableContext) {}
 
+// EnterBalance is called when production balance is entered.
+func (s *BaseNumScriptListener) EnterBalance(ctx *BalanceContext) {}
+
+// ExitBalance is called when production balance is exited.
+func (s *BaseNumScriptListener) ExitBalance(ctx *BalanceContext) {}
+
 // EnterExprAddSub is called when production ExprAddSub is entered.
 func (s *BaseNumScriptListener) EnterExprAddSub(ctx *ExprAddSubContext) {}
 
 // ExitExprAddSub is called when production ExprAddSub is exited.
 func (s *BaseNumScriptListener) ExitExprAddSub(ctx *ExprAddSubContext) {}
 
+// EnterExprAnd is called when production ExprAnd is entered.
+func (s *BaseNumScriptListener) EnterExprAnd(ctx *ExprAndContext) {}
+
+// ExitExprAnd is called when production ExprAnd is exited.
+func (s *BaseNumScriptListener) ExitExprAnd(ctx *ExprAndContext) {}
+
+// EnterExprComparison is called when production ExprComparison is entered.
+func (s *BaseNumScriptListener) EnterExprComparison(ctx *ExprComparisonContext) {}
+
+// ExitExprComparison is called when production ExprComparison is exited.
+func (s *BaseNumScriptListener) ExitExprComparison(ctx *ExprComparisonContext) {}
+
+// EnterExprOr is called when production ExprOr is entered.
+func (s *BaseNumScriptListener) EnterExprOr(ctx *ExprOrContext) {}
+
+// ExitExprOr is called when production ExprOr is exited.
+func (s *BaseNumScriptListener) ExitExprOr(ctx *ExprOrContext) {}
+
+// EnterExprParens is called when production ExprParens is entered.
+func (s *BaseNumScriptListener) EnterExprParens(ctx *ExprParensContext) {}
+
+// ExitExprParens is called when production ExprParens is exited.
+func (s *BaseNumScriptListener) ExitExprParens(ctx *ExprParensContext) {}
+
 // EnterExprLiteral is called when production ExprLiteral is entered.
 func (s *BaseNumScriptListener) EnterExprLiteral(ctx *ExprLiteralContext) {}
 
 // ExitExprLiteral is called when production ExprLiteral is exited.
 func (s *BaseNumScriptListener) ExitExprLiteral(ctx *ExprLiteralContext) {}
 
+// EnterExprNot is called when production ExprNot is entered.
+func (s *BaseNumScriptListener) EnterExprNot(ctx *ExprNotContext) {}
+
+// ExitExprNot is called when production ExprNot is exited.
+func (s *BaseNumScriptListener) ExitExprNot(ctx *ExprNotContext) {}
+
+// EnterExprMul is called when production ExprMul is entered.
+func (s *BaseNumScriptListener) EnterExprMul(ctx *ExprMulContext) {}
+
+// ExitExprMul is called when production ExprMul is exited.
+func (s *BaseNumScriptListener) ExitExprMul(ctx *ExprMulContext) {}
+
 // EnterExprVariable is called when production ExprVariable is entered.
 func (s *BaseNumScriptListener) EnterExprVariable(ctx *ExprVariableContext) {}
 
 // ExitExprVariable is called when production ExprVariable is exited.
 func (s *BaseNumScriptListener) ExitExprVariable(ctx *ExprVariableContext) {}
 
+// EnterExprBalance is called when production ExprBalance is entered.
+func (s *BaseNumScriptListener) EnterExprBalance(ctx *ExprBalanceContext) {}
+
+// ExitExprBalance is called when production ExprBalance is exited.
+func (s *BaseNumScriptListener) ExitExprBalance(ctx *ExprBalanceContext) {}
+
 // EnterAllotmentPortionConst is called when production allotmentPortionConst is entered.
 func (s *BaseNumScriptListener) EnterAllotmentPortionConst(ctx *AllotmentPortionConstContext) {}
 
@@ -161,6 +221,28 @@ func (s *BaseNumScriptListener) EnterSourceMaxed(ctx *SourceMaxedContext) {}
 // ExitSourceMaxed is called when production sourceMaxed is exited.
 func (s *BaseNumScriptListener) ExitSourceMaxed(ctx *SourceMaxedContext) {}
 
+// EnterSrcAccountOverdraftSpecific is called when production SrcAccountOverdraftSpecific is entered.
+func (s *BaseNumScriptListener) EnterSrcAccountOverdraftSpecific(ctx *SrcAccountOverdraftSpecificContext) {
+}
+
+// ExitSrcAccountOverdraftSpecific is called when production SrcAccountOverdraftSpecific is exited.
+func (s *BaseNumScriptListener) ExitSrcAccountOverdraftSpecific(ctx *SrcAccountOverdraftSpecificContext) {
+}
+
+// EnterSrcAccountOverdraftUnbounded is called when production SrcAccountOverdraftUnbounded is entered.
+func (s *BaseNumScriptListener) EnterSrcAccountOverdraftUnbounded(ctx *SrcAccountOverdraftUnboundedContext) {
+}
+
+// ExitSrcAccountOverdraftUnbounded is called when production SrcAccountOverdraftUnbounded is exited.
+func (s *BaseNumScriptListener) ExitSrcAccountOverdraftUnbounded(ctx *SrcAccountOverdraftUnboundedContext) {
+}
+
+// EnterSourceAccount is called when production sourceAccount is entered.
+func (s *BaseNumScriptListener) EnterSourceAccount(ctx *SourceAccountContext) {}
+
+// ExitSourceAccount is called when production sourceAccount is exited.
+func (s *BaseNumScriptListener) ExitSourceAccount(ctx *SourceAccountContext) {}
+
 // EnterSrcAccount is called when production SrcAccount is entered.
 func (s *BaseNumScriptListener) EnterSrcAccount(ctx *SrcAccountContext) {}
 
@@ -197,6 +279,18 @@ func (s *BaseNumScriptListener) EnterSrcAllotment(ctx *SrcAllotmentContext) {}
 // ExitSrcAllotment is called when production SrcAllotment is exited.
 func (s *BaseNumScriptListener) ExitSrcAllotment(ctx *SrcAllotmentContext) {}
 
+// EnterStatementBlock is called when production statementBlock is entered.
+func (s *BaseNumScriptListener) EnterStatementBlock(ctx *StatementBlockContext) {}
+
+// ExitStatementBlock is called when production statementBlock is exited.
+func (s *BaseNumScriptListener) ExitStatementBlock(ctx *StatementBlockContext) {}
+
+// EnterIfStatement is called when production ifStatement is entered.
+func (s *BaseNumScriptListener) EnterIfStatement(ctx *IfStatementContext) {}
+
+// ExitIfStatement is called when production ifStatement is exited.
+func (s *BaseNumScriptListener) ExitIfStatement(ctx *IfStatementContext) {}
+
 // EnterPrint is called when production Print is entered.
 func (s *BaseNumScriptListener) EnterPrint(ctx *PrintContext) {}
 
@@ -209,29 +303,53 @@ func (s *BaseNumScriptListener) EnterSetTxMeta(ctx *SetTxMetaContext) {}
 // ExitSetTxMeta is called when production SetTxMeta is exited.
 func (s *BaseNumScriptListener) ExitSetTxMeta(ctx *SetTxMetaContext) {}
 
+// EnterSetAccountMeta is called when production SetAccountMeta is entered.
+func (s *BaseNumScriptListener) EnterSetAccountMeta(ctx *SetAccountMetaContext) {}
+
+// ExitSetAccountMeta is called when production SetAccountMeta is exited.
+func (s *BaseNumScriptListener) ExitSetAccountMeta(ctx *SetAccountMetaContext) {}
+
 // EnterFail is called when production Fail is entered.
 func (s *BaseNumScriptListener) EnterFail(ctx *FailContext) {}
 
 // ExitFail is called when production Fail is exited.
 func (s *BaseNumScriptListener) ExitFail(ctx *FailContext) {}
 
+// EnterAssert is called when production Assert is entered.
+func (s *BaseNumScriptListener) EnterAssert(ctx *AssertContext) {}
+
+// ExitAssert is called when production Assert is exited.
+func (s *BaseNumScriptListener) ExitAssert(ctx *AssertContext) {}
+
 // EnterSend is called when production Send is entered.
 func (s *BaseNumScriptListener) EnterSend(ctx *SendContext) {}
 
 // ExitSend is called when production Send is exited.
 func (s *BaseNumScriptListener) ExitSend(ctx *SendContext) {}
 
+// EnterIf is called when production If is entered.
+func (s *BaseNumScriptListener) EnterIf(ctx *IfContext) {}
+
+// ExitIf is called when production If is exited.
+func (s *BaseNumScriptListener) ExitIf(ctx *IfContext) {}
+
 // EnterType_ is called when production type_ is entered.
 func (s *BaseNumScriptListener) EnterType_(ctx *Type_Context) {}
 
 // ExitType_ is called when production type_ is exited.
 func (s *BaseNumScriptListener) ExitType_(ctx *Type_Context) {}
 
-// EnterOrigin is called when production origin is entered.
-func (s *BaseNumScriptListener) EnterOrigin(ctx *OriginContext) {}
+// EnterOriginAccountMeta is called when production OriginAccountMeta is entered.
+func (s *BaseNumScriptListener) EnterOriginAccountMeta(ctx *OriginAccountMetaContext) {}
+
+// ExitOriginAccountMeta is called when production OriginAccountMeta is exited.
+func (s *BaseNumScriptListener) ExitOriginAccountMeta(ctx *OriginAccountMetaContext) {}
+
+// EnterOriginAccountBalance is called when production OriginAccountBalance is entered.
+func (s *BaseNumScriptListener) EnterOriginAccountBalance(ctx *OriginAccountBalanceContext) {}
 
-// ExitOrigin is called when production origin is exited.
-func (s *BaseNumScriptListener) ExitOrigin(ctx *OriginContext) {}
+// ExitOriginAccountBalance is called when production OriginAccountBalance is exited.
+func (s *BaseNumScriptListener) ExitOriginAccountBalance(ctx *OriginAccountBalanceContext) {}
 
 // EnterVarDecl is called when production varDecl is entered.
 func (s *BaseNumScriptListener) EnterVarDecl(ctx *VarDeclContext) {}
@@ -44,33 +44,42 @@ func numscriptlexerLexerInit() {
 		"DEFAULT_MODE",
 	}
 	staticData.literalNames = []string{
-		"", "'*'", "','", "", "", "", "", "'vars'", "'meta'", "'set_tx_meta'",
-		"'print'", "'fail'", "'send'", "'source'", "'from'", "'max'", "'destination'",
-		"'to'", "'allocate'", "'+'", "'-'", "'('", "')'", "'['", "']'", "'{'",
-		"'}'", "'='", "'account'", "'asset'", "'number'", "'monetary'", "'portion'",
-		"'string'", "", "", "'remaining'", "'kept'", "", "'%'",
+		"", "','", "", "", "", "", "'vars'", "'meta'", "'balance'", "'set_tx_meta'",
+		"'set_account_meta'", "'print'", "'fail'", "'assert'", "'if'", "'else'",
+		"'send'", "'source'", "'from'", "'max'", "'destination'", "'to'", "'allocate'",
+		"'+'", "'-'", "'*'", "'=='", "'!='", "'<'", "'<='", "'>'", "'>='", "'&&'",
+		"'||'", "'!'", "'('", "')'", "'['", "']'", "'{'", "'}'", "'='", "'account'",
+		"'asset'", "'number'", "'monetary'", "'portion'", "'string'", "'bool'",
+		"'true'", "'false'", "", "", "'remaining'", "'kept'", "'allowing'", "'unbounded'",
+		"'overdraft'", "'up'", "'down'", "'rounded'", "", "'%'",
 	}
 	staticData.symbolicNames = []string{
-		"", "", "", "NEWLINE", "WHITESPACE", "MULTILINE_COMMENT", "LINE_COMMENT",
-		"VARS", "META", "SET_TX_META", "PRINT", "FAIL", "SEND", "SOURCE", "FROM",
-		"MAX", "DESTINATION", "TO", "ALLOCATE", "OP_ADD", "OP_SUB", "LPAREN",
-		"RPAREN", "LBRACK", "RBRACK", "LBRACE", "RBRACE", "EQ", "TY_ACCOUNT",
-		"TY_ASSET", "TY_NUMBER", "TY_MONETARY", "TY_PORTION", "TY_STRING", "STRING",
-		"PORTION", "REMAINING", "KEPT", "NUMBER", "PERCENT", "VARIABLE_NAME",
+		"", "", "NEWLINE", "WHITESPACE", "MULTILINE_COMMENT", "LINE_COMMENT",
+		"VARS", "META", "BALANCE", "SET_TX_META", "SET_ACCOUNT_META", "PRINT",
+		"FAIL", "ASSERT", "IF", "ELSE", "SEND", "SOURCE", "FROM", "MAX", "DESTINATION",
+		"TO", "ALLOCATE", "OP_ADD", "OP_SUB", "OP_MUL", "OP_EQ", "OP_NEQ", "OP_LT",
+		"OP_LTE", "OP_GT", "OP_GTE", "OP_AND", "OP_OR", "OP_NOT", "LPAREN", "RPAREN",
+		"LBRACK", "RBRACK", "LBRACE", "RBRACE", "EQ", "TY_ACCOUNT", "TY_ASSET",
+		"TY_NUMBER", "TY_MONETARY", "TY_PORTION", "TY_STRING", "TY_BOOL", "TRUE",
+		"FALSE", "STRING", "PORTION", "REMAINING", "KEPT", "ALLOWING", "UNBOUNDED",
+		"OVERDRAFT", "UP", "DOWN", "ROUNDED", "NUMBER", "PERCENT", "VARIABLE_NAME",
 		"ACCOUNT", "ASSET",
 	}
 	staticData.ruleNames = []string{
-		"T__0", "T__1", "NEWLINE", "WHITESPACE", "MULTILINE_COMMENT", "LINE_COMMENT",
-		"VARS", "META", "SET_TX_META", "PRINT", "FAIL", "SEND", "SOURCE", "FROM",
-		"MAX", "DESTINATION", "TO", "ALLOCATE", "OP_ADD", "OP_SUB", "LPAREN",
-		"RPAREN", "LBRACK", "RBRACK", "LBRACE", "RBRACE", "EQ", "TY_ACCOUNT",
-		"TY_ASSET", "TY_NUMBER", "TY_MONETARY", "TY_PORTION", "TY_STRING", "STRING",
-		"PORTION", "REMAINING", "KEPT", "NUMBER", "PERCENT", "VARIABLE_NAME",
+		"T__0", "NEWLINE", "WHITESPACE", "MULTILINE_COMMENT", "LINE_COMMENT",
+		"VARS", "META", "BALANCE", "SET_TX_META", "SET_ACCOUNT_META", "PRINT",
+		"FAIL", "ASSERT", "IF", "ELSE", "SEND", "SOURCE", "FROM", "MAX", "DESTINATION",
+		"TO", "ALLOCATE", "OP_ADD", "OP_SUB", "OP_MUL", "OP_EQ", "OP_NEQ", "OP_LT",
+		"OP_LTE", "OP_GT", "OP_GTE", "OP_AND", "OP_OR", "OP_NOT", "LPAREN", "RPAREN",
+		"LBRACK", "RBRACK", "LBRACE", "RBRACE", "EQ", "TY_ACCOUNT", "TY_ASSET",
+		"TY_NUMBER", "TY_MONETARY", "TY_PORTION", "TY_STRING", "TY_BOOL", "TRUE",
+		"FALSE", "STRING", "PORTION", "REMAINING", "KEPT", "ALLOWING", "UNBOUNDED",
+		"OVERDRAFT", "UP", "DOWN", "ROUNDED", "NUMBER", "PERCENT", "VARIABLE_NAME",
 		"ACCOUNT", "ASSET",
 	}
 	staticData.predictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 0, 42, 363, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
+		4, 0, 65, 534, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
 		4, 7, 4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2,
 		10, 7, 10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15,
 		7, 15, 2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7,
@@ -78,161 +87,234 @@ func numscriptlexerLexerInit() {
 		2, 26, 7, 26, 2, 27, 7, 27, 2, 28, 7, 28, 2, 29, 7, 29, 2, 30, 7, 30, 2,
 		31, 7, 31, 2, 32, 7, 32, 2, 33, 7, 33, 2, 34, 7, 34, 2, 35, 7, 35, 2, 36,
 		7, 36, 2, 37, 7, 37, 2, 38, 7, 38, 2, 39, 7, 39, 2, 40, 7, 40, 2, 41, 7,
-		41, 1, 0, 1, 0, 1, 1, 1, 1, 1, 2, 4, 2, 91, 8, 2, 11, 2, 12, 2, 92, 1,
-		3, 4, 3, 96, 8, 3, 11, 3, 12, 3, 97, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4, 1, 4,
-		1, 4, 5, 4, 107, 8, 4, 10, 4, 12, 4, 110, 9, 4, 1, 4, 1, 4, 1, 4, 1, 4,
-		1, 4, 1, 5, 1, 5, 1, 5, 1, 5, 5, 5, 121, 8, 5, 10, 5, 12, 5, 124, 9, 5,
-		1, 5, 1, 5, 1, 5, 1, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 7, 1, 7, 1, 7,
-		1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8,
-		1, 8, 1, 8, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 10, 1, 10, 1, 10, 1,
-		10, 1, 10, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 12, 1, 12, 1, 12, 1, 12,
-		1, 12, 1, 12, 1, 12, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 14, 1, 14, 1,
-		14, 1, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15,
-		1, 15, 1, 15, 1, 15, 1, 16, 1, 16, 1, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1,
-		17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 18, 1, 18, 1, 19, 1, 19, 1, 20, 1, 20,
-		1, 21, 1, 21, 1, 22, 1, 22, 1, 23, 1, 23, 1, 24, 1, 24, 1, 25, 1, 25, 1,
-		26, 1, 26, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 28,
-		1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1,
-		29, 1, 29, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30,
-		1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 32, 1, 32, 1,
-		32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 33, 1, 33, 5, 33, 273, 8, 33, 10, 33,
-		12, 33, 276, 9, 33, 1, 33, 1, 33, 1, 34, 4, 34, 281, 8, 34, 11, 34, 12,
-		34, 282, 1, 34, 3, 34, 286, 8, 34, 1, 34, 1, 34, 3, 34, 290, 8, 34, 1,
-		34, 4, 34, 293, 8, 34, 11, 34, 12, 34, 294, 1, 34, 4, 34, 298, 8, 34, 11,
-		34, 12, 34, 299, 1, 34, 1, 34, 4, 34, 304, 8, 34, 11, 34, 12, 34, 305,
-		3, 34, 308, 8, 34, 1, 34, 3, 34, 311, 8, 34, 1, 35, 1, 35, 1, 35, 1, 35,
-		1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 36, 1, 36, 1, 36, 1, 36, 1,
-		36, 1, 37, 4, 37, 329, 8, 37, 11, 37, 12, 37, 330, 1, 38, 1, 38, 1, 39,
-		1, 39, 4, 39, 337, 8, 39, 11, 39, 12, 39, 338, 1, 39, 5, 39, 342, 8, 39,
-		10, 39, 12, 39, 345, 9, 39, 1, 40, 1, 40, 4, 40, 349, 8, 40, 11, 40, 12,
-		40, 350, 1, 40, 5, 40, 354, 8, 40, 10, 40, 12, 40, 357, 9, 40, 1, 41, 4,
-		41, 360, 8, 41, 11, 41, 12, 41, 361, 2, 108, 122, 0, 42, 1, 1, 3, 2, 5,
-		3, 7, 4, 9, 5, 11, 6, 13, 7, 15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25,
+		41, 2, 42, 7, 42, 2, 43, 7, 43, 2, 44, 7, 44, 2, 45, 7, 45, 2, 46, 7, 46,
+		2, 47, 7, 47, 2, 48, 7, 48, 2, 49, 7, 49, 2, 50, 7, 50, 2, 51, 7, 51, 2,
+		52, 7, 52, 2, 53, 7, 53, 2, 54, 7, 54, 2, 55, 7, 55, 2, 56, 7, 56, 2, 57,
+		7, 57, 2, 58, 7, 58, 2, 59, 7, 59, 2, 60, 7, 60, 2, 61, 7, 61, 2, 62, 7,
+		62, 2, 63, 7, 63, 2, 64, 7, 64, 1, 0, 1, 0, 1, 1, 4, 1, 135, 8, 1, 11,
+		1, 12, 1, 136, 1, 2, 4, 2, 140, 8, 2, 11, 2, 12, 2, 141, 1, 2, 1, 2, 1,
+		3, 1, 3, 1, 3, 1, 3, 1, 3, 5, 3, 151, 8, 3, 10, 3, 12, 3, 154, 9, 3, 1,
+		3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4, 1, 4, 5, 4, 165, 8, 4, 10,
+		4, 12, 4, 168, 9, 4, 1, 4, 1, 4, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 5, 1,
+		5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1,
+		7, 1, 7, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1,
+		8, 1, 8, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1,
+		9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10,
+		1, 10, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 12, 1, 12, 1, 12, 1, 12, 1,
+		12, 1, 12, 1, 12, 1, 13, 1, 13, 1, 13, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14,
+		1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1,
+		16, 1, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 18, 1, 18, 1, 18, 1, 18,
+		1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1,
+		19, 1, 19, 1, 20, 1, 20, 1, 20, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21,
+		1, 21, 1, 21, 1, 21, 1, 22, 1, 22, 1, 23, 1, 23, 1, 24, 1, 24, 1, 25, 1,
+		25, 1, 25, 1, 26, 1, 26, 1, 26, 1, 27, 1, 27, 1, 28, 1, 28, 1, 28, 1, 29,
+		1, 29, 1, 30, 1, 30, 1, 30, 1, 31, 1, 31, 1, 31, 1, 32, 1, 32, 1, 32, 1,
+		33, 1, 33, 1, 34, 1, 34, 1, 35, 1, 35, 1, 36, 1, 36, 1, 37, 1, 37, 1, 38,
+		1, 38, 1, 39, 1, 39, 1, 40, 1, 40, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1,
+		41, 1, 41, 1, 41, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 43, 1, 43,
+		1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 44, 1, 44, 1, 44, 1, 44, 1, 44, 1,
+		44, 1, 44, 1, 44, 1, 44, 1, 45, 1, 45, 1, 45, 1, 45, 1, 45, 1, 45, 1, 45,
+		1, 45, 1, 46, 1, 46, 1, 46, 1, 46, 1, 46, 1, 46, 1, 46, 1, 47, 1, 47, 1,
+		47, 1, 47, 1, 47, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 49, 1, 49, 1, 49,
+		1, 49, 1, 49, 1, 49, 1, 50, 1, 50, 5, 50, 399, 8, 50, 10, 50, 12, 50, 402,
+		9, 50, 1, 50, 1, 50, 1, 51, 4, 51, 407, 8, 51, 11, 51, 12, 51, 408, 1,
+		51, 3, 51, 412, 8, 51, 1, 51, 1, 51, 3, 51, 416, 8, 51, 1, 51, 4, 51, 419,
+		8, 51, 11, 51, 12, 51, 420, 1, 51, 4, 51, 424, 8, 51, 11, 51, 12, 51, 425,
+		1, 51, 1, 51, 4, 51, 430, 8, 51, 11, 51, 12, 51, 431, 3, 51, 434, 8, 51,
+		1, 51, 3, 51, 437, 8, 51, 1, 52, 1, 52, 1, 52, 1, 52, 1, 52, 1, 52, 1,
+		52, 1, 52, 1, 52, 1, 52, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 54, 1, 54,
+		1, 54, 1, 54, 1, 54, 1, 54, 1, 54, 1, 54, 1, 54, 1, 55, 1, 55, 1, 55, 1,
+		55, 1, 55, 1, 55, 1, 55, 1, 55, 1, 55, 1, 55, 1, 56, 1, 56, 1, 56, 1, 56,
+		1, 56, 1, 56, 1, 56, 1, 56, 1, 56, 1, 56, 1, 57, 1, 57, 1, 57, 1, 58, 1,
+		58, 1, 58, 1, 58, 1, 58, 1, 59, 1, 59, 1, 59, 1, 59, 1, 59, 1, 59, 1, 59,
+		1, 59, 1, 60, 4, 60, 500, 8, 60, 11, 60, 12, 60, 501, 1, 61, 1, 61, 1,
+		62, 1, 62, 4, 62, 508, 8, 62, 11, 62, 12, 62, 509, 1, 62, 5, 62, 513, 8,
+		62, 10, 62, 12, 62, 516, 9, 62, 1, 63, 1, 63, 4, 63, 520, 8, 63, 11, 63,
+		12, 63, 521, 1, 63, 5, 63, 525, 8, 63, 10, 63, 12, 63, 528, 9, 63, 1, 64,
+		4, 64, 531, 8, 64, 11, 64, 12, 64, 532, 2, 152, 166, 0, 65, 1, 1, 3, 2,
+		5, 3, 7, 4, 9, 5, 11, 6, 13, 7, 15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25,
 		13, 27, 14, 29, 15, 31, 16, 33, 17, 35, 18, 37, 19, 39, 20, 41, 21, 43,
 		22, 45, 23, 47, 24, 49, 25, 51, 26, 53, 27, 55, 28, 57, 29, 59, 30, 61,
 		31, 63, 32, 65, 33, 67, 34, 69, 35, 71, 36, 73, 37, 75, 38, 77, 39, 79,
-		40, 81, 41, 83, 42, 1, 0, 10, 2, 0, 10, 10, 13, 13, 2, 0, 9, 9, 32, 32,
-		6, 0, 32, 32, 45, 45, 48, 57, 65, 90, 95, 95, 97, 122, 1, 0, 48, 57, 1,
-		0, 32, 32, 2, 0, 95, 95, 97, 122, 3, 0, 48, 57, 95, 95, 97, 122, 3, 0,
-		65, 90, 95, 95, 97, 122, 4, 0, 48, 58, 65, 90, 95, 95, 97, 122, 2, 0, 47,
-		57, 65, 90, 382, 0, 1, 1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0,
-		0, 7, 1, 0, 0, 0, 0, 9, 1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0, 0,
-		0, 0, 15, 1, 0, 0, 0, 0, 17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1, 0,
-		0, 0, 0, 23, 1, 0, 0, 0, 0, 25, 1, 0, 0, 0, 0, 27, 1, 0, 0, 0, 0, 29, 1,
-		0, 0, 0, 0, 31, 1, 0, 0, 0, 0, 33, 1, 0, 0, 0, 0, 35, 1, 0, 0, 0, 0, 37,
-		1, 0, 0, 0, 0, 39, 1, 0, 0, 0, 0, 41, 1, 0, 0, 0, 0, 43, 1, 0, 0, 0, 0,
-		45, 1, 0, 0, 0, 0, 47, 1, 0, 0, 0, 0, 49, 1, 0, 0, 0, 0, 51, 1, 0, 0, 0,
-		0, 53, 1, 0, 0, 0, 0, 55, 1, 0, 0, 0, 0, 57, 1, 0, 0, 0, 0, 59, 1, 0, 0,
-		0, 0, 61, 1, 0, 0, 0, 0, 63, 1, 0, 0, 0, 0, 65, 1, 0, 0, 0, 0, 67, 1, 0,
-		0, 0, 0, 69, 1, 0, 0, 0, 0, 71, 1, 0, 0, 0, 0, 73, 1, 0, 0, 0, 0, 75, 1,
-		0, 0, 0, 0, 77, 1, 0, 0, 0, 0, 79, 1, 0, 0, 0, 0, 81, 1, 0, 0, 0, 0, 83,
-		1, 0, 0, 0, 1, 85, 1, 0, 0, 0, 3, 87, 1, 0, 0, 0, 5, 90, 1, 0, 0, 0, 7,
-		95, 1, 0, 0, 0, 9, 101, 1, 0, 0, 0, 11, 116, 1, 0, 0, 0, 13, 129, 1, 0,
-		0, 0, 15, 134, 1, 0, 0, 0, 17, 139, 1, 0, 0, 0, 19, 151, 1, 0, 0, 0, 21,
-		157, 1, 0, 0, 0, 23, 162, 1, 0, 0, 0, 25, 167, 1, 0, 0, 0, 27, 174, 1,
-		0, 0, 0, 29, 179, 1, 0, 0, 0, 31, 183, 1, 0, 0, 0, 33, 195, 1, 0, 0, 0,
-		35, 198, 1, 0, 0, 0, 37, 207, 1, 0, 0, 0, 39, 209, 1, 0, 0, 0, 41, 211,
-		1, 0, 0, 0, 43, 213, 1, 0, 0, 0, 45, 215, 1, 0, 0, 0, 47, 217, 1, 0, 0,
-		0, 49, 219, 1, 0, 0, 0, 51, 221, 1, 0, 0, 0, 53, 223, 1, 0, 0, 0, 55, 225,
-		1, 0, 0, 0, 57, 233, 1, 0, 0, 0, 59, 239, 1, 0, 0, 0, 61, 246, 1, 0, 0,
-		0, 63, 255, 1, 0, 0, 0, 65, 263, 1, 0, 0, 0, 67, 270, 1, 0, 0, 0, 69, 310,
-		1, 0, 0, 0, 71, 312, 1, 0, 0, 0, 73, 322, 1, 0, 0, 0, 75, 328, 1, 0, 0,
-		0, 77, 332, 1, 0, 0, 0, 79, 334, 1, 0, 0, 0, 81, 346, 1, 0, 0, 0, 83, 359,
-		1, 0, 0, 0, 85, 86, 5, 42, 0, 0, 86, 2, 1, 0, 0, 0, 87, 88, 5, 44, 0, 0,
-		88, 4, 1, 0, 0, 0, 89, 91, 7, 0, 0, 0, 90, 89, 1, 0, 0, 0, 91, 92, 1, 0,
-		0, 0, 92, 90, 1, 0, 0, 0, 92, 93, 1, 0, 0, 0, 93, 6, 1, 0, 0, 0, 94, 96,
-		7, 1, 0, 0, 95, 94, 1, 0, 0, 0, 96, 97, 1, 0, 0, 0, 97, 95, 1, 0, 0, 0,
-		97, 98, 1, 0, 0, 0, 98, 99, 1, 0, 0, 0, 99, 100, 6, 3, 0, 0, 100, 8, 1,
-		0, 0, 0, 101, 102, 5, 47, 0, 0, 102, 103, 5, 42, 0, 0, 103, 108, 1, 0,
-		0, 0, 104, 107, 3, 9, 4, 0, 105, 107, 9, 0, 0, 0, 106, 104, 1, 0, 0, 0,
-		106, 105, 1, 0, 0, 0, 107, 110, 1, 0, 0, 0, 108, 109, 1, 0, 0, 0, 108,
-		106, 1, 0, 0, 0, 109, 111, 1, 0, 0, 0, 110, 108, 1, 0, 0, 0, 111, 112,
-		5, 42, 0, 0, 112, 113, 5, 47, 0, 0, 113, 114, 1, 0, 0, 0, 114, 115, 6,
-		4, 0, 0, 115, 10, 1, 0, 0, 0, 116, 117, 5, 47, 0, 0, 117, 118, 5, 47, 0,
-		0, 118, 122, 1, 0, 0, 0, 119, 121, 9, 0, 0, 0, 120, 119, 1, 0, 0, 0, 121,
-		124, 1, 0, 0, 0, 122, 123, 1, 0, 0, 0, 122, 120, 1, 0, 0, 0, 123, 125,
-		1, 0, 0, 0, 124, 122, 1, 0, 0, 0, 125, 126, 3, 5, 2, 0, 126, 127, 1, 0,
-		0, 0, 127, 128, 6, 5, 0, 0, 128, 12, 1, 0, 0, 0, 129, 130, 5, 118, 0, 0,
-		130, 131, 5, 97, 0, 0, 131, 132, 5, 114, 0, 0, 132, 133, 5, 115, 0, 0,
-		133, 14, 1, 0, 0, 0, 134, 135, 5, 109, 0, 0, 135, 136, 5, 101, 0, 0, 136,
-		137, 5, 116, 0, 0, 137, 138, 5, 97, 0, 0, 138, 16, 1, 0, 0, 0, 139, 140,
-		5, 115, 0, 0, 140, 141, 5, 101, 0, 0, 141, 142, 5, 116, 0, 0, 142, 143,
-		5, 95, 0, 0, 143, 144, 5, 116, 0, 0, 144, 145, 5, 120, 0, 0, 145, 146,
-		5, 95, 0, 0, 146, 147, 5, 109, 0, 0, 147, 148, 5, 101, 0, 0, 148, 149,
-		5, 116, 0, 0, 149, 150, 5, 97, 0, 0, 150, 18, 1, 0, 0, 0, 151, 152, 5,
-		112, 0, 0, 152, 153, 5, 114, 0, 0, 153, 154, 5, 105, 0, 0, 154, 155, 5,
-		110, 0, 0, 155, 156, 5, 116, 0, 0, 156, 20, 1, 0, 0, 0, 157, 158, 5, 102,
-		0, 0, 158, 159, 5, 97, 0, 0, 159, 160, 5, 105, 0, 0, 160, 161, 5, 108,
-		0, 0, 161, 22, 1, 0, 0, 0, 162, 163, 5, 115, 0, 0, 163, 164, 5, 101, 0,
-		0, 164, 165, 5, 110, 0, 0, 165, 166, 5, 100, 0, 0, 166, 24, 1, 0, 0, 0,
-		167, 168, 5, 115, 0, 0, 168, 169, 5, 111, 0, 0, 169, 170, 5, 117, 0, 0,
-		170, 171, 5, 114, 0, 0, 171, 172, 5, 99, 0, 0, 172, 173, 5, 101, 0, 0,
-		173, 26, 1, 0, 0, 0, 174, 175, 5, 102, 0, 0, 175, 176, 5, 114, 0, 0, 176,
-		177, 5, 111, 0, 0, 177, 178, 5, 109, 0, 0, 178, 28, 1, 0, 0, 0, 179, 180,
-		5, 109, 0, 0, 180, 181, 5, 97, 0, 0, 181, 182, 5, 120, 0, 0, 182, 30, 1,
-		0, 0, 0, 183, 184, 5, 100, 0, 0, 184, 185, 5, 101, 0, 0, 185, 186, 5, 115,
-		0, 0, 186, 187, 5, 116, 0, 0, 187, 188, 5, 105, 0, 0, 188, 189, 5, 110,
-		0, 0, 189, 190, 5, 97, 0, 0, 190, 191, 5, 116, 0, 0, 191, 192, 5, 105,
-		0, 0, 192, 193, 5, 111, 0, 0, 193, 194, 5, 110, 0, 0, 194, 32, 1, 0, 0,
-		0, 195, 196, 5, 116, 0, 0, 196, 197, 5, 111, 0, 0, 197, 34, 1, 0, 0, 0,
-		198, 199, 5, 97, 0, 0, 199, 200, 5, 108, 0, 0, 200, 201, 5, 108, 0, 0,
-		201, 202, 5, 111, 0, 0, 202, 203, 5, 99, 0, 0, 203, 204, 5, 97, 0, 0, 204,
-		205, 5, 116, 0, 0, 205, 206, 5, 101, 0, 0, 206, 36, 1, 0, 0, 0, 207, 208,
-		5, 43, 0, 0, 208, 38, 1, 0, 0, 0, 209, 210, 5, 45, 0, 0, 210, 40, 1, 0,
-		0, 0, 211, 212, 5, 40, 0, 0, 212, 42, 1, 0, 0, 0, 213, 214, 5, 41, 0, 0,
-		214, 44, 1, 0, 0, 0, 215, 216, 5, 91, 0, 0, 216, 46, 1, 0, 0, 0, 217, 218,
-		5, 93, 0, 0, 218, 48, 1, 0, 0, 0, 219, 220, 5, 123, 0, 0, 220, 50, 1, 0,
-		0, 0, 221, 222, 5, 125, 0, 0, 222, 52, 1, 0, 0, 0, 223, 224, 5, 61, 0,
-		0, 224, 54, 1, 0, 0, 0, 225, 226, 5, 97, 0, 0, 226, 227, 5, 99, 0, 0, 227,
-		228, 5, 99, 0, 0, 228, 229, 5, 111, 0, 0, 229, 230, 5, 117, 0, 0, 230,
-		231, 5, 110, 0, 0, 231, 232, 5, 116, 0, 0, 232, 56, 1, 0, 0, 0, 233, 234,
-		5, 97, 0, 0, 234, 235, 5, 115, 0, 0, 235, 236, 5, 115, 0, 0, 236, 237,
-		5, 101, 0, 0, 237, 238, 5, 116, 0, 0, 238, 58, 1, 0, 0, 0, 239, 240, 5,
-		110, 0, 0, 240, 241, 5, 117, 0, 0, 241, 242, 5, 109, 0, 0, 242, 243, 5,
-		98, 0, 0, 243, 244, 5, 101, 0, 0, 244, 245, 5, 114, 0, 0, 245, 60, 1, 0,
-		0, 0, 246, 247, 5, 109, 0, 0, 247, 248, 5, 111, 0, 0, 248, 249, 5, 110,
-		0, 0, 249, 250, 5, 101, 0, 0, 250, 251, 5, 116, 0, 0, 251, 252, 5, 97,
-		0, 0, 252, 253, 5, 114, 0, 0, 253, 254, 5, 121, 0, 0, 254, 62, 1, 0, 0,
-		0, 255, 256, 5, 112, 0, 0, 256, 257, 5, 111, 0, 0, 257, 258, 5, 114, 0,
-		0, 258, 259, 5, 116, 0, 0, 259, 260, 5, 105, 0, 0, 260, 261, 5, 111, 0,
-		0, 261, 262, 5, 110, 0, 0, 262, 64, 1, 0, 0, 0, 263, 264, 5, 115, 0, 0,
-		264, 265, 5, 116, 0, 0, 265, 266, 5, 114, 0, 0, 266, 267, 5, 105, 0, 0,
-		267, 268, 5, 110, 0, 0, 268, 269, 5, 103, 0, 0, 269, 66, 1, 0, 0, 0, 270,
-		274, 5, 34, 0, 0, 271, 273, 7, 2, 0, 0, 272, 271, 1, 0, 0, 0, 273, 276,
-		1, 0, 0, 0, 274, 272, 1, 0, 0, 0, 274, 275, 1, 0, 0, 0, 275, 277, 1, 0,
-		0, 0, 276, 274, 1, 0, 0, 0, 277, 278, 5, 34, 0, 0, 278, 68, 1, 0, 0, 0,
-		279, 281, 7, 3, 0, 0, 280, 279, 1, 0, 0, 0, 281, 282, 1, 0, 0, 0, 282,
-		280, 1, 0, 0, 0, 282, 283, 1, 0, 0, 0, 283, 285, 1, 0, 0, 0, 284, 286,
-		7, 4, 0, 0, 285, 284, 1, 0, 0, 0, 285, 286, 1, 0, 0, 0, 286, 287, 1, 0,
-		0, 0, 287, 289, 5, 47, 0, 0, 288, 290, 7, 4, 0, 0, 289, 288, 1, 0, 0, 0,
-		289, 290, 1, 0, 0, 0, 290, 292, 1, 0, 0, 0, 291, 293, 7, 3, 0, 0, 292,
-		291, 1, 0, 0, 0, 293, 294, 1, 0, 0, 0, 294, 292, 1, 0, 0, 0, 294, 295,
-		1, 0, 0, 0, 295, 311, 1, 0, 0, 0, 296, 298, 7, 3, 0, 0, 297, 296, 1, 0,
-		0, 0, 298, 299, 1, 0, 0, 0, 299, 297, 1, 0, 0, 0, 299, 300, 1, 0, 0, 0,
-		300, 307, 1, 0, 0, 0, 301, 303, 5, 46, 0, 0, 302, 304, 7, 3, 0, 0, 303,
-		302, 1, 0, 0, 0, 304, 305, 1, 0, 0, 0, 305, 303, 1, 0, 0, 0, 305, 306,
-		1, 0, 0, 0, 306, 308, 1, 0, 0, 0, 307, 301, 1, 0, 0, 0, 307, 308, 1, 0,
-		0, 0, 308, 309, 1, 0, 0, 0, 309, 311, 5, 37, 0, 0, 310, 280, 1, 0, 0, 0,
-		310, 297, 1, 0, 0, 0, 311, 70, 1, 0, 0, 0, 312, 313, 5, 114, 0, 0, 313,
-		314, 5, 101, 0, 0, 314, 315, 5, 109, 0, 0, 315, 316, 5, 97, 0, 0, 316,
-		317, 5, 105, 0, 0, 317, 318, 5, 110, 0, 0, 318, 319, 5, 105, 0, 0, 319,
-		320, 5, 110, 0, 0, 320, 321, 5, 103, 0, 0, 321, 72, 1, 0, 0, 0, 322, 323,
-		5, 107, 0, 0, 323, 324, 5, 101, 0, 0, 324, 325, 5, 112, 0, 0, 325, 326,
-		5, 116, 0, 0, 326, 74, 1, 0, 0, 0, 327, 329, 7, 3, 0, 0, 328, 327, 1, 0,
-		0, 0, 329, 330, 1, 0, 0, 0, 330, 328, 1, 0, 0, 0, 330, 331, 1, 0, 0, 0,
-		331, 76, 1, 0, 0, 0, 332, 333, 5, 37, 0, 0, 333, 78, 1, 0, 0, 0, 334, 336,
-		5, 36, 0, 0, 335, 337, 7, 5, 0, 0, 336, 335, 1, 0, 0, 0, 337, 338, 1, 0,
-		0, 0, 338, 336, 1, 0, 0, 0, 338, 339, 1, 0, 0, 0, 339, 343, 1, 0, 0, 0,
-		340, 342, 7, 6, 0, 0, 341, 340, 1, 0, 0, 0, 342, 345, 1, 0, 0, 0, 343,
-		341, 1, 0, 0, 0, 343, 344, 1, 0, 0, 0, 344, 80, 1, 0, 0, 0, 345, 343, 1,
-		0, 0, 0, 346, 348, 5, 64, 0, 0, 347, 349, 7, 7, 0, 0, 348, 347, 1, 0, 0,
-		0, 349, 350, 1, 0, 0, 0, 350, 348, 1, 0, 0, 0, 350, 351, 1, 0, 0, 0, 351,
-		355, 1, 0, 0, 0, 352, 354, 7, 8, 0, 0, 353, 352, 1, 0, 0, 0, 354, 357,
-		1, 0, 0, 0, 355, 353, 1, 0, 0, 0, 355, 356, 1, 0, 0, 0, 356, 82, 1, 0,
-		0, 0, 357, 355, 1, 0, 0, 0, 358, 360, 7, 9, 0, 0, 359, 358, 1, 0, 0, 0,
-		360, 361, 1, 0, 0, 0, 361, 359, 1, 0, 0, 0, 361, 362, 1, 0, 0, 0, 362,
-		84, 1, 0, 0, 0, 21, 0, 92, 97, 106, 108, 122, 274, 282, 285, 289, 294,
-		299, 305, 307, 310, 330, 338, 343, 350, 355, 361, 1, 6, 0, 0,
+		40, 81, 41, 83, 42, 85, 43, 87, 44, 89, 45, 91, 46, 93, 47, 95, 48, 97,
+		49, 99, 50, 101, 51, 103, 52, 105, 53, 107, 54, 109, 55, 111, 56, 113,
+		57, 115, 58, 117, 59, 119, 60, 121, 61, 123, 62, 125, 63, 127, 64, 129,
+		65, 1, 0, 10, 2, 0, 10, 10, 13, 13, 2, 0, 9, 9, 32, 32, 6, 0, 32, 32, 45,
+		45, 48, 57, 65, 90, 95, 95, 97, 122, 1, 0, 48, 57, 1, 0, 32, 32, 2, 0,
+		95, 95, 97, 122, 3, 0, 48, 57, 95, 95, 97, 122, 3, 0, 65, 90, 95, 95, 97,
+		122, 4, 0, 48, 58, 65, 90, 95, 95, 97, 122, 2, 0, 47, 57, 65, 90, 553,
+		0, 1, 1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 7, 1, 0, 0, 0,
+		0, 9, 1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0, 0, 0, 0, 15, 1, 0, 0,
+		0, 0, 17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1, 0, 0, 0, 0, 23, 1, 0,
+		0, 0, 0, 25, 1, 0, 0, 0, 0, 27, 1, 0, 0, 0, 0, 29, 1, 0, 0, 0, 0, 31, 1,
+		0, 0, 0, 0, 33, 1, 0, 0, 0, 0, 35, 1, 0, 0, 0, 0, 37, 1, 0, 0, 0, 0, 39,
+		1, 0, 0, 0, 0, 41, 1, 0, 0, 0, 0, 43, 1, 0, 0, 0, 0, 45, 1, 0, 0, 0, 0,
+		47, 1, 0, 0, 0, 0, 49, 1, 0, 0, 0, 0, 51, 1, 0, 0, 0, 0, 53, 1, 0, 0, 0,
+		0, 55, 1, 0, 0, 0, 0, 57, 1, 0, 0, 0, 0, 59, 1, 0, 0, 0, 0, 61, 1, 0, 0,
+		0, 0, 63, 1, 0, 0, 0, 0, 65, 1, 0, 0, 0, 0, 67, 1, 0, 0, 0, 0, 69, 1, 0,
+		0, 0, 0, 71, 1, 0, 0, 0, 0, 73, 1, 0, 0, 0, 0, 75, 1, 0, 0, 0, 0, 77, 1,
+		0, 0, 0, 0, 79, 1, 0, 0, 0, 0, 81, 1, 0, 0, 0, 0, 83, 1, 0, 0, 0, 0, 85,
+		1, 0, 0, 0, 0, 87, 1, 0, 0, 0, 0, 89, 1, 0, 0, 0, 0, 91, 1, 0, 0, 0, 0,
+		93, 1, 0, 0, 0, 0, 95, 1, 0, 0, 0, 0, 97, 1, 0, 0, 0, 0, 99, 1, 0, 0, 0,
+		0, 101, 1, 0, 0, 0, 0, 103, 1, 0, 0, 0, 0, 105, 1, 0, 0, 0, 0, 107, 1,
+		0, 0, 0, 0, 109, 1, 0, 0, 0, 0, 111, 1, 0, 0, 0, 0, 113, 1, 0, 0, 0, 0,
+		115, 1, 0, 0, 0, 0, 117, 1, 0, 0, 0, 0, 119, 1, 0, 0, 0, 0, 121, 1, 0,
+		0, 0, 0, 123, 1, 0, 0, 0, 0, 125, 1, 0, 0, 0, 0, 127, 1, 0, 0, 0, 0, 129,
+		1, 0, 0, 0, 1, 131, 1, 0, 0, 0, 3, 134, 1, 0, 0, 0, 5, 139, 1, 0, 0, 0,
+		7, 145, 1, 0, 0, 0, 9, 160, 1, 0, 0, 0, 11, 173, 1, 0, 0, 0, 13, 178, 1,
+		0, 0, 0, 15, 183, 1, 0, 0, 0, 17, 191, 1, 0, 0, 0, 19, 203, 1, 0, 0, 0,
+		21, 220, 1, 0, 0, 0, 23, 226, 1, 0, 0, 0, 25, 231, 1, 0, 0, 0, 27, 238,
+		1, 0, 0, 0, 29, 241, 1, 0, 0, 0, 31, 246, 1, 0, 0, 0, 33, 251, 1, 0, 0,
+		0, 35, 258, 1, 0, 0, 0, 37, 263, 1, 0, 0, 0, 39, 267, 1, 0, 0, 0, 41, 279,
+		1, 0, 0, 0, 43, 282, 1, 0, 0, 0, 45, 291, 1, 0, 0, 0, 47, 293, 1, 0, 0,
+		0, 49, 295, 1, 0, 0, 0, 51, 297, 1, 0, 0, 0, 53, 300, 1, 0, 0, 0, 55, 303,
+		1, 0, 0, 0, 57, 305, 1, 0, 0, 0, 59, 308, 1, 0, 0, 0, 61, 310, 1, 0, 0,
+		0, 63, 313, 1, 0, 0, 0, 65, 316, 1, 0, 0, 0, 67, 319, 1, 0, 0, 0, 69, 321,
+		1, 0, 0, 0, 71, 323, 1, 0, 0, 0, 73, 325, 1, 0, 0, 0, 75, 327, 1, 0, 0,
+		0, 77, 329, 1, 0, 0, 0, 79, 331, 1, 0, 0, 0, 81, 333, 1, 0, 0, 0, 83, 335,
+		1, 0, 0, 0, 85, 343, 1, 0, 0, 0, 87, 349, 1, 0, 0, 0, 89, 356, 1, 0, 0,
+		0, 91, 365, 1, 0, 0, 0, 93, 373, 1, 0, 0, 0, 95, 380, 1, 0, 0, 0, 97, 385,
+		1, 0, 0, 0, 99, 390, 1, 0, 0, 0, 101, 396, 1, 0, 0, 0, 103, 436, 1, 0,
+		0, 0, 105, 438, 1, 0, 0, 0, 107, 448, 1, 0, 0, 0, 109, 453, 1, 0, 0, 0,
+		111, 462, 1, 0, 0, 0, 113, 472, 1, 0, 0, 0, 115, 482, 1, 0, 0, 0, 117,
+		485, 1, 0, 0, 0, 119, 490, 1, 0, 0, 0, 121, 499, 1, 0, 0, 0, 123, 503,
+		1, 0, 0, 0, 125, 505, 1, 0, 0, 0, 127, 517, 1, 0, 0, 0, 129, 530, 1, 0,
+		0, 0, 131, 132, 5, 44, 0, 0, 132, 2, 1, 0, 0, 0, 133, 135, 7, 0, 0, 0,
+		134, 133, 1, 0, 0, 0, 135, 136, 1, 0, 0, 0, 136, 134, 1, 0, 0, 0, 136,
+		137, 1, 0, 0, 0, 137, 4, 1, 0, 0, 0, 138, 140, 7, 1, 0, 0, 139, 138, 1,
+		0, 0, 0, 140, 141, 1, 0, 0, 0, 141, 139, 1, 0, 0, 0, 141, 142, 1, 0, 0,
+		0, 142, 143, 1, 0, 0, 0, 143, 144, 6, 2, 0, 0, 144, 6, 1, 0, 0, 0, 145,
+		146, 5, 47, 0, 0, 146, 147, 5, 42, 0, 0, 147, 152, 1, 0, 0, 0, 148, 151,
+		3, 7, 3, 0, 149, 151, 9, 0, 0, 0, 150, 148, 1, 0, 0, 0, 150, 149, 1, 0,
+		0, 0, 151, 154, 1, 0, 0, 0, 152, 153, 1, 0, 0, 0, 152, 150, 1, 0, 0, 0,
+		153, 155, 1, 0, 0, 0, 154, 152, 1, 0, 0, 0, 155, 156, 5, 42, 0, 0, 156,
+		157, 5, 47, 0, 0, 157, 158, 1, 0, 0, 0, 158, 159, 6, 3, 0, 0, 159, 8, 1,
+		0, 0, 0, 160, 161, 5, 47, 0, 0, 161, 162, 5, 47, 0, 0, 162, 166, 1, 0,
+		0, 0, 163, 165, 9, 0, 0, 0, 164, 163, 1, 0, 0, 0, 165, 168, 1, 0, 0, 0,
+		166, 167, 1, 0, 0, 0, 166, 164, 1, 0, 0, 0, 167, 169, 1, 0, 0, 0, 168,
+		166, 1, 0, 0, 0, 169, 170, 3, 3, 1, 0, 170, 171, 1, 0, 0, 0, 171, 172,
+		6, 4, 0, 0, 172, 10, 1, 0, 0, 0, 173, 174, 5, 118, 0, 0, 174, 175, 5, 97,
+		0, 0, 175, 176, 5, 114, 0, 0, 176, 177, 5, 115, 0, 0, 177, 12, 1, 0, 0,
+		0, 178, 179, 5, 109, 0, 0, 179, 180, 5, 101, 0, 0, 180, 181, 5, 116, 0,
+		0, 181, 182, 5, 97, 0, 0, 182, 14, 1, 0, 0, 0, 183, 184, 5, 98, 0, 0, 184,
+		185, 5, 97, 0, 0, 185, 186, 5, 108, 0, 0, 186, 187, 5, 97, 0, 0, 187, 188,
+		5, 110, 0, 0, 188, 189, 5, 99, 0, 0, 189, 190, 5, 101, 0, 0, 190, 16, 1,
+		0, 0, 0, 191, 192, 5, 115, 0, 0, 192, 193, 5, 101, 0, 0, 193, 194, 5, 116,
+		0, 0, 194, 195, 5, 95, 0, 0, 195, 196, 5, 116, 0, 0, 196, 197, 5, 120,
+		0, 0, 197, 198, 5, 95, 0, 0, 198, 199, 5, 109, 0, 0, 199, 200, 5, 101,
+		0, 0, 200, 201, 5, 116, 0, 0, 201, 202, 5, 97, 0, 0, 202, 18, 1, 0, 0,
+		0, 203, 204, 5, 115, 0, 0, 204, 205, 5, 101, 0, 0, 205, 206, 5, 116, 0,
+		0, 206, 207, 5, 95, 0, 0, 207, 208, 5, 97, 0, 0, 208, 209, 5, 99, 0, 0,
+		209, 210, 5, 99, 0, 0, 210, 211, 5, 111, 0, 0, 211, 212, 5, 117, 0, 0,
+		212, 213, 5, 110, 0, 0, 213, 214, 5, 116, 0, 0, 214, 215, 5, 95, 0, 0,
+		215, 216, 5, 109, 0, 0, 216, 217, 5, 101, 0, 0, 217, 218, 5, 116, 0, 0,
+		218, 219, 5, 97, 0, 0, 219, 20, 1, 0, 0, 0, 220, 221, 5, 112, 0, 0, 221,
+		222, 5, 114, 0, 0, 222, 223, 5, 105, 0, 0, 223, 224, 5, 110, 0, 0, 224,
+		225, 5, 116, 0, 0, 225, 22, 1, 0, 0, 0, 226, 227, 5, 102, 0, 0, 227, 228,
+		5, 97, 0, 0, 228, 229, 5, 105, 0, 0, 229, 230, 5, 108, 0, 0, 230, 24, 1,
+		0, 0, 0, 231, 232, 5, 97, 0, 0, 232, 233, 5, 115, 0, 0, 233, 234, 5, 115,
+		0, 0, 234, 235, 5, 101, 0, 0, 235, 236, 5, 114, 0, 0, 236, 237, 5, 116,
+		0, 0, 237, 26, 1, 0, 0, 0, 238, 239, 5, 105, 0, 0, 239, 240, 5, 102, 0,
+		0, 240, 28, 1, 0, 0, 0, 241, 242, 5, 101, 0, 0, 242, 243, 5, 108, 0, 0,
+		243, 244, 5, 115, 0, 0, 244, 245, 5, 101, 0, 0, 245, 30, 1, 0, 0, 0, 246,
+		247, 5, 115, 0, 0, 247, 248, 5, 101, 0, 0, 248, 249, 5, 110, 0, 0, 249,
+		250, 5, 100, 0, 0, 250, 32, 1, 0, 0, 0, 251, 252, 5, 115, 0, 0, 252, 253,
+		5, 111, 0, 0, 253, 254, 5, 117, 0, 0, 254, 255, 5, 114, 0, 0, 255, 256,
+		5, 99, 0, 0, 256, 257, 5, 101, 0, 0, 257, 34, 1, 0, 0, 0, 258, 259, 5,
+		102, 0, 0, 259, 260, 5, 114, 0, 0, 260, 261, 5, 111, 0, 0, 261, 262, 5,
+		109, 0, 0, 262, 36, 1, 0, 0, 0, 263, 264, 5, 109, 0, 0, 264, 265, 5, 97,
+		0, 0, 265, 266, 5, 120, 0, 0, 266, 38, 1, 0, 0, 0, 267, 268, 5, 100, 0,
+		0, 268, 269, 5, 101, 0, 0, 269, 270, 5, 115, 0, 0, 270, 271, 5, 116, 0,
+		0, 271, 272, 5, 105, 0, 0, 272, 273, 5, 110, 0, 0, 273, 274, 5, 97, 0,
+		0, 274, 275, 5, 116, 0, 0, 275, 276, 5, 105, 0, 0, 276, 277, 5, 111, 0,
+		0, 277, 278, 5, 110, 0, 0, 278, 40, 1, 0, 0, 0, 279, 280, 5, 116, 0, 0,
+		280, 281, 5, 111, 0, 0, 281, 42, 1, 0, 0, 0, 282, 283, 5, 97, 0, 0, 283,
+		284, 5, 108, 0, 0, 284, 285, 5, 108, 0, 0, 285, 286, 5, 111, 0, 0, 286,
+		287, 5, 99, 0, 0, 287, 288, 5, 97, 0, 0, 288, 289, 5, 116, 0, 0, 289, 290,
+		5, 101, 0, 0, 290, 44, 1, 0, 0, 0, 291, 292, 5, 43, 0, 0, 292, 46, 1, 0,
+		0, 0, 293, 294, 5, 45, 0, 0, 294, 48, 1, 0, 0, 0, 295, 296, 5, 42, 0, 0,
+		296, 50, 1, 0, 0, 0, 297, 298, 5, 61, 0, 0, 298, 299, 5, 61, 0, 0, 299,
+		52, 1, 0, 0, 0, 300, 301, 5, 33, 0, 0, 301, 302, 5, 61, 0, 0, 302, 54,
+		1, 0, 0, 0, 303, 304, 5, 60, 0, 0, 304, 56, 1, 0, 0, 0, 305, 306, 5, 60,
+		0, 0, 306, 307, 5, 61, 0, 0, 307, 58, 1, 0, 0, 0, 308, 309, 5, 62, 0, 0,
+		309, 60, 1, 0, 0, 0, 310, 311, 5, 62, 0, 0, 311, 312, 5, 61, 0, 0, 312,
+		62, 1, 0, 0, 0, 313, 314, 5, 38, 0, 0, 314, 315, 5, 38, 0, 0, 315, 64,
+		1, 0, 0, 0, 316, 317, 5, 124, 0, 0, 317, 318, 5, 124, 0, 0, 318, 66, 1,
+		0, 0, 0, 319, 320, 5, 33, 0, 0, 320, 68, 1, 0, 0, 0, 321, 322, 5, 40, 0,
+		0, 322, 70, 1, 0, 0, 0, 323, 324, 5, 41, 0, 0, 324, 72, 1, 0, 0, 0, 325,
+		326, 5, 91, 0, 0, 326, 74, 1, 0, 0, 0, 327, 328, 5, 93, 0, 0, 328, 76,
+		1, 0, 0, 0, 329, 330, 5, 123, 0, 0, 330, 78, 1, 0, 0, 0, 331, 332, 5, 125,
+		0, 0, 332, 80, 1, 0, 0, 0, 333, 334, 5, 61, 0, 0, 334, 82, 1, 0, 0, 0,
+		335, 336, 5, 97, 0, 0, 336, 337, 5, 99, 0, 0, 337, 338, 5, 99, 0, 0, 338,
+		339, 5, 111, 0, 0, 339, 340, 5, 117, 0, 0, 340, 341, 5, 110, 0, 0, 341,
+		342, 5, 116, 0, 0, 342, 84, 1, 0, 0, 0, 343, 344, 5, 97, 0, 0, 344, 345,
+		5, 115, 0, 0, 345, 346, 5, 115, 0, 0, 346, 347, 5, 101, 0, 0, 347, 348,
+		5, 116, 0, 0, 348, 86, 1, 0, 0, 0, 349, 350, 5, 110, 0, 0, 350, 351, 5,
+		117, 0, 0, 351, 352, 5, 109, 0, 0, 352, 353, 5, 98, 0, 0, 353, 354, 5,
+		101, 0, 0, 354, 355, 5, 114, 0, 0, 355, 88, 1, 0, 0, 0, 356, 357, 5, 109,
+		0, 0, 357, 358, 5, 111, 0, 0, 358, 359, 5, 110, 0, 0, 359, 360, 5, 101,
+		0, 0, 360, 361, 5, 116, 0, 0, 361, 362, 5, 97, 0, 0, 362, 363, 5, 114,
+		0, 0, 363, 364, 5, 121, 0, 0, 364, 90, 1, 0, 0, 0, 365, 366, 5, 112, 0,
+		0, 366, 367, 5, 111, 0, 0, 367, 368, 5, 114, 0, 0, 368, 369, 5, 116, 0,
+		0, 369, 370, 5, 105, 0, 0, 370, 371, 5, 111, 0, 0, 371, 372, 5, 110, 0,
+		0, 372, 92, 1, 0, 0, 0, 373, 374, 5, 115, 0, 0, 374, 375, 5, 116, 0, 0,
+		375, 376, 5, 114, 0, 0, 376, 377, 5, 105, 0, 0, 377, 378, 5, 110, 0, 0,
+		378, 379, 5, 103, 0, 0, 379, 94, 1, 0, 0, 0, 380, 381, 5, 98, 0, 0, 381,
+		382, 5, 111, 0, 0, 382, 383, 5, 111, 0, 0, 383, 384, 5, 108, 0, 0, 384,
+		96, 1, 0, 0, 0, 385, 386, 5, 116, 0, 0, 386, 387, 5, 114, 0, 0, 387, 388,
+		5, 117, 0, 0, 388, 389, 5, 101, 0, 0, 389, 98, 1, 0, 0, 0, 390, 391, 5,
+		102, 0, 0, 391, 392, 5, 97, 0, 0, 392, 393, 5, 108, 0, 0, 393, 394, 5,
+		115, 0, 0, 394, 395, 5, 101, 0, 0, 395, 100, 1, 0, 0, 0, 396, 400, 5, 34,
+		0, 0, 397, 399, 7, 2, 0, 0, 398, 397, 1, 0, 0, 0, 399, 402, 1, 0, 0, 0,
+		400, 398, 1, 0, 0, 0, 400, 401, 1, 0, 0, 0, 401, 403, 1, 0, 0, 0, 402,
+		400, 1, 0, 0, 0, 403, 404, 5, 34, 0, 0, 404, 102, 1, 0, 0, 0, 405, 407,
+		7, 3, 0, 0, 406, 405, 1, 0, 0, 0, 407, 408, 1, 0, 0, 0, 408, 406, 1, 0,
+		0, 0, 408, 409, 1, 0, 0, 0, 409, 411, 1, 0, 0, 0, 410, 412, 7, 4, 0, 0,
+		411, 410, 1, 0, 0, 0, 411, 412, 1, 0, 0, 0, 412, 413, 1, 0, 0, 0, 413,
+		415, 5, 47, 0, 0, 414, 416, 7, 4, 0, 0, 415, 414, 1, 0, 0, 0, 415, 416,
+		1, 0, 0, 0, 416, 418, 1, 0, 0, 0, 417, 419, 7, 3, 0, 0, 418, 417, 1, 0,
+		0, 0, 419, 420, 1, 0, 0, 0, 420, 418, 1, 0, 0, 0, 420, 421, 1, 0, 0, 0,
+		421, 437, 1, 0, 0, 0, 422, 424, 7, 3, 0, 0, 423, 422, 1, 0, 0, 0, 424,
+		425, 1, 0, 0, 0, 425, 423, 1, 0, 0, 0, 425, 426, 1, 0, 0, 0, 426, 433,
+		1, 0, 0, 0, 427, 429, 5, 46, 0, 0, 428, 430, 7, 3, 0, 0, 429, 428, 1, 0,
+		0, 0, 430, 431, 1, 0, 0, 0, 431, 429, 1, 0, 0, 0, 431, 432, 1, 0, 0, 0,
+		432, 434, 1, 0, 0, 0, 433, 427, 1, 0, 0, 0, 433, 434, 1, 0, 0, 0, 434,
+		435, 1, 0, 0, 0, 435, 437, 5, 37, 0, 0, 436, 406, 1, 0, 0, 0, 436, 423,
+		1, 0, 0, 0, 437, 104, 1, 0, 0, 0, 438, 439, 5, 114, 0, 0, 439, 440, 5,
+		101, 0, 0, 440, 441, 5, 109, 0, 0, 441, 442, 5, 97, 0, 0, 442, 443, 5,
+		105, 0, 0, 443, 444, 5, 110, 0, 0, 444, 445, 5, 105, 0, 0, 445, 446, 5,
+		110, 0, 0, 446, 447, 5, 103, 0, 0, 447, 106, 1, 0, 0, 0, 448, 449, 5, 107,
+		0, 0, 449, 450, 5, 101, 0, 0, 450, 451, 5, 112, 0, 0, 451, 452, 5, 116,
+		0, 0, 452, 108, 1, 0, 0, 0, 453, 454, 5, 97, 0, 0, 454, 455, 5, 108, 0,
+		0, 455, 456, 5, 108, 0, 0, 456, 457, 5, 111, 0, 0, 457, 458, 5, 119, 0,
+		0, 458, 459, 5, 105, 0, 0, 459, 460, 5, 110, 0, 0, 460, 461, 5, 103, 0,
+		0, 461, 110, 1, 0, 0, 0, 462, 463, 5, 117, 0, 0, 463, 464, 5, 110, 0, 0,
+		464, 465, 5, 98, 0, 0, 465, 466, 5, 111, 0, 0, 466, 467, 5, 117, 0, 0,
+		467, 468, 5, 110, 0, 0, 468, 469, 5, 100, 0, 0, 469, 470, 5, 101, 0, 0,
+		470, 471, 5, 100, 0, 0, 471, 112, 1, 0, 0, 0, 472, 473, 5, 111, 0, 0, 473,
+		474, 5, 118, 0, 0, 474, 475, 5, 101, 0, 0, 475, 476, 5, 114, 0, 0, 476,
+		477, 5, 100, 0, 0, 477, 478, 5, 114, 0, 0, 478, 479, 5, 97, 0, 0, 479,
+		480, 5, 102, 0, 0, 480, 481, 5, 116, 0, 0, 481, 114, 1, 0, 0, 0, 482, 483,
+		5, 117, 0, 0, 483, 484, 5, 112, 0, 0, 484, 116, 1, 0, 0, 0, 485, 486, 5,
+		100, 0, 0, 486, 487, 5, 111, 0, 0, 487, 488, 5, 119, 0, 0, 488, 489, 5,
+		110, 0, 0, 489, 118, 1, 0, 0, 0, 490, 491, 5, 114, 0, 0, 491, 492, 5, 111,
+		0, 0, 492, 493, 5, 117, 0, 0, 493, 494, 5, 110, 0, 0, 494, 495, 5, 100,
+		0, 0, 495, 496, 5, 101, 0, 0, 496, 497, 5, 100, 0, 0, 497, 120, 1, 0, 0,
+		0, 498, 500, 7, 3, 0, 0, 499, 498, 1, 0, 0, 0, 500, 501, 1, 0, 0, 0, 501,
+		499, 1, 0, 0, 0, 501, 502, 1, 0, 0, 0, 502, 122, 1, 0, 0, 0, 503, 504,
+		5, 37, 0, 0, 504, 124, 1, 0, 0, 0, 505, 507, 5, 36, 0, 0, 506, 508, 7,
+		5, 0, 0, 507, 506, 1, 0, 0, 0, 508, 509, 1, 0, 0, 0, 509, 507, 1, 0, 0,
+		0, 509, 510, 1, 0, 0, 0, 510, 514, 1, 0, 0, 0, 511, 513, 7, 6, 0, 0, 512,
+		511, 1, 0, 0, 0, 513, 516, 1, 0, 0, 0, 514, 512, 1, 0, 0, 0, 514, 515,
+		1, 0, 0, 0, 515, 126, 1, 0, 0, 0, 516, 514, 1, 0, 0, 0, 517, 519, 5, 64,
+		0, 0, 518, 520, 7, 7, 0, 0, 519, 518, 1, 0, 0, 0, 520, 521, 1, 0, 0, 0,
+		521, 519, 1, 0, 0, 0, 521, 522, 1, 0, 0, 0, 522, 526, 1, 0, 0, 0, 523,
+		525, 7, 8, 0, 0, 524, 523, 1, 0, 0, 0, 525, 528, 1, 0, 0, 0, 526, 524,
+		1, 0, 0, 0, 526, 527, 1, 0, 0, 0, 527, 128, 1, 0, 0, 0, 528, 526, 1, 0,
+		0, 0, 529, 531, 7, 9, 0, 0, 530, 529, 1, 0, 0, 0, 531, 532, 1, 0, 0, 0,
+		532, 530, 1, 0, 0, 0, 532, 533, 1, 0, 0, 0, 533, 130, 1, 0, 0, 0, 21, 0,
+		136, 141, 150, 152, 166, 400, 408, 411, 415, 420, 425, 431, 433, 436, 501,
+		509, 514, 521, 526, 532, 1, 6, 0, 0,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -274,45 +356,68 @@ func NewNumScriptLexer(input antlr.CharStream) *NumScriptLexer {
 // NumScriptLexer tokens.
 const (
 	NumScriptLexerT__0              = 1
-	NumScriptLexerT__1              = 2
-	NumScriptLexerNEWLINE           = 3
-	NumScriptLexerWHITESPACE        = 4
-	NumScriptLexerMULTILINE_COMMENT = 5
-	NumScriptLexerLINE_COMMENT      = 6
-	NumScriptLexerVARS              = 7
-	NumScriptLexerMETA              = 8
+	NumScriptLexerNEWLINE           = 2
+	NumScriptLexerWHITESPACE        = 3
+	NumScriptLexerMULTILINE_COMMENT = 4
+	NumScriptLexerLINE_COMMENT      = 5
+	NumScriptLexerVARS              = 6
+	NumScriptLexerMETA              = 7
+	NumScriptLexerBALANCE           = 8
 	NumScriptLexerSET_TX_META       = 9
-	NumScriptLexerPRINT             = 10
-	NumScriptLexerFAIL              = 11
-	NumScriptLexerSEND              = 12
-	NumScriptLexerSOURCE            = 13
-	NumScriptLexerFROM              = 14
-	NumScriptLexerMAX               = 15
-	NumScriptLexerDESTINATION       = 16
-	NumScriptLexerTO                = 17
-	NumScriptLexerALLOCATE          = 18
-	NumScriptLexerOP_ADD            = 19
-	NumScriptLexerOP_SUB            = 20
-	NumScriptLexerLPAREN            = 21
-	NumScriptLexerRPAREN            = 22
-	NumScriptLexerLBRACK            = 23
-	NumScriptLexerRBRACK            = 24
-	NumScriptLexerLBRACE            = 25
-	NumScriptLexerRBRACE            = 26
-	NumScriptLexerEQ                = 27
-	NumScriptLexerTY_ACCOUNT        = 28
-	NumScriptLexerTY_ASSET          = 29
-	NumScriptLexerTY_NUMBER         = 30
-	NumScriptLexerTY_MONETARY       = 31
-	NumScriptLexerTY_PORTION        = 32
-	NumScriptLexerTY_STRING         = 33
-	NumScriptLexerSTRING            = 34
-	NumScriptLexerPORTION           = 35
-	NumScriptLexerREMAINING         = 36
-	NumScriptLexerKEPT              = 37
-	NumScriptLexerNUMBER            = 38
-	NumScriptLexerPERCENT           = 39
-	NumScriptLexerVARIABLE_NAME     = 40
-	NumScriptLexerACCOUNT           = 41
-	NumScriptLexerASSET             = 42
+	NumScriptLexerSET_ACCOUNT_META  = 10
+	NumScriptLexerPRINT             = 11
+	NumScriptLexerFAIL              = 12
+	NumScriptLexerASSERT            = 13
+	NumScriptLexerIF                = 14
+	NumScriptLexerELSE              = 15
+	NumScriptLexerSEND              = 16
+	NumScriptLexerSOURCE            = 17
+	NumScriptLexerFROM              = 18
+	NumScriptLexerMAX               = 19
+	NumScriptLexerDESTINATION       = 20
+	NumScriptLexerTO                = 21
+	NumScriptLexerALLOCATE          = 22
+	NumScriptLexerOP_ADD            = 23
+	NumScriptLexerOP_SUB            = 24
+	NumScriptLexerOP_MUL            = 25
+	NumScriptLexerOP_EQ             = 26
+	NumScriptLexerOP_NEQ            = 27
+	NumScriptLexerOP_LT             = 28
+	NumScriptLexerOP_LTE            = 29
+	NumScriptLexerOP_GT             = 30
+	NumScriptLexerOP_GTE            = 31
+	NumScriptLexerOP_AND            = 32
+	NumScriptLexerOP_OR             = 33
+	NumScriptLexerOP_NOT            = 34
+	NumScriptLexerLPAREN            = 35
+	NumScriptLexerRPAREN            = 36
+	NumScriptLexerLBRACK            = 37
+	NumScriptLexerRBRACK            = 38
+	NumScriptLexerLBRACE            = 39
+	NumScriptLexerRBRACE            = 40
+	NumScriptLexerEQ                = 41
+	NumScriptLexerTY_ACCOUNT        = 42
+	NumScriptLexerTY_ASSET          = 43
+	NumScriptLexerTY_NUMBER         = 44
+	NumScriptLexerTY_MONETARY       = 45
+	NumScriptLexerTY_PORTION        = 46
+	NumScriptLexerTY_STRING         = 47
+	NumScriptLexerTY_BOOL           = 48
+	NumScriptLexerTRUE              = 49
+	NumScriptLexerFALSE             = 50
+	NumScriptLexerSTRING            = 51
+	NumScriptLexerPORTION           = 52
+	NumScriptLexerREMAINING         = 53
+	NumScriptLexerKEPT              = 54
+	NumScriptLexerALLOWING          = 55
+	NumScriptLexerUNBOUNDED         = 56
+	NumScriptLexerOVERDRAFT         = 57
+	NumScriptLexerUP                = 58
+	NumScriptLexerDOWN              = 59
+	NumScriptLexerROUNDED           = 60
+	NumScriptLexerNUMBER            = 61
+	NumScriptLexerPERCENT           = 62
+	NumScriptLexerVARIABLE_NAME     = 63
+	NumScriptLexerACCOUNT           = 64
+	NumScriptLexerASSET             = 65
 )
@@ -26,21 +26,51 @@ type NumScriptListener interface {
 	// EnterLitString is called when entering the LitString production.
 	EnterLitString(c *LitStringContext)
 
+	// EnterLitBool is called when entering the LitBool production.
+	EnterLitBool(c *LitBoolContext)
+
+	// EnterLitPortion is called when entering the LitPortion production.
+	EnterLitPortion(c *LitPortionContext)
+
 	// EnterLitMonetary is called when entering the LitMonetary production.
 	EnterLitMonetary(c *LitMonetaryContext)
 
 	// EnterVariable is called when entering the variable production.
 	EnterVariable(c *VariableContext)
 
+	// EnterBalance is called when entering the balance production.
+	EnterBalance(c *BalanceContext)
+
 	// EnterExprAddSub is called when entering the ExprAddSub production.
 	EnterExprAddSub(c *ExprAddSubContext)
 
+	// EnterExprAnd is called when entering the ExprAnd production.
+	EnterExprAnd(c *ExprAndContext)
+
+	// EnterExprComparison is called when entering the ExprComparison production.
+	EnterExprComparison(c *ExprComparisonContext)
+
+	// EnterExprOr is called when entering the ExprOr production.
+	EnterExprOr(c *ExprOrContext)
+
+	// EnterExprParens is called when entering the ExprParens production.
+	EnterExprParens(c *ExprParensContext)
+
 	// EnterExprLiteral is called when entering the ExprLiteral production.
 	EnterExprLiteral(c *ExprLiteralContext)
 
+	// EnterExprNot is called when entering the ExprNot production.
+	EnterExprNot(c *ExprNotContext)
+
+	// EnterExprMul is called when entering the ExprMul production.
+	EnterExprMul(c *ExprMulContext)
+
 	// EnterExprVariable is called when entering the ExprVariable production.
 	EnterExprVariable(c *ExprVariableContext)
 
+	// EnterExprBalance is called when entering the ExprBalance production.
+	EnterExprBalance(c *ExprBalanceContext)
+
 	// EnterAllotmentPortionConst is called when entering the allotmentPortionConst production.
 	EnterAllotmentPortionConst(c *AllotmentPortionConstContext)
 
@@ -77,6 +107,15 @@ type NumScriptListener interface {
 	// EnterSourceMaxed is called when entering the sourceMaxed production.
 	EnterSourceMaxed(c *SourceMaxedContext)
 
+	// EnterSrcAccountOverdraftSpecific is called when entering the SrcAccountOverdraftSpecific production.
+	EnterSrcAccountOverdraftSpecific(c *SrcAccountOverdraftSpecificContext)
+
+	// EnterSrcAccountOverdraftUnbounded is called when entering the SrcAccountOverdraftUnbounded production.
+	EnterSrcAccountOverdraftUnbounded(c *SrcAccountOverdraftUnboundedContext)
+
+	// EnterSourceAccount is called when entering the sourceAccount production.
+	EnterSourceAccount(c *SourceAccountContext)
+
 	// EnterSrcAccount is called when entering the SrcAccount production.
 	EnterSrcAccount(c *SrcAccountContext)
 
@@ -95,23 +134,41 @@ type NumScriptListener interface {
 	// EnterSrcAllotment is called when entering the SrcAllotment production.
 	EnterSrcAllotment(c *SrcAllotmentContext)
 
+	// EnterStatementBlock is called when entering the statementBlock production.
+	EnterStatementBlock(c *StatementBlockContext)
+
+	// EnterIfStatement is called when entering the ifStatement production.
+	EnterIfStatement(c *IfStatementContext)
+
 	// EnterPrint is called when entering the Print production.
 	EnterPrint(c *PrintContext)
 
 	// EnterSetTxMeta is called when entering the SetTxMeta production.
 	EnterSetTxMeta(c *SetTxMetaContext)
 
+	// EnterSetAccountMeta is called when entering the SetAccountMeta production.
+	EnterSetAccountMeta(c *SetAccountMetaContext)
+
 	// EnterFail is called when entering the Fail production.
 	EnterFail(c *FailContext)
 
+	// EnterAssert is called when entering the Assert production.
+	EnterAssert(c *AssertContext)
+
 	// EnterSend is called when entering the Send production.
 	EnterSend(c *SendContext)
 
+	// EnterIf is called when entering the If production.
+	EnterIf(c *IfContext)
+
 	// EnterType_ is called when entering the type_ production.
 	EnterType_(c *Type_Context)
 
-	// EnterOrigin is called when entering the origin production.
-	EnterOrigin(c *OriginContext)
+	// EnterOriginAccountMeta is called when entering the OriginAccountMeta production.
+	EnterOriginAccountMeta(c *OriginAccountMetaContext)
+
+	// EnterOriginAccountBalance is called when entering the OriginAccountBalance production.
+	EnterOriginAccountBalance(c *OriginAccountBalanceContext)
 
 	// EnterVarDecl is called when entering the varDecl production.
 	EnterVarDecl(c *VarDeclContext)
@@ -140,21 +197,51 @@ type NumScriptListener interface {
 	// ExitLitString is called when exiting the LitString production.
 	ExitLitString(c *LitStringContext)
 
+	// ExitLitBool is called when exiting the LitBool production.
+	ExitLitBool(c *LitBoolContext)
+
+	// ExitLitPortion is called when exiting the LitPortion production.
+	ExitLitPortion(c *LitPortionContext)
+
 	// ExitLitMonetary is called when exiting the LitMonetary production.
 	ExitLitMonetary(c *LitMonetaryContext)
 
 	// ExitVariable is called when exiting the variable production.
 	ExitVariable(c *VariableContext)
 
+	// ExitBalance is called when exiting the balance production.
+	ExitBalance(c *BalanceContext)
+
 	// ExitExprAddSub is called when exiting the ExprAddSub production.
 	ExitExprAddSub(c *ExprAddSubContext)
 
+	// ExitExprAnd is called when exiting the ExprAnd production.
+	ExitExprAnd(c *ExprAndContext)
+
+	// ExitExprComparison is called when exiting the ExprComparison production.
+	ExitExprComparison(c *ExprComparisonContext)
+
+	// ExitExprOr is called when exiting the ExprOr production.
+	ExitExprOr(c *ExprOrContext)
+
+	// ExitExprParens is called when exiting the ExprParens production.
+	ExitExprParens(c *ExprParensContext)
+
 	// ExitExprLiteral is called when exiting the ExprLiteral production.
 	ExitExprLiteral(c *ExprLiteralContext)
 
+	// ExitExprNot is called when exiting the ExprNot production.
+	ExitExprNot(c *ExprNotContext)
+
+	// ExitExprMul is called when exiting the ExprMul production.
+	ExitExprMul(c *ExprMulContext)
+
 	// ExitExprVariable is called when exiting the ExprVariable production.
 	ExitExprVariable(c *ExprVariableContext)
 
+	// ExitExprBalance is called when exiting the ExprBalance production.
+	ExitExprBalance(c *ExprBalanceContext)
+
 	// ExitAllotmentPortionConst is called when exiting the allotmentPortionConst production.
 	ExitAllotmentPortionConst(c *AllotmentPortionConstContext)
 
@@ -191,6 +278,15 @@ type NumScriptListener interface {
 	// ExitSourceMaxed is called when exiting the sourceMaxed production.
 	ExitSourceMaxed(c *SourceMaxedContext)
 
+	// ExitSrcAccountOverdraftSpecific is called when exiting the SrcAccountOverdraftSpecific production.
+	ExitSrcAccountOverdraftSpecific(c *SrcAccountOverdraftSpecificContext)
+
+	// ExitSrcAccountOverdraftUnbounded is called when exiting the SrcAccountOverdraftUnbounded production.
+	ExitSrcAccountOverdraftUnbounded(c *SrcAccountOverdraftUnboundedContext)
+
+	// ExitSourceAccount is called when exiting the sourceAccount production.
+	ExitSourceAccount(c *SourceAccountContext)
+
 	// ExitSrcAccount is called when exiting the SrcAccount production.
 	ExitSrcAccount(c *SrcAccountContext)
 
@@ -209,23 +305,41 @@ type NumScriptListener interface {
 	// ExitSrcAllotment is called when exiting the SrcAllotment production.
 	ExitSrcAllotment(c *SrcAllotmentContext)
 
+	// ExitStatementBlock is called when exiting the statementBlock production.
+	ExitStatementBlock(c *StatementBlockContext)
+
+	// ExitIfStatement is called when exiting the ifStatement production.
+	ExitIfStatement(c *IfStatementContext)
+
 	// ExitPrint is called when exiting the Print production.
 	ExitPrint(c *PrintContext)
 
 	// ExitSetTxMeta is called when exiting the SetTxMeta production.
 	ExitSetTxMeta(c *SetTxMetaContext)
 
+	// ExitSetAccountMeta is called when exiting the SetAccountMeta production.
+	ExitSetAccountMeta(c *SetAccountMetaContext)
+
 	// ExitFail is called when exiting the Fail production.
 	ExitFail(c *FailContext)
 
+	// ExitAssert is called when exiting the Assert production.
+	ExitAssert(c *AssertContext)
+
 	// ExitSend is called when exiting the Send production.
 	ExitSend(c *SendContext)
 
+	// ExitIf is called when exiting the If production.
+	ExitIf(c *IfContext)
+
 	// ExitType_ is called when exiting the type_ production.
 	ExitType_(c *Type_Context)
 
-	// ExitOrigin is called when exiting the origin production.
-	ExitOrigin(c *OriginContext)
+	// ExitOriginAccountMeta is called when exiting the OriginAccountMeta production.
+	ExitOriginAccountMeta(c *OriginAccountMetaContext)
+
+	// ExitOriginAccountBalance is called when exiting the OriginAccountBalance production.
+	ExitOriginAccountBalance(c *OriginAccountBalanceContext)
 
 	// ExitVarDecl is called when exiting the varDecl production.
 	ExitVarDecl(c *VarDeclContext)
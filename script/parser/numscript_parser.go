@@ -33,136 +33,195 @@ var numscriptParserStaticData struct {
 func numscriptParserInit() {
 	staticData := &numscriptParserStaticData
 	staticData.literalNames = []string{
-		"", "'*'", "','", "", "", "", "", "'vars'", "'meta'", "'set_tx_meta'",
-		"'print'", "'fail'", "'send'", "'source'", "'from'", "'max'", "'destination'",
-		"'to'", "'allocate'", "'+'", "'-'", "'('", "')'", "'['", "']'", "'{'",
-		"'}'", "'='", "'account'", "'asset'", "'number'", "'monetary'", "'portion'",
-		"'string'", "", "", "'remaining'", "'kept'", "", "'%'",
+		"", "','", "", "", "", "", "'vars'", "'meta'", "'balance'", "'set_tx_meta'",
+		"'set_account_meta'", "'print'", "'fail'", "'assert'", "'if'", "'else'",
+		"'send'", "'source'", "'from'", "'max'", "'destination'", "'to'", "'allocate'",
+		"'+'", "'-'", "'*'", "'=='", "'!='", "'<'", "'<='", "'>'", "'>='", "'&&'",
+		"'||'", "'!'", "'('", "')'", "'['", "']'", "'{'", "'}'", "'='", "'account'",
+		"'asset'", "'number'", "'monetary'", "'portion'", "'string'", "'bool'",
+		"'true'", "'false'", "", "", "'remaining'", "'kept'", "'allowing'", "'unbounded'",
+		"'overdraft'", "'up'", "'down'", "'rounded'", "", "'%'",
 	}
 	staticData.symbolicNames = []string{
-		"", "", "", "NEWLINE", "WHITESPACE", "MULTILINE_COMMENT", "LINE_COMMENT",
-		"VARS", "META", "SET_TX_META", "PRINT", "FAIL", "SEND", "SOURCE", "FROM",
-		"MAX", "DESTINATION", "TO", "ALLOCATE", "OP_ADD", "OP_SUB", "LPAREN",
-		"RPAREN", "LBRACK", "RBRACK", "LBRACE", "RBRACE", "EQ", "TY_ACCOUNT",
-		"TY_ASSET", "TY_NUMBER", "TY_MONETARY", "TY_PORTION", "TY_STRING", "STRING",
-		"PORTION", "REMAINING", "KEPT", "NUMBER", "PERCENT", "VARIABLE_NAME",
+		"", "", "NEWLINE", "WHITESPACE", "MULTILINE_COMMENT", "LINE_COMMENT",
+		"VARS", "META", "BALANCE", "SET_TX_META", "SET_ACCOUNT_META", "PRINT",
+		"FAIL", "ASSERT", "IF", "ELSE", "SEND", "SOURCE", "FROM", "MAX", "DESTINATION",
+		"TO", "ALLOCATE", "OP_ADD", "OP_SUB", "OP_MUL", "OP_EQ", "OP_NEQ", "OP_LT",
+		"OP_LTE", "OP_GT", "OP_GTE", "OP_AND", "OP_OR", "OP_NOT", "LPAREN", "RPAREN",
+		"LBRACK", "RBRACK", "LBRACE", "RBRACE", "EQ", "TY_ACCOUNT", "TY_ASSET",
+		"TY_NUMBER", "TY_MONETARY", "TY_PORTION", "TY_STRING", "TY_BOOL", "TRUE",
+		"FALSE", "STRING", "PORTION", "REMAINING", "KEPT", "ALLOWING", "UNBOUNDED",
+		"OVERDRAFT", "UP", "DOWN", "ROUNDED", "NUMBER", "PERCENT", "VARIABLE_NAME",
 		"ACCOUNT", "ASSET",
 	}
 	staticData.ruleNames = []string{
-		"monetary", "monetaryAll", "literal", "variable", "expression", "allotmentPortion",
-		"destinationInOrder", "destinationAllotment", "keptOrDestination", "destination",
-		"sourceInOrder", "sourceMaxed", "source", "sourceAllotment", "valueAwareSource",
-		"statement", "type_", "origin", "varDecl", "varListDecl", "script",
+		"monetary", "monetaryAll", "literal", "variable", "balance", "expression",
+		"allotmentPortion", "destinationInOrder", "destinationAllotment", "keptOrDestination",
+		"destination", "sourceInOrder", "sourceMaxed", "sourceAccountOverdraft",
+		"sourceAccount", "source", "sourceAllotment", "valueAwareSource", "statementBlock",
+		"ifStatement", "statement", "type_", "origin", "varDecl", "varListDecl",
+		"script",
 	}
 	staticData.predictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 42, 252, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 65, 358, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
 		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7, 15,
-		2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7, 20, 1,
-		0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1,
-		2, 1, 2, 1, 2, 3, 2, 58, 8, 2, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4, 3, 4, 65,
-		8, 4, 1, 4, 1, 4, 1, 4, 5, 4, 70, 8, 4, 10, 4, 12, 4, 73, 9, 4, 1, 5, 1,
-		5, 1, 5, 3, 5, 78, 8, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 4, 6,
-		87, 8, 6, 11, 6, 12, 6, 88, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 7, 1, 7, 1,
-		7, 1, 7, 1, 7, 1, 7, 4, 7, 102, 8, 7, 11, 7, 12, 7, 103, 1, 7, 1, 7, 1,
-		8, 1, 8, 1, 8, 3, 8, 111, 8, 8, 1, 9, 1, 9, 1, 9, 3, 9, 116, 8, 9, 1, 10,
-		1, 10, 1, 10, 1, 10, 1, 10, 4, 10, 123, 8, 10, 11, 10, 12, 10, 124, 1,
-		10, 1, 10, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 12, 1, 12, 1, 12, 3, 12,
-		137, 8, 12, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 4, 13, 146,
-		8, 13, 11, 13, 12, 13, 147, 1, 13, 1, 13, 1, 14, 1, 14, 3, 14, 154, 8,
-		14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15,
-		1, 15, 1, 15, 1, 15, 3, 15, 169, 8, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1,
-		15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15,
-		1, 15, 1, 15, 1, 15, 3, 15, 189, 8, 15, 1, 15, 1, 15, 1, 15, 3, 15, 194,
-		8, 15, 1, 16, 1, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1,
-		18, 1, 18, 1, 18, 1, 18, 3, 18, 209, 8, 18, 1, 19, 1, 19, 1, 19, 1, 19,
-		1, 19, 4, 19, 216, 8, 19, 11, 19, 12, 19, 217, 4, 19, 220, 8, 19, 11, 19,
-		12, 19, 221, 1, 19, 1, 19, 1, 19, 1, 20, 5, 20, 228, 8, 20, 10, 20, 12,
-		20, 231, 9, 20, 1, 20, 3, 20, 234, 8, 20, 1, 20, 1, 20, 1, 20, 5, 20, 239,
-		8, 20, 10, 20, 12, 20, 242, 9, 20, 1, 20, 5, 20, 245, 8, 20, 10, 20, 12,
-		20, 248, 9, 20, 1, 20, 1, 20, 1, 20, 0, 1, 8, 21, 0, 2, 4, 6, 8, 10, 12,
-		14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 0, 2, 1, 0, 19,
-		20, 1, 0, 28, 33, 260, 0, 42, 1, 0, 0, 0, 2, 47, 1, 0, 0, 0, 4, 57, 1,
-		0, 0, 0, 6, 59, 1, 0, 0, 0, 8, 64, 1, 0, 0, 0, 10, 77, 1, 0, 0, 0, 12,
-		79, 1, 0, 0, 0, 14, 95, 1, 0, 0, 0, 16, 110, 1, 0, 0, 0, 18, 115, 1, 0,
-		0, 0, 20, 117, 1, 0, 0, 0, 22, 128, 1, 0, 0, 0, 24, 136, 1, 0, 0, 0, 26,
-		138, 1, 0, 0, 0, 28, 153, 1, 0, 0, 0, 30, 193, 1, 0, 0, 0, 32, 195, 1,
-		0, 0, 0, 34, 197, 1, 0, 0, 0, 36, 204, 1, 0, 0, 0, 38, 210, 1, 0, 0, 0,
-		40, 229, 1, 0, 0, 0, 42, 43, 5, 23, 0, 0, 43, 44, 5, 42, 0, 0, 44, 45,
-		5, 38, 0, 0, 45, 46, 5, 24, 0, 0, 46, 1, 1, 0, 0, 0, 47, 48, 5, 23, 0,
-		0, 48, 49, 5, 42, 0, 0, 49, 50, 5, 1, 0, 0, 50, 51, 5, 24, 0, 0, 51, 3,
-		1, 0, 0, 0, 52, 58, 5, 41, 0, 0, 53, 58, 5, 42, 0, 0, 54, 58, 5, 38, 0,
-		0, 55, 58, 5, 34, 0, 0, 56, 58, 3, 0, 0, 0, 57, 52, 1, 0, 0, 0, 57, 53,
-		1, 0, 0, 0, 57, 54, 1, 0, 0, 0, 57, 55, 1, 0, 0, 0, 57, 56, 1, 0, 0, 0,
-		58, 5, 1, 0, 0, 0, 59, 60, 5, 40, 0, 0, 60, 7, 1, 0, 0, 0, 61, 62, 6, 4,
-		-1, 0, 62, 65, 3, 4, 2, 0, 63, 65, 3, 6, 3, 0, 64, 61, 1, 0, 0, 0, 64,
-		63, 1, 0, 0, 0, 65, 71, 1, 0, 0, 0, 66, 67, 10, 3, 0, 0, 67, 68, 7, 0,
-		0, 0, 68, 70, 3, 8, 4, 4, 69, 66, 1, 0, 0, 0, 70, 73, 1, 0, 0, 0, 71, 69,
-		1, 0, 0, 0, 71, 72, 1, 0, 0, 0, 72, 9, 1, 0, 0, 0, 73, 71, 1, 0, 0, 0,
-		74, 78, 5, 35, 0, 0, 75, 78, 3, 6, 3, 0, 76, 78, 5, 36, 0, 0, 77, 74, 1,
-		0, 0, 0, 77, 75, 1, 0, 0, 0, 77, 76, 1, 0, 0, 0, 78, 11, 1, 0, 0, 0, 79,
-		80, 5, 25, 0, 0, 80, 86, 5, 3, 0, 0, 81, 82, 5, 15, 0, 0, 82, 83, 3, 8,
-		4, 0, 83, 84, 3, 16, 8, 0, 84, 85, 5, 3, 0, 0, 85, 87, 1, 0, 0, 0, 86,
-		81, 1, 0, 0, 0, 87, 88, 1, 0, 0, 0, 88, 86, 1, 0, 0, 0, 88, 89, 1, 0, 0,
-		0, 89, 90, 1, 0, 0, 0, 90, 91, 5, 36, 0, 0, 91, 92, 3, 16, 8, 0, 92, 93,
-		5, 3, 0, 0, 93, 94, 5, 26, 0, 0, 94, 13, 1, 0, 0, 0, 95, 96, 5, 25, 0,
-		0, 96, 101, 5, 3, 0, 0, 97, 98, 3, 10, 5, 0, 98, 99, 3, 16, 8, 0, 99, 100,
-		5, 3, 0, 0, 100, 102, 1, 0, 0, 0, 101, 97, 1, 0, 0, 0, 102, 103, 1, 0,
-		0, 0, 103, 101, 1, 0, 0, 0, 103, 104, 1, 0, 0, 0, 104, 105, 1, 0, 0, 0,
-		105, 106, 5, 26, 0, 0, 106, 15, 1, 0, 0, 0, 107, 108, 5, 17, 0, 0, 108,
-		111, 3, 18, 9, 0, 109, 111, 5, 37, 0, 0, 110, 107, 1, 0, 0, 0, 110, 109,
-		1, 0, 0, 0, 111, 17, 1, 0, 0, 0, 112, 116, 3, 8, 4, 0, 113, 116, 3, 12,
-		6, 0, 114, 116, 3, 14, 7, 0, 115, 112, 1, 0, 0, 0, 115, 113, 1, 0, 0, 0,
-		115, 114, 1, 0, 0, 0, 116, 19, 1, 0, 0, 0, 117, 118, 5, 25, 0, 0, 118,
-		122, 5, 3, 0, 0, 119, 120, 3, 24, 12, 0, 120, 121, 5, 3, 0, 0, 121, 123,
-		1, 0, 0, 0, 122, 119, 1, 0, 0, 0, 123, 124, 1, 0, 0, 0, 124, 122, 1, 0,
-		0, 0, 124, 125, 1, 0, 0, 0, 125, 126, 1, 0, 0, 0, 126, 127, 5, 26, 0, 0,
-		127, 21, 1, 0, 0, 0, 128, 129, 5, 15, 0, 0, 129, 130, 3, 8, 4, 0, 130,
-		131, 5, 14, 0, 0, 131, 132, 3, 24, 12, 0, 132, 23, 1, 0, 0, 0, 133, 137,
-		3, 8, 4, 0, 134, 137, 3, 22, 11, 0, 135, 137, 3, 20, 10, 0, 136, 133, 1,
-		0, 0, 0, 136, 134, 1, 0, 0, 0, 136, 135, 1, 0, 0, 0, 137, 25, 1, 0, 0,
-		0, 138, 139, 5, 25, 0, 0, 139, 145, 5, 3, 0, 0, 140, 141, 3, 10, 5, 0,
-		141, 142, 5, 14, 0, 0, 142, 143, 3, 24, 12, 0, 143, 144, 5, 3, 0, 0, 144,
-		146, 1, 0, 0, 0, 145, 140, 1, 0, 0, 0, 146, 147, 1, 0, 0, 0, 147, 145,
-		1, 0, 0, 0, 147, 148, 1, 0, 0, 0, 148, 149, 1, 0, 0, 0, 149, 150, 5, 26,
-		0, 0, 150, 27, 1, 0, 0, 0, 151, 154, 3, 24, 12, 0, 152, 154, 3, 26, 13,
-		0, 153, 151, 1, 0, 0, 0, 153, 152, 1, 0, 0, 0, 154, 29, 1, 0, 0, 0, 155,
-		156, 5, 10, 0, 0, 156, 194, 3, 8, 4, 0, 157, 158, 5, 9, 0, 0, 158, 159,
-		5, 21, 0, 0, 159, 160, 5, 34, 0, 0, 160, 161, 5, 2, 0, 0, 161, 162, 3,
-		8, 4, 0, 162, 163, 5, 22, 0, 0, 163, 194, 1, 0, 0, 0, 164, 194, 5, 11,
-		0, 0, 165, 168, 5, 12, 0, 0, 166, 169, 3, 8, 4, 0, 167, 169, 3, 2, 1, 0,
-		168, 166, 1, 0, 0, 0, 168, 167, 1, 0, 0, 0, 169, 170, 1, 0, 0, 0, 170,
-		171, 5, 21, 0, 0, 171, 188, 5, 3, 0, 0, 172, 173, 5, 13, 0, 0, 173, 174,
-		5, 27, 0, 0, 174, 175, 3, 28, 14, 0, 175, 176, 5, 3, 0, 0, 176, 177, 5,
-		16, 0, 0, 177, 178, 5, 27, 0, 0, 178, 179, 3, 18, 9, 0, 179, 189, 1, 0,
-		0, 0, 180, 181, 5, 16, 0, 0, 181, 182, 5, 27, 0, 0, 182, 183, 3, 18, 9,
-		0, 183, 184, 5, 3, 0, 0, 184, 185, 5, 13, 0, 0, 185, 186, 5, 27, 0, 0,
-		186, 187, 3, 28, 14, 0, 187, 189, 1, 0, 0, 0, 188, 172, 1, 0, 0, 0, 188,
-		180, 1, 0, 0, 0, 189, 190, 1, 0, 0, 0, 190, 191, 5, 3, 0, 0, 191, 192,
-		5, 22, 0, 0, 192, 194, 1, 0, 0, 0, 193, 155, 1, 0, 0, 0, 193, 157, 1, 0,
-		0, 0, 193, 164, 1, 0, 0, 0, 193, 165, 1, 0, 0, 0, 194, 31, 1, 0, 0, 0,
-		195, 196, 7, 1, 0, 0, 196, 33, 1, 0, 0, 0, 197, 198, 5, 8, 0, 0, 198, 199,
-		5, 21, 0, 0, 199, 200, 3, 8, 4, 0, 200, 201, 5, 2, 0, 0, 201, 202, 5, 34,
-		0, 0, 202, 203, 5, 22, 0, 0, 203, 35, 1, 0, 0, 0, 204, 205, 3, 32, 16,
-		0, 205, 208, 3, 6, 3, 0, 206, 207, 5, 27, 0, 0, 207, 209, 3, 34, 17, 0,
-		208, 206, 1, 0, 0, 0, 208, 209, 1, 0, 0, 0, 209, 37, 1, 0, 0, 0, 210, 211,
-		5, 7, 0, 0, 211, 212, 5, 25, 0, 0, 212, 219, 5, 3, 0, 0, 213, 215, 3, 36,
-		18, 0, 214, 216, 5, 3, 0, 0, 215, 214, 1, 0, 0, 0, 216, 217, 1, 0, 0, 0,
-		217, 215, 1, 0, 0, 0, 217, 218, 1, 0, 0, 0, 218, 220, 1, 0, 0, 0, 219,
-		213, 1, 0, 0, 0, 220, 221, 1, 0, 0, 0, 221, 219, 1, 0, 0, 0, 221, 222,
-		1, 0, 0, 0, 222, 223, 1, 0, 0, 0, 223, 224, 5, 26, 0, 0, 224, 225, 5, 3,
-		0, 0, 225, 39, 1, 0, 0, 0, 226, 228, 5, 3, 0, 0, 227, 226, 1, 0, 0, 0,
-		228, 231, 1, 0, 0, 0, 229, 227, 1, 0, 0, 0, 229, 230, 1, 0, 0, 0, 230,
-		233, 1, 0, 0, 0, 231, 229, 1, 0, 0, 0, 232, 234, 3, 38, 19, 0, 233, 232,
-		1, 0, 0, 0, 233, 234, 1, 0, 0, 0, 234, 235, 1, 0, 0, 0, 235, 240, 3, 30,
-		15, 0, 236, 237, 5, 3, 0, 0, 237, 239, 3, 30, 15, 0, 238, 236, 1, 0, 0,
-		0, 239, 242, 1, 0, 0, 0, 240, 238, 1, 0, 0, 0, 240, 241, 1, 0, 0, 0, 241,
-		246, 1, 0, 0, 0, 242, 240, 1, 0, 0, 0, 243, 245, 5, 3, 0, 0, 244, 243,
-		1, 0, 0, 0, 245, 248, 1, 0, 0, 0, 246, 244, 1, 0, 0, 0, 246, 247, 1, 0,
-		0, 0, 247, 249, 1, 0, 0, 0, 248, 246, 1, 0, 0, 0, 249, 250, 5, 0, 0, 1,
-		250, 41, 1, 0, 0, 0, 22, 57, 64, 71, 77, 88, 103, 110, 115, 124, 136, 147,
-		153, 168, 188, 193, 208, 217, 221, 229, 233, 240, 246,
+		2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7, 20, 2,
+		21, 7, 21, 2, 22, 7, 22, 2, 23, 7, 23, 2, 24, 7, 24, 2, 25, 7, 25, 1, 0,
+		1, 0, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2,
+		1, 2, 1, 2, 1, 2, 1, 2, 3, 2, 70, 8, 2, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4, 1,
+		4, 1, 4, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1,
+		5, 1, 5, 3, 5, 91, 8, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 3, 5, 98, 8, 5,
+		1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5,
+		5, 5, 112, 8, 5, 10, 5, 12, 5, 115, 9, 5, 1, 6, 1, 6, 1, 6, 3, 6, 120,
+		8, 6, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 4, 7, 129, 8, 7, 11, 7,
+		12, 7, 130, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8,
+		1, 8, 4, 8, 144, 8, 8, 11, 8, 12, 8, 145, 1, 8, 1, 8, 1, 9, 1, 9, 1, 9,
+		3, 9, 153, 8, 9, 1, 10, 1, 10, 1, 10, 3, 10, 158, 8, 10, 1, 11, 1, 11,
+		1, 11, 1, 11, 1, 11, 4, 11, 165, 8, 11, 11, 11, 12, 11, 166, 1, 11, 1,
+		11, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13,
+		1, 13, 1, 13, 1, 13, 3, 13, 184, 8, 13, 1, 14, 1, 14, 3, 14, 188, 8, 14,
+		1, 15, 1, 15, 1, 15, 3, 15, 193, 8, 15, 1, 16, 1, 16, 1, 16, 1, 16, 1,
+		16, 1, 16, 1, 16, 4, 16, 202, 8, 16, 11, 16, 12, 16, 203, 1, 16, 1, 16,
+		1, 17, 1, 17, 3, 17, 210, 8, 17, 1, 18, 1, 18, 4, 18, 214, 8, 18, 11, 18,
+		12, 18, 215, 1, 18, 1, 18, 4, 18, 220, 8, 18, 11, 18, 12, 18, 221, 5, 18,
+		224, 8, 18, 10, 18, 12, 18, 227, 9, 18, 1, 18, 1, 18, 1, 19, 1, 19, 1,
+		19, 1, 19, 1, 19, 1, 19, 3, 19, 237, 8, 19, 3, 19, 239, 8, 19, 1, 20, 1,
+		20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20,
+		1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 3, 20, 261, 8,
+		20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 3, 20, 271,
+		8, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1,
+		20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 3, 20, 291,
+		8, 20, 1, 20, 1, 20, 1, 20, 1, 20, 3, 20, 297, 8, 20, 1, 21, 1, 21, 1,
+		22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 3, 22, 309, 8, 22,
+		1, 23, 1, 23, 1, 23, 1, 23, 3, 23, 315, 8, 23, 1, 24, 1, 24, 1, 24, 1,
+		24, 1, 24, 4, 24, 322, 8, 24, 11, 24, 12, 24, 323, 4, 24, 326, 8, 24, 11,
+		24, 12, 24, 327, 1, 24, 1, 24, 1, 24, 1, 25, 5, 25, 334, 8, 25, 10, 25,
+		12, 25, 337, 9, 25, 1, 25, 3, 25, 340, 8, 25, 1, 25, 1, 25, 1, 25, 5, 25,
+		345, 8, 25, 10, 25, 12, 25, 348, 9, 25, 1, 25, 5, 25, 351, 8, 25, 10, 25,
+		12, 25, 354, 9, 25, 1, 25, 1, 25, 1, 25, 0, 1, 10, 26, 0, 2, 4, 6, 8, 10,
+		12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46,
+		48, 50, 0, 5, 1, 0, 49, 50, 1, 0, 58, 59, 1, 0, 23, 24, 1, 0, 26, 31, 1,
+		0, 42, 48, 383, 0, 52, 1, 0, 0, 0, 2, 57, 1, 0, 0, 0, 4, 69, 1, 0, 0, 0,
+		6, 71, 1, 0, 0, 0, 8, 73, 1, 0, 0, 0, 10, 90, 1, 0, 0, 0, 12, 119, 1, 0,
+		0, 0, 14, 121, 1, 0, 0, 0, 16, 137, 1, 0, 0, 0, 18, 152, 1, 0, 0, 0, 20,
+		157, 1, 0, 0, 0, 22, 159, 1, 0, 0, 0, 24, 170, 1, 0, 0, 0, 26, 183, 1,
+		0, 0, 0, 28, 185, 1, 0, 0, 0, 30, 192, 1, 0, 0, 0, 32, 194, 1, 0, 0, 0,
+		34, 209, 1, 0, 0, 0, 36, 211, 1, 0, 0, 0, 38, 230, 1, 0, 0, 0, 40, 296,
+		1, 0, 0, 0, 42, 298, 1, 0, 0, 0, 44, 308, 1, 0, 0, 0, 46, 310, 1, 0, 0,
+		0, 48, 316, 1, 0, 0, 0, 50, 335, 1, 0, 0, 0, 52, 53, 5, 37, 0, 0, 53, 54,
+		5, 65, 0, 0, 54, 55, 5, 61, 0, 0, 55, 56, 5, 38, 0, 0, 56, 1, 1, 0, 0,
+		0, 57, 58, 5, 37, 0, 0, 58, 59, 5, 65, 0, 0, 59, 60, 5, 25, 0, 0, 60, 61,
+		5, 38, 0, 0, 61, 3, 1, 0, 0, 0, 62, 70, 5, 64, 0, 0, 63, 70, 5, 65, 0,
+		0, 64, 70, 5, 61, 0, 0, 65, 70, 5, 51, 0, 0, 66, 70, 7, 0, 0, 0, 67, 70,
+		5, 52, 0, 0, 68, 70, 3, 0, 0, 0, 69, 62, 1, 0, 0, 0, 69, 63, 1, 0, 0, 0,
+		69, 64, 1, 0, 0, 0, 69, 65, 1, 0, 0, 0, 69, 66, 1, 0, 0, 0, 69, 67, 1,
+		0, 0, 0, 69, 68, 1, 0, 0, 0, 70, 5, 1, 0, 0, 0, 71, 72, 5, 63, 0, 0, 72,
+		7, 1, 0, 0, 0, 73, 74, 5, 8, 0, 0, 74, 75, 5, 35, 0, 0, 75, 76, 3, 10,
+		5, 0, 76, 77, 5, 1, 0, 0, 77, 78, 3, 10, 5, 0, 78, 79, 5, 36, 0, 0, 79,
+		9, 1, 0, 0, 0, 80, 81, 6, 5, -1, 0, 81, 82, 5, 34, 0, 0, 82, 91, 3, 10,
+		5, 10, 83, 84, 5, 35, 0, 0, 84, 85, 3, 10, 5, 0, 85, 86, 5, 36, 0, 0, 86,
+		91, 1, 0, 0, 0, 87, 91, 3, 4, 2, 0, 88, 91, 3, 6, 3, 0, 89, 91, 3, 8, 4,
+		0, 90, 80, 1, 0, 0, 0, 90, 83, 1, 0, 0, 0, 90, 87, 1, 0, 0, 0, 90, 88,
+		1, 0, 0, 0, 90, 89, 1, 0, 0, 0, 91, 113, 1, 0, 0, 0, 92, 93, 10, 9, 0,
+		0, 93, 94, 5, 25, 0, 0, 94, 97, 3, 10, 5, 10, 95, 96, 5, 60, 0, 0, 96,
+		98, 7, 1, 0, 0, 97, 95, 1, 0, 0, 0, 97, 98, 1, 0, 0, 0, 98, 112, 1, 0,
+		0, 0, 99, 100, 10, 8, 0, 0, 100, 101, 7, 2, 0, 0, 101, 112, 3, 10, 5, 9,
+		102, 103, 10, 7, 0, 0, 103, 104, 7, 3, 0, 0, 104, 112, 3, 10, 5, 8, 105,
+		106, 10, 6, 0, 0, 106, 107, 5, 32, 0, 0, 107, 112, 3, 10, 5, 7, 108, 109,
+		10, 5, 0, 0, 109, 110, 5, 33, 0, 0, 110, 112, 3, 10, 5, 6, 111, 92, 1,
+		0, 0, 0, 111, 99, 1, 0, 0, 0, 111, 102, 1, 0, 0, 0, 111, 105, 1, 0, 0,
+		0, 111, 108, 1, 0, 0, 0, 112, 115, 1, 0, 0, 0, 113, 111, 1, 0, 0, 0, 113,
+		114, 1, 0, 0, 0, 114, 11, 1, 0, 0, 0, 115, 113, 1, 0, 0, 0, 116, 120, 5,
+		52, 0, 0, 117, 120, 3, 6, 3, 0, 118, 120, 5, 53, 0, 0, 119, 116, 1, 0,
+		0, 0, 119, 117, 1, 0, 0, 0, 119, 118, 1, 0, 0, 0, 120, 13, 1, 0, 0, 0,
+		121, 122, 5, 39, 0, 0, 122, 128, 5, 2, 0, 0, 123, 124, 5, 19, 0, 0, 124,
+		125, 3, 10, 5, 0, 125, 126, 3, 18, 9, 0, 126, 127, 5, 2, 0, 0, 127, 129,
+		1, 0, 0, 0, 128, 123, 1, 0, 0, 0, 129, 130, 1, 0, 0, 0, 130, 128, 1, 0,
+		0, 0, 130, 131, 1, 0, 0, 0, 131, 132, 1, 0, 0, 0, 132, 133, 5, 53, 0, 0,
+		133, 134, 3, 18, 9, 0, 134, 135, 5, 2, 0, 0, 135, 136, 5, 40, 0, 0, 136,
+		15, 1, 0, 0, 0, 137, 138, 5, 39, 0, 0, 138, 143, 5, 2, 0, 0, 139, 140,
+		3, 12, 6, 0, 140, 141, 3, 18, 9, 0, 141, 142, 5, 2, 0, 0, 142, 144, 1,
+		0, 0, 0, 143, 139, 1, 0, 0, 0, 144, 145, 1, 0, 0, 0, 145, 143, 1, 0, 0,
+		0, 145, 146, 1, 0, 0, 0, 146, 147, 1, 0, 0, 0, 147, 148, 5, 40, 0, 0, 148,
+		17, 1, 0, 0, 0, 149, 150, 5, 21, 0, 0, 150, 153, 3, 20, 10, 0, 151, 153,
+		5, 54, 0, 0, 152, 149, 1, 0, 0, 0, 152, 151, 1, 0, 0, 0, 153, 19, 1, 0,
+		0, 0, 154, 158, 3, 10, 5, 0, 155, 158, 3, 14, 7, 0, 156, 158, 3, 16, 8,
+		0, 157, 154, 1, 0, 0, 0, 157, 155, 1, 0, 0, 0, 157, 156, 1, 0, 0, 0, 158,
+		21, 1, 0, 0, 0, 159, 160, 5, 39, 0, 0, 160, 164, 5, 2, 0, 0, 161, 162,
+		3, 30, 15, 0, 162, 163, 5, 2, 0, 0, 163, 165, 1, 0, 0, 0, 164, 161, 1,
+		0, 0, 0, 165, 166, 1, 0, 0, 0, 166, 164, 1, 0, 0, 0, 166, 167, 1, 0, 0,
+		0, 167, 168, 1, 0, 0, 0, 168, 169, 5, 40, 0, 0, 169, 23, 1, 0, 0, 0, 170,
+		171, 5, 19, 0, 0, 171, 172, 3, 10, 5, 0, 172, 173, 5, 18, 0, 0, 173, 174,
+		3, 30, 15, 0, 174, 25, 1, 0, 0, 0, 175, 176, 5, 55, 0, 0, 176, 177, 5,
+		57, 0, 0, 177, 178, 5, 58, 0, 0, 178, 179, 5, 21, 0, 0, 179, 184, 3, 10,
+		5, 0, 180, 181, 5, 55, 0, 0, 181, 182, 5, 56, 0, 0, 182, 184, 5, 57, 0,
+		0, 183, 175, 1, 0, 0, 0, 183, 180, 1, 0, 0, 0, 184, 27, 1, 0, 0, 0, 185,
+		187, 3, 10, 5, 0, 186, 188, 3, 26, 13, 0, 187, 186, 1, 0, 0, 0, 187, 188,
+		1, 0, 0, 0, 188, 29, 1, 0, 0, 0, 189, 193, 3, 28, 14, 0, 190, 193, 3, 24,
+		12, 0, 191, 193, 3, 22, 11, 0, 192, 189, 1, 0, 0, 0, 192, 190, 1, 0, 0,
+		0, 192, 191, 1, 0, 0, 0, 193, 31, 1, 0, 0, 0, 194, 195, 5, 39, 0, 0, 195,
+		201, 5, 2, 0, 0, 196, 197, 3, 12, 6, 0, 197, 198, 5, 18, 0, 0, 198, 199,
+		3, 30, 15, 0, 199, 200, 5, 2, 0, 0, 200, 202, 1, 0, 0, 0, 201, 196, 1,
+		0, 0, 0, 202, 203, 1, 0, 0, 0, 203, 201, 1, 0, 0, 0, 203, 204, 1, 0, 0,
+		0, 204, 205, 1, 0, 0, 0, 205, 206, 5, 40, 0, 0, 206, 33, 1, 0, 0, 0, 207,
+		210, 3, 30, 15, 0, 208, 210, 3, 32, 16, 0, 209, 207, 1, 0, 0, 0, 209, 208,
+		1, 0, 0, 0, 210, 35, 1, 0, 0, 0, 211, 213, 5, 39, 0, 0, 212, 214, 5, 2,
+		0, 0, 213, 212, 1, 0, 0, 0, 214, 215, 1, 0, 0, 0, 215, 213, 1, 0, 0, 0,
+		215, 216, 1, 0, 0, 0, 216, 225, 1, 0, 0, 0, 217, 219, 3, 40, 20, 0, 218,
+		220, 5, 2, 0, 0, 219, 218, 1, 0, 0, 0, 220, 221, 1, 0, 0, 0, 221, 219,
+		1, 0, 0, 0, 221, 222, 1, 0, 0, 0, 222, 224, 1, 0, 0, 0, 223, 217, 1, 0,
+		0, 0, 224, 227, 1, 0, 0, 0, 225, 223, 1, 0, 0, 0, 225, 226, 1, 0, 0, 0,
+		226, 228, 1, 0, 0, 0, 227, 225, 1, 0, 0, 0, 228, 229, 5, 40, 0, 0, 229,
+		37, 1, 0, 0, 0, 230, 231, 5, 14, 0, 0, 231, 232, 3, 10, 5, 0, 232, 238,
+		3, 36, 18, 0, 233, 236, 5, 15, 0, 0, 234, 237, 3, 36, 18, 0, 235, 237,
+		3, 38, 19, 0, 236, 234, 1, 0, 0, 0, 236, 235, 1, 0, 0, 0, 237, 239, 1,
+		0, 0, 0, 238, 233, 1, 0, 0, 0, 238, 239, 1, 0, 0, 0, 239, 39, 1, 0, 0,
+		0, 240, 241, 5, 11, 0, 0, 241, 297, 3, 10, 5, 0, 242, 243, 5, 9, 0, 0,
+		243, 244, 5, 35, 0, 0, 244, 245, 5, 51, 0, 0, 245, 246, 5, 1, 0, 0, 246,
+		247, 3, 10, 5, 0, 247, 248, 5, 36, 0, 0, 248, 297, 1, 0, 0, 0, 249, 250,
+		5, 10, 0, 0, 250, 251, 5, 35, 0, 0, 251, 252, 3, 10, 5, 0, 252, 253, 5,
+		1, 0, 0, 253, 254, 5, 51, 0, 0, 254, 255, 5, 1, 0, 0, 255, 256, 3, 10,
+		5, 0, 256, 257, 5, 36, 0, 0, 257, 297, 1, 0, 0, 0, 258, 260, 5, 12, 0,
+		0, 259, 261, 5, 51, 0, 0, 260, 259, 1, 0, 0, 0, 260, 261, 1, 0, 0, 0, 261,
+		297, 1, 0, 0, 0, 262, 263, 5, 13, 0, 0, 263, 264, 3, 10, 5, 0, 264, 265,
+		5, 1, 0, 0, 265, 266, 5, 51, 0, 0, 266, 297, 1, 0, 0, 0, 267, 270, 5, 16,
+		0, 0, 268, 271, 3, 10, 5, 0, 269, 271, 3, 2, 1, 0, 270, 268, 1, 0, 0, 0,
+		270, 269, 1, 0, 0, 0, 271, 272, 1, 0, 0, 0, 272, 273, 5, 35, 0, 0, 273,
+		290, 5, 2, 0, 0, 274, 275, 5, 17, 0, 0, 275, 276, 5, 41, 0, 0, 276, 277,
+		3, 34, 17, 0, 277, 278, 5, 2, 0, 0, 278, 279, 5, 20, 0, 0, 279, 280, 5,
+		41, 0, 0, 280, 281, 3, 20, 10, 0, 281, 291, 1, 0, 0, 0, 282, 283, 5, 20,
+		0, 0, 283, 284, 5, 41, 0, 0, 284, 285, 3, 20, 10, 0, 285, 286, 5, 2, 0,
+		0, 286, 287, 5, 17, 0, 0, 287, 288, 5, 41, 0, 0, 288, 289, 3, 34, 17, 0,
+		289, 291, 1, 0, 0, 0, 290, 274, 1, 0, 0, 0, 290, 282, 1, 0, 0, 0, 291,
+		292, 1, 0, 0, 0, 292, 293, 5, 2, 0, 0, 293, 294, 5, 36, 0, 0, 294, 297,
+		1, 0, 0, 0, 295, 297, 3, 38, 19, 0, 296, 240, 1, 0, 0, 0, 296, 242, 1,
+		0, 0, 0, 296, 249, 1, 0, 0, 0, 296, 258, 1, 0, 0, 0, 296, 262, 1, 0, 0,
+		0, 296, 267, 1, 0, 0, 0, 296, 295, 1, 0, 0, 0, 297, 41, 1, 0, 0, 0, 298,
+		299, 7, 4, 0, 0, 299, 43, 1, 0, 0, 0, 300, 301, 5, 7, 0, 0, 301, 302, 5,
+		35, 0, 0, 302, 303, 3, 10, 5, 0, 303, 304, 5, 1, 0, 0, 304, 305, 5, 51,
+		0, 0, 305, 306, 5, 36, 0, 0, 306, 309, 1, 0, 0, 0, 307, 309, 3, 8, 4, 0,
+		308, 300, 1, 0, 0, 0, 308, 307, 1, 0, 0, 0, 309, 45, 1, 0, 0, 0, 310, 311,
+		3, 42, 21, 0, 311, 314, 3, 6, 3, 0, 312, 313, 5, 41, 0, 0, 313, 315, 3,
+		44, 22, 0, 314, 312, 1, 0, 0, 0, 314, 315, 1, 0, 0, 0, 315, 47, 1, 0, 0,
+		0, 316, 317, 5, 6, 0, 0, 317, 318, 5, 39, 0, 0, 318, 325, 5, 2, 0, 0, 319,
+		321, 3, 46, 23, 0, 320, 322, 5, 2, 0, 0, 321, 320, 1, 0, 0, 0, 322, 323,
+		1, 0, 0, 0, 323, 321, 1, 0, 0, 0, 323, 324, 1, 0, 0, 0, 324, 326, 1, 0,
+		0, 0, 325, 319, 1, 0, 0, 0, 326, 327, 1, 0, 0, 0, 327, 325, 1, 0, 0, 0,
+		327, 328, 1, 0, 0, 0, 328, 329, 1, 0, 0, 0, 329, 330, 5, 40, 0, 0, 330,
+		331, 5, 2, 0, 0, 331, 49, 1, 0, 0, 0, 332, 334, 5, 2, 0, 0, 333, 332, 1,
+		0, 0, 0, 334, 337, 1, 0, 0, 0, 335, 333, 1, 0, 0, 0, 335, 336, 1, 0, 0,
+		0, 336, 339, 1, 0, 0, 0, 337, 335, 1, 0, 0, 0, 338, 340, 3, 48, 24, 0,
+		339, 338, 1, 0, 0, 0, 339, 340, 1, 0, 0, 0, 340, 341, 1, 0, 0, 0, 341,
+		346, 3, 40, 20, 0, 342, 343, 5, 2, 0, 0, 343, 345, 3, 40, 20, 0, 344, 342,
+		1, 0, 0, 0, 345, 348, 1, 0, 0, 0, 346, 344, 1, 0, 0, 0, 346, 347, 1, 0,
+		0, 0, 347, 352, 1, 0, 0, 0, 348, 346, 1, 0, 0, 0, 349, 351, 5, 2, 0, 0,
+		350, 349, 1, 0, 0, 0, 351, 354, 1, 0, 0, 0, 352, 350, 1, 0, 0, 0, 352,
+		353, 1, 0, 0, 0, 353, 355, 1, 0, 0, 0, 354, 352, 1, 0, 0, 0, 355, 356,
+		5, 0, 0, 1, 356, 51, 1, 0, 0, 0, 33, 69, 90, 97, 111, 113, 119, 130, 145,
+		152, 157, 166, 183, 187, 192, 203, 209, 215, 221, 225, 236, 238, 260, 270,
+		290, 296, 308, 314, 323, 327, 335, 339, 346, 352,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -202,72 +261,100 @@ func NewNumScriptParser(input antlr.TokenStream) *NumScriptParser {
 const (
 	NumScriptParserEOF               = antlr.TokenEOF
 	NumScriptParserT__0              = 1
-	NumScriptParserT__1              = 2
-	NumScriptParserNEWLINE           = 3
-	NumScriptParserWHITESPACE        = 4
-	NumScriptParserMULTILINE_COMMENT = 5
-	NumScriptParserLINE_COMMENT      = 6
-	NumScriptParserVARS              = 7
-	NumScriptParserMETA              = 8
+	NumScriptParserNEWLINE           = 2
+	NumScriptParserWHITESPACE        = 3
+	NumScriptParserMULTILINE_COMMENT = 4
+	NumScriptParserLINE_COMMENT      = 5
+	NumScriptParserVARS              = 6
+	NumScriptParserMETA              = 7
+	NumScriptParserBALANCE           = 8
 	NumScriptParserSET_TX_META       = 9
-	NumScriptParserPRINT             = 10
-	NumScriptParserFAIL              = 11
-	NumScriptParserSEND              = 12
-	NumScriptParserSOURCE            = 13
-	NumScriptParserFROM              = 14
-	NumScriptParserMAX               = 15
-	NumScriptParserDESTINATION       = 16
-	NumScriptParserTO                = 17
-	NumScriptParserALLOCATE          = 18
-	NumScriptParserOP_ADD            = 19
-	NumScriptParserOP_SUB            = 20
-	NumScriptParserLPAREN            = 21
-	NumScriptParserRPAREN            = 22
-	NumScriptParserLBRACK            = 23
-	NumScriptParserRBRACK            = 24
-	NumScriptParserLBRACE            = 25
-	NumScriptParserRBRACE            = 26
-	NumScriptParserEQ                = 27
-	NumScriptParserTY_ACCOUNT        = 28
-	NumScriptParserTY_ASSET          = 29
-	NumScriptParserTY_NUMBER         = 30
-	NumScriptParserTY_MONETARY       = 31
-	NumScriptParserTY_PORTION        = 32
-	NumScriptParserTY_STRING         = 33
-	NumScriptParserSTRING            = 34
-	NumScriptParserPORTION           = 35
-	NumScriptParserREMAINING         = 36
-	NumScriptParserKEPT              = 37
-	NumScriptParserNUMBER            = 38
-	NumScriptParserPERCENT           = 39
-	NumScriptParserVARIABLE_NAME     = 40
-	NumScriptParserACCOUNT           = 41
-	NumScriptParserASSET             = 42
+	NumScriptParserSET_ACCOUNT_META  = 10
+	NumScriptParserPRINT             = 11
+	NumScriptParserFAIL              = 12
+	NumScriptParserASSERT            = 13
+	NumScriptParserIF                = 14
+	NumScriptParserELSE              = 15
+	NumScriptParserSEND              = 16
+	NumScriptParserSOURCE            = 17
+	NumScriptParserFROM              = 18
+	NumScriptParserMAX               = 19
+	NumScriptParserDESTINATION       = 20
+	NumScriptParserTO                = 21
+	NumScriptParserALLOCATE          = 22
+	NumScriptParserOP_ADD            = 23
+	NumScriptParserOP_SUB            = 24
+	NumScriptParserOP_MUL            = 25
+	NumScriptParserOP_EQ             = 26
+	NumScriptParserOP_NEQ            = 27
+	NumScriptParserOP_LT             = 28
+	NumScriptParserOP_LTE            = 29
+	NumScriptParserOP_GT             = 30
+	NumScriptParserOP_GTE            = 31
+	NumScriptParserOP_AND            = 32
+	NumScriptParserOP_OR             = 33
+	NumScriptParserOP_NOT            = 34
+	NumScriptParserLPAREN            = 35
+	NumScriptParserRPAREN            = 36
+	NumScriptParserLBRACK            = 37
+	NumScriptParserRBRACK            = 38
+	NumScriptParserLBRACE            = 39
+	NumScriptParserRBRACE            = 40
+	NumScriptParserEQ                = 41
+	NumScriptParserTY_ACCOUNT        = 42
+	NumScriptParserTY_ASSET          = 43
+	NumScriptParserTY_NUMBER         = 44
+	NumScriptParserTY_MONETARY       = 45
+	NumScriptParserTY_PORTION        = 46
+	NumScriptParserTY_STRING         = 47
+	NumScriptParserTY_BOOL           = 48
+	NumScriptParserTRUE              = 49
+	NumScriptParserFALSE             = 50
+	NumScriptParserSTRING            = 51
+	NumScriptParserPORTION           = 52
+	NumScriptParserREMAINING         = 53
+	NumScriptParserKEPT              = 54
+	NumScriptParserALLOWING          = 55
+	NumScriptParserUNBOUNDED         = 56
+	NumScriptParserOVERDRAFT         = 57
+	NumScriptParserUP                = 58
+	NumScriptParserDOWN              = 59
+	NumScriptParserROUNDED           = 60
+	NumScriptParserNUMBER            = 61
+	NumScriptParserPERCENT           = 62
+	NumScriptParserVARIABLE_NAME     = 63
+	NumScriptParserACCOUNT           = 64
+	NumScriptParserASSET             = 65
 )
 
 // NumScriptParser rules.
 const (
-	NumScriptParserRULE_monetary             = 0
-	NumScriptParserRULE_monetaryAll          = 1
-	NumScriptParserRULE_literal              = 2
-	NumScriptParserRULE_variable             = 3
-	NumScriptParserRULE_expression           = 4
-	NumScriptParserRULE_allotmentPortion     = 5
-	NumScriptParserRULE_destinationInOrder   = 6
-	NumScriptParserRULE_destinationAllotment = 7
-	NumScriptParserRULE_keptOrDestination    = 8
-	NumScriptParserRULE_destination          = 9
-	NumScriptParserRULE_sourceInOrder        = 10
-	NumScriptParserRULE_sourceMaxed          = 11
-	NumScriptParserRULE_source               = 12
-	NumScriptParserRULE_sourceAllotment      = 13
-	NumScriptParserRULE_valueAwareSource     = 14
-	NumScriptParserRULE_statement            = 15
-	NumScriptParserRULE_type_                = 16
-	NumScriptParserRULE_origin               = 17
-	NumScriptParserRULE_varDecl              = 18
-	NumScriptParserRULE_varListDecl          = 19
-	NumScriptParserRULE_script               = 20
+	NumScriptParserRULE_monetary               = 0
+	NumScriptParserRULE_monetaryAll            = 1
+	NumScriptParserRULE_literal                = 2
+	NumScriptParserRULE_variable               = 3
+	NumScriptParserRULE_balance                = 4
+	NumScriptParserRULE_expression             = 5
+	NumScriptParserRULE_allotmentPortion       = 6
+	NumScriptParserRULE_destinationInOrder     = 7
+	NumScriptParserRULE_destinationAllotment   = 8
+	NumScriptParserRULE_keptOrDestination      = 9
+	NumScriptParserRULE_destination            = 10
+	NumScriptParserRULE_sourceInOrder          = 11
+	NumScriptParserRULE_sourceMaxed            = 12
+	NumScriptParserRULE_sourceAccountOverdraft = 13
+	NumScriptParserRULE_sourceAccount          = 14
+	NumScriptParserRULE_source                 = 15
+	NumScriptParserRULE_sourceAllotment        = 16
+	NumScriptParserRULE_valueAwareSource       = 17
+	NumScriptParserRULE_statementBlock         = 18
+	NumScriptParserRULE_ifStatement            = 19
+	NumScriptParserRULE_statement              = 20
+	NumScriptParserRULE_type_                  = 21
+	NumScriptParserRULE_origin                 = 22
+	NumScriptParserRULE_varDecl                = 23
+	NumScriptParserRULE_varListDecl            = 24
+	NumScriptParserRULE_script                 = 25
 )
 
 // IMonetaryContext is an interface to support dynamic dispatch.
@@ -391,25 +478,25 @@ func (p *NumScriptParser) Monetary() (localctx IMonetaryContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(42)
+		p.SetState(52)
 		p.Match(NumScriptParserLBRACK)
 	}
 	{
-		p.SetState(43)
+		p.SetState(53)
 
 		var _m = p.Match(NumScriptParserASSET)
 
 		localctx.(*MonetaryContext).asset = _m
 	}
 	{
-		p.SetState(44)
+		p.SetState(54)
 
 		var _m = p.Match(NumScriptParserNUMBER)
 
 		localctx.(*MonetaryContext).amt = _m
 	}
 	{
-		p.SetState(45)
+		p.SetState(55)
 		p.Match(NumScriptParserRBRACK)
 	}
 
@@ -469,6 +556,10 @@ func (s *MonetaryAllContext) LBRACK() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserLBRACK, 0)
 }
 
+func (s *MonetaryAllContext) OP_MUL() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_MUL, 0)
+}
+
 func (s *MonetaryAllContext) RBRACK() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserRBRACK, 0)
 }
@@ -522,22 +613,22 @@ func (p *NumScriptParser) MonetaryAll() (localctx IMonetaryAllContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(47)
+		p.SetState(57)
 		p.Match(NumScriptParserLBRACK)
 	}
 	{
-		p.SetState(48)
+		p.SetState(58)
 
 		var _m = p.Match(NumScriptParserASSET)
 
 		localctx.(*MonetaryAllContext).asset = _m
 	}
 	{
-		p.SetState(49)
-		p.Match(NumScriptParserT__0)
+		p.SetState(59)
+		p.Match(NumScriptParserOP_MUL)
 	}
 	{
-		p.SetState(50)
+		p.SetState(60)
 		p.Match(NumScriptParserRBRACK)
 	}
 
@@ -594,6 +685,40 @@ func (s *LiteralContext) ToStringTree(ruleNames []string, recog antlr.Recognizer
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
+type LitPortionContext struct {
+	*LiteralContext
+}
+
+func NewLitPortionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LitPortionContext {
+	var p = new(LitPortionContext)
+
+	p.LiteralContext = NewEmptyLiteralContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*LiteralContext))
+
+	return p
+}
+
+func (s *LitPortionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LitPortionContext) PORTION() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserPORTION, 0)
+}
+
+func (s *LitPortionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterLitPortion(s)
+	}
+}
+
+func (s *LitPortionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitLitPortion(s)
+	}
+}
+
 type LitStringContext struct {
 	*LiteralContext
 }
@@ -662,6 +787,44 @@ func (s *LitAccountContext) ExitRule(listener antlr.ParseTreeListener) {
 	}
 }
 
+type LitBoolContext struct {
+	*LiteralContext
+}
+
+func NewLitBoolContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LitBoolContext {
+	var p = new(LitBoolContext)
+
+	p.LiteralContext = NewEmptyLiteralContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*LiteralContext))
+
+	return p
+}
+
+func (s *LitBoolContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LitBoolContext) TRUE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserTRUE, 0)
+}
+
+func (s *LitBoolContext) FALSE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserFALSE, 0)
+}
+
+func (s *LitBoolContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterLitBool(s)
+	}
+}
+
+func (s *LitBoolContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitLitBool(s)
+	}
+}
+
 type LitAssetContext struct {
 	*LiteralContext
 }
@@ -782,6 +945,7 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 
 	localctx = NewLiteralContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 4, NumScriptParserRULE_literal)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -799,7 +963,7 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 		}
 	}()
 
-	p.SetState(57)
+	p.SetState(69)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
@@ -807,7 +971,7 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 		localctx = NewLitAccountContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(52)
+			p.SetState(62)
 			p.Match(NumScriptParserACCOUNT)
 		}
 
@@ -815,7 +979,7 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 		localctx = NewLitAssetContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(53)
+			p.SetState(63)
 			p.Match(NumScriptParserASSET)
 		}
 
@@ -823,7 +987,7 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 		localctx = NewLitNumberContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(54)
+			p.SetState(64)
 			p.Match(NumScriptParserNUMBER)
 		}
 
@@ -831,15 +995,38 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 		localctx = NewLitStringContext(p, localctx)
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(55)
+			p.SetState(65)
 			p.Match(NumScriptParserSTRING)
 		}
 
+	case NumScriptParserTRUE, NumScriptParserFALSE:
+		localctx = NewLitBoolContext(p, localctx)
+		p.EnterOuterAlt(localctx, 5)
+		{
+			p.SetState(66)
+			_la = p.GetTokenStream().LA(1)
+
+			if !(_la == NumScriptParserTRUE || _la == NumScriptParserFALSE) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+
+	case NumScriptParserPORTION:
+		localctx = NewLitPortionContext(p, localctx)
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(67)
+			p.Match(NumScriptParserPORTION)
+		}
+
 	case NumScriptParserLBRACK:
 		localctx = NewLitMonetaryContext(p, localctx)
-		p.EnterOuterAlt(localctx, 5)
+		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(56)
+			p.SetState(68)
 			p.Monetary()
 		}
 
@@ -937,13 +1124,204 @@ func (p *NumScriptParser) Variable() (localctx IVariableContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(59)
+		p.SetState(71)
 		p.Match(NumScriptParserVARIABLE_NAME)
 	}
 
 	return localctx
 }
 
+// IBalanceContext is an interface to support dynamic dispatch.
+type IBalanceContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// GetAcc returns the acc rule contexts.
+	GetAcc() IExpressionContext
+
+	// GetAsset returns the asset rule contexts.
+	GetAsset() IExpressionContext
+
+	// SetAcc sets the acc rule contexts.
+	SetAcc(IExpressionContext)
+
+	// SetAsset sets the asset rule contexts.
+	SetAsset(IExpressionContext)
+
+	// IsBalanceContext differentiates from other interfaces.
+	IsBalanceContext()
+}
+
+type BalanceContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+	acc    IExpressionContext
+	asset  IExpressionContext
+}
+
+func NewEmptyBalanceContext() *BalanceContext {
+	var p = new(BalanceContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = NumScriptParserRULE_balance
+	return p
+}
+
+func (*BalanceContext) IsBalanceContext() {}
+
+func NewBalanceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *BalanceContext {
+	var p = new(BalanceContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = NumScriptParserRULE_balance
+
+	return p
+}
+
+func (s *BalanceContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *BalanceContext) GetAcc() IExpressionContext { return s.acc }
+
+func (s *BalanceContext) GetAsset() IExpressionContext { return s.asset }
+
+func (s *BalanceContext) SetAcc(v IExpressionContext) { s.acc = v }
+
+func (s *BalanceContext) SetAsset(v IExpressionContext) { s.asset = v }
+
+func (s *BalanceContext) BALANCE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserBALANCE, 0)
+}
+
+func (s *BalanceContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLPAREN, 0)
+}
+
+func (s *BalanceContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRPAREN, 0)
+}
+
+func (s *BalanceContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *BalanceContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *BalanceContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *BalanceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *BalanceContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterBalance(s)
+	}
+}
+
+func (s *BalanceContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitBalance(s)
+	}
+}
+
+func (p *NumScriptParser) Balance() (localctx IBalanceContext) {
+	this := p
+	_ = this
+
+	localctx = NewBalanceContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, NumScriptParserRULE_balance)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(73)
+		p.Match(NumScriptParserBALANCE)
+	}
+	{
+		p.SetState(74)
+		p.Match(NumScriptParserLPAREN)
+	}
+	{
+		p.SetState(75)
+
+		var _x = p.expression(0)
+
+		localctx.(*BalanceContext).acc = _x
+	}
+	{
+		p.SetState(76)
+		p.Match(NumScriptParserT__0)
+	}
+	{
+		p.SetState(77)
+
+		var _x = p.expression(0)
+
+		localctx.(*BalanceContext).asset = _x
+	}
+	{
+		p.SetState(78)
+		p.Match(NumScriptParserRPAREN)
+	}
+
+	return localctx
+}
+
 // IExpressionContext is an interface to support dynamic dispatch.
 type IExpressionContext interface {
 	antlr.ParserRuleContext
@@ -1088,13 +1466,15 @@ func (s *ExprAddSubContext) ExitRule(listener antlr.ParseTreeListener) {
 	}
 }
 
-type ExprLiteralContext struct {
+type ExprAndContext struct {
 	*ExpressionContext
-	lit ILiteralContext
+	lhs IExpressionContext
+	op  antlr.Token
+	rhs IExpressionContext
 }
 
-func NewExprLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprLiteralContext {
-	var p = new(ExprLiteralContext)
+func NewExprAndContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprAndContext {
+	var p = new(ExprAndContext)
 
 	p.ExpressionContext = NewEmptyExpressionContext()
 	p.parser = parser
@@ -1103,49 +1483,88 @@ func NewExprLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *Ex
 	return p
 }
 
-func (s *ExprLiteralContext) GetLit() ILiteralContext { return s.lit }
+func (s *ExprAndContext) GetOp() antlr.Token { return s.op }
 
-func (s *ExprLiteralContext) SetLit(v ILiteralContext) { s.lit = v }
+func (s *ExprAndContext) SetOp(v antlr.Token) { s.op = v }
 
-func (s *ExprLiteralContext) GetRuleContext() antlr.RuleContext {
+func (s *ExprAndContext) GetLhs() IExpressionContext { return s.lhs }
+
+func (s *ExprAndContext) GetRhs() IExpressionContext { return s.rhs }
+
+func (s *ExprAndContext) SetLhs(v IExpressionContext) { s.lhs = v }
+
+func (s *ExprAndContext) SetRhs(v IExpressionContext) { s.rhs = v }
+
+func (s *ExprAndContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ExprLiteralContext) Literal() ILiteralContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ILiteralContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+func (s *ExprAndContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
 		}
 	}
 
-	if t == nil {
-		return nil
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
 	}
 
-	return t.(ILiteralContext)
+	return tst
 }
 
-func (s *ExprLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ExprAndContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *ExprAndContext) OP_AND() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_AND, 0)
+}
+
+func (s *ExprAndContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterExprLiteral(s)
+		listenerT.EnterExprAnd(s)
 	}
 }
 
-func (s *ExprLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ExprAndContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitExprLiteral(s)
+		listenerT.ExitExprAnd(s)
 	}
 }
 
-type ExprVariableContext struct {
+type ExprComparisonContext struct {
 	*ExpressionContext
-	var_ IVariableContext
+	lhs IExpressionContext
+	op  antlr.Token
+	rhs IExpressionContext
 }
 
-func NewExprVariableContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprVariableContext {
-	var p = new(ExprVariableContext)
+func NewExprComparisonContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprComparisonContext {
+	var p = new(ExprComparisonContext)
 
 	p.ExpressionContext = NewEmptyExpressionContext()
 	p.parser = parser
@@ -1154,20 +1573,53 @@ func NewExprVariableContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *E
 	return p
 }
 
-func (s *ExprVariableContext) GetVar_() IVariableContext { return s.var_ }
+func (s *ExprComparisonContext) GetOp() antlr.Token { return s.op }
 
-func (s *ExprVariableContext) SetVar_(v IVariableContext) { s.var_ = v }
+func (s *ExprComparisonContext) SetOp(v antlr.Token) { s.op = v }
 
-func (s *ExprVariableContext) GetRuleContext() antlr.RuleContext {
+func (s *ExprComparisonContext) GetLhs() IExpressionContext { return s.lhs }
+
+func (s *ExprComparisonContext) GetRhs() IExpressionContext { return s.rhs }
+
+func (s *ExprComparisonContext) SetLhs(v IExpressionContext) { s.lhs = v }
+
+func (s *ExprComparisonContext) SetRhs(v IExpressionContext) { s.rhs = v }
+
+func (s *ExprComparisonContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ExprVariableContext) Variable() IVariableContext {
+func (s *ExprComparisonContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ExprComparisonContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IVariableContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -1175,289 +1627,2007 @@ func (s *ExprVariableContext) Variable() IVariableContext {
 		return nil
 	}
 
-	return t.(IVariableContext)
+	return t.(IExpressionContext)
 }
 
-func (s *ExprVariableContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ExprComparisonContext) OP_EQ() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_EQ, 0)
+}
+
+func (s *ExprComparisonContext) OP_NEQ() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_NEQ, 0)
+}
+
+func (s *ExprComparisonContext) OP_LT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_LT, 0)
+}
+
+func (s *ExprComparisonContext) OP_LTE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_LTE, 0)
+}
+
+func (s *ExprComparisonContext) OP_GT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_GT, 0)
+}
+
+func (s *ExprComparisonContext) OP_GTE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_GTE, 0)
+}
+
+func (s *ExprComparisonContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterExprVariable(s)
+		listenerT.EnterExprComparison(s)
 	}
 }
 
-func (s *ExprVariableContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ExprComparisonContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitExprVariable(s)
+		listenerT.ExitExprComparison(s)
 	}
 }
 
-func (p *NumScriptParser) Expression() (localctx IExpressionContext) {
-	return p.expression(0)
+type ExprOrContext struct {
+	*ExpressionContext
+	lhs IExpressionContext
+	op  antlr.Token
+	rhs IExpressionContext
 }
 
-func (p *NumScriptParser) expression(_p int) (localctx IExpressionContext) {
-	this := p
-	_ = this
+func NewExprOrContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprOrContext {
+	var p = new(ExprOrContext)
 
-	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
-	_parentState := p.GetState()
-	localctx = NewExpressionContext(p, p.GetParserRuleContext(), _parentState)
-	var _prevctx IExpressionContext = localctx
-	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 8
-	p.EnterRecursionRule(localctx, 8, NumScriptParserRULE_expression, _p)
-	var _la int
+	p.ExpressionContext = NewEmptyExpressionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExpressionContext))
 
-	defer func() {
-		p.UnrollRecursionContexts(_parentctx)
-	}()
+	return p
+}
 
-	defer func() {
-		if err := recover(); err != nil {
-			if v, ok := err.(antlr.RecognitionException); ok {
-				localctx.SetException(v)
-				p.GetErrorHandler().ReportError(p, v)
-				p.GetErrorHandler().Recover(p, v)
-			} else {
-				panic(err)
+func (s *ExprOrContext) GetOp() antlr.Token { return s.op }
+
+func (s *ExprOrContext) SetOp(v antlr.Token) { s.op = v }
+
+func (s *ExprOrContext) GetLhs() IExpressionContext { return s.lhs }
+
+func (s *ExprOrContext) GetRhs() IExpressionContext { return s.rhs }
+
+func (s *ExprOrContext) SetLhs(v IExpressionContext) { s.lhs = v }
+
+func (s *ExprOrContext) SetRhs(v IExpressionContext) { s.rhs = v }
+
+func (s *ExprOrContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExprOrContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ExprOrContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
 			}
+			j++
 		}
-	}()
+	}
 
-	var _alt int
+	if t == nil {
+		return nil
+	}
 
-	p.EnterOuterAlt(localctx, 1)
-	p.SetState(64)
-	p.GetErrorHandler().Sync(p)
+	return t.(IExpressionContext)
+}
 
-	switch p.GetTokenStream().LA(1) {
-	case NumScriptParserLBRACK, NumScriptParserSTRING, NumScriptParserNUMBER, NumScriptParserACCOUNT, NumScriptParserASSET:
-		localctx = NewExprLiteralContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
+func (s *ExprOrContext) OP_OR() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_OR, 0)
+}
 
-		{
-			p.SetState(62)
+func (s *ExprOrContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterExprOr(s)
+	}
+}
 
-			var _x = p.Literal()
+func (s *ExprOrContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitExprOr(s)
+	}
+}
 
-			localctx.(*ExprLiteralContext).lit = _x
-		}
+type ExprParensContext struct {
+	*ExpressionContext
+	expr IExpressionContext
+}
 
-	case NumScriptParserVARIABLE_NAME:
-		localctx = NewExprVariableContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
-		{
-			p.SetState(63)
+func NewExprParensContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprParensContext {
+	var p = new(ExprParensContext)
 
-			var _x = p.Variable()
+	p.ExpressionContext = NewEmptyExpressionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExpressionContext))
 
-			localctx.(*ExprVariableContext).var_ = _x
+	return p
+}
+
+func (s *ExprParensContext) GetExpr() IExpressionContext { return s.expr }
+
+func (s *ExprParensContext) SetExpr(v IExpressionContext) { s.expr = v }
+
+func (s *ExprParensContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExprParensContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLPAREN, 0)
+}
+
+func (s *ExprParensContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRPAREN, 0)
+}
+
+func (s *ExprParensContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
 		}
+	}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	if t == nil {
+		return nil
 	}
-	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(71)
-	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext())
 
-	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
-		if _alt == 1 {
-			if p.GetParseListeners() != nil {
-				p.TriggerExitRuleEvent()
-			}
-			_prevctx = localctx
-			localctx = NewExprAddSubContext(p, NewExpressionContext(p, _parentctx, _parentState))
-			localctx.(*ExprAddSubContext).lhs = _prevctx
+	return t.(IExpressionContext)
+}
 
-			p.PushNewRecursionContext(localctx, _startState, NumScriptParserRULE_expression)
-			p.SetState(66)
+func (s *ExprParensContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterExprParens(s)
+	}
+}
 
-			if !(p.Precpred(p.GetParserRuleContext(), 3)) {
-				panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
-			}
-			{
-				p.SetState(67)
+func (s *ExprParensContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitExprParens(s)
+	}
+}
+
+type ExprLiteralContext struct {
+	*ExpressionContext
+	lit ILiteralContext
+}
 
-				var _lt = p.GetTokenStream().LT(1)
+func NewExprLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprLiteralContext {
+	var p = new(ExprLiteralContext)
+
+	p.ExpressionContext = NewEmptyExpressionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExpressionContext))
 
-				localctx.(*ExprAddSubContext).op = _lt
+	return p
+}
 
-				_la = p.GetTokenStream().LA(1)
+func (s *ExprLiteralContext) GetLit() ILiteralContext { return s.lit }
 
-				if !(_la == NumScriptParserOP_ADD || _la == NumScriptParserOP_SUB) {
-					var _ri = p.GetErrorHandler().RecoverInline(p)
+func (s *ExprLiteralContext) SetLit(v ILiteralContext) { s.lit = v }
 
-					localctx.(*ExprAddSubContext).op = _ri
-				} else {
-					p.GetErrorHandler().ReportMatch(p)
-					p.Consume()
-				}
+func (s *ExprLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExprLiteralContext) Literal() ILiteralContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ILiteralContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralContext)
+}
+
+func (s *ExprLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterExprLiteral(s)
+	}
+}
+
+func (s *ExprLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitExprLiteral(s)
+	}
+}
+
+type ExprNotContext struct {
+	*ExpressionContext
+	expr IExpressionContext
+}
+
+func NewExprNotContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprNotContext {
+	var p = new(ExprNotContext)
+
+	p.ExpressionContext = NewEmptyExpressionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *ExprNotContext) GetExpr() IExpressionContext { return s.expr }
+
+func (s *ExprNotContext) SetExpr(v IExpressionContext) { s.expr = v }
+
+func (s *ExprNotContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExprNotContext) OP_NOT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_NOT, 0)
+}
+
+func (s *ExprNotContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *ExprNotContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterExprNot(s)
+	}
+}
+
+func (s *ExprNotContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitExprNot(s)
+	}
+}
+
+type ExprMulContext struct {
+	*ExpressionContext
+	lhs      IExpressionContext
+	op       antlr.Token
+	rhs      IExpressionContext
+	rounding antlr.Token
+}
+
+func NewExprMulContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprMulContext {
+	var p = new(ExprMulContext)
+
+	p.ExpressionContext = NewEmptyExpressionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *ExprMulContext) GetOp() antlr.Token { return s.op }
+
+func (s *ExprMulContext) GetRounding() antlr.Token { return s.rounding }
+
+func (s *ExprMulContext) SetOp(v antlr.Token) { s.op = v }
+
+func (s *ExprMulContext) SetRounding(v antlr.Token) { s.rounding = v }
+
+func (s *ExprMulContext) GetLhs() IExpressionContext { return s.lhs }
+
+func (s *ExprMulContext) GetRhs() IExpressionContext { return s.rhs }
+
+func (s *ExprMulContext) SetLhs(v IExpressionContext) { s.lhs = v }
+
+func (s *ExprMulContext) SetRhs(v IExpressionContext) { s.rhs = v }
+
+func (s *ExprMulContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExprMulContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ExprMulContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *ExprMulContext) OP_MUL() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_MUL, 0)
+}
+
+func (s *ExprMulContext) ROUNDED() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserROUNDED, 0)
+}
+
+func (s *ExprMulContext) UP() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserUP, 0)
+}
+
+func (s *ExprMulContext) DOWN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserDOWN, 0)
+}
+
+func (s *ExprMulContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterExprMul(s)
+	}
+}
+
+func (s *ExprMulContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitExprMul(s)
+	}
+}
+
+type ExprVariableContext struct {
+	*ExpressionContext
+	var_ IVariableContext
+}
+
+func NewExprVariableContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprVariableContext {
+	var p = new(ExprVariableContext)
+
+	p.ExpressionContext = NewEmptyExpressionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *ExprVariableContext) GetVar_() IVariableContext { return s.var_ }
+
+func (s *ExprVariableContext) SetVar_(v IVariableContext) { s.var_ = v }
+
+func (s *ExprVariableContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExprVariableContext) Variable() IVariableContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IVariableContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IVariableContext)
+}
+
+func (s *ExprVariableContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterExprVariable(s)
+	}
+}
+
+func (s *ExprVariableContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitExprVariable(s)
+	}
+}
+
+type ExprBalanceContext struct {
+	*ExpressionContext
+	bal IBalanceContext
+}
+
+func NewExprBalanceContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprBalanceContext {
+	var p = new(ExprBalanceContext)
+
+	p.ExpressionContext = NewEmptyExpressionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *ExprBalanceContext) GetBal() IBalanceContext { return s.bal }
+
+func (s *ExprBalanceContext) SetBal(v IBalanceContext) { s.bal = v }
+
+func (s *ExprBalanceContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExprBalanceContext) Balance() IBalanceContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IBalanceContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IBalanceContext)
+}
+
+func (s *ExprBalanceContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterExprBalance(s)
+	}
+}
+
+func (s *ExprBalanceContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitExprBalance(s)
+	}
+}
+
+func (p *NumScriptParser) Expression() (localctx IExpressionContext) {
+	return p.expression(0)
+}
+
+func (p *NumScriptParser) expression(_p int) (localctx IExpressionContext) {
+	this := p
+	_ = this
+
+	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
+	_parentState := p.GetState()
+	localctx = NewExpressionContext(p, p.GetParserRuleContext(), _parentState)
+	var _prevctx IExpressionContext = localctx
+	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
+	_startState := 10
+	p.EnterRecursionRule(localctx, 10, NumScriptParserRULE_expression, _p)
+	var _la int
+
+	defer func() {
+		p.UnrollRecursionContexts(_parentctx)
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(90)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case NumScriptParserOP_NOT:
+		localctx = NewExprNotContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+
+		{
+			p.SetState(81)
+			p.Match(NumScriptParserOP_NOT)
+		}
+		{
+			p.SetState(82)
+
+			var _x = p.expression(10)
+
+			localctx.(*ExprNotContext).expr = _x
+		}
+
+	case NumScriptParserLPAREN:
+		localctx = NewExprParensContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(83)
+			p.Match(NumScriptParserLPAREN)
+		}
+		{
+			p.SetState(84)
+
+			var _x = p.expression(0)
+
+			localctx.(*ExprParensContext).expr = _x
+		}
+		{
+			p.SetState(85)
+			p.Match(NumScriptParserRPAREN)
+		}
+
+	case NumScriptParserLBRACK, NumScriptParserTRUE, NumScriptParserFALSE, NumScriptParserSTRING, NumScriptParserPORTION, NumScriptParserNUMBER, NumScriptParserACCOUNT, NumScriptParserASSET:
+		localctx = NewExprLiteralContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(87)
+
+			var _x = p.Literal()
+
+			localctx.(*ExprLiteralContext).lit = _x
+		}
+
+	case NumScriptParserVARIABLE_NAME:
+		localctx = NewExprVariableContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(88)
+
+			var _x = p.Variable()
+
+			localctx.(*ExprVariableContext).var_ = _x
+		}
+
+	case NumScriptParserBALANCE:
+		localctx = NewExprBalanceContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(89)
+
+			var _x = p.Balance()
+
+			localctx.(*ExprBalanceContext).bal = _x
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
+	p.SetState(113)
+	p.GetErrorHandler().Sync(p)
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 4, p.GetParserRuleContext())
+
+	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		if _alt == 1 {
+			if p.GetParseListeners() != nil {
+				p.TriggerExitRuleEvent()
+			}
+			_prevctx = localctx
+			p.SetState(111)
+			p.GetErrorHandler().Sync(p)
+			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 3, p.GetParserRuleContext()) {
+			case 1:
+				localctx = NewExprMulContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*ExprMulContext).lhs = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, NumScriptParserRULE_expression)
+				p.SetState(92)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 9)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 9)", ""))
+				}
+				{
+					p.SetState(93)
+
+					var _m = p.Match(NumScriptParserOP_MUL)
+
+					localctx.(*ExprMulContext).op = _m
+				}
+				{
+					p.SetState(94)
+
+					var _x = p.expression(10)
+
+					localctx.(*ExprMulContext).rhs = _x
+				}
+				p.SetState(97)
+				p.GetErrorHandler().Sync(p)
+
+				if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext()) == 1 {
+					{
+						p.SetState(95)
+						p.Match(NumScriptParserROUNDED)
+					}
+					{
+						p.SetState(96)
+
+						var _lt = p.GetTokenStream().LT(1)
+
+						localctx.(*ExprMulContext).rounding = _lt
+
+						_la = p.GetTokenStream().LA(1)
+
+						if !(_la == NumScriptParserUP || _la == NumScriptParserDOWN) {
+							var _ri = p.GetErrorHandler().RecoverInline(p)
+
+							localctx.(*ExprMulContext).rounding = _ri
+						} else {
+							p.GetErrorHandler().ReportMatch(p)
+							p.Consume()
+						}
+					}
+
+				}
+
+			case 2:
+				localctx = NewExprAddSubContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*ExprAddSubContext).lhs = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, NumScriptParserRULE_expression)
+				p.SetState(99)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
+				}
+				{
+					p.SetState(100)
+
+					var _lt = p.GetTokenStream().LT(1)
+
+					localctx.(*ExprAddSubContext).op = _lt
+
+					_la = p.GetTokenStream().LA(1)
+
+					if !(_la == NumScriptParserOP_ADD || _la == NumScriptParserOP_SUB) {
+						var _ri = p.GetErrorHandler().RecoverInline(p)
+
+						localctx.(*ExprAddSubContext).op = _ri
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(101)
+
+					var _x = p.expression(9)
+
+					localctx.(*ExprAddSubContext).rhs = _x
+				}
+
+			case 3:
+				localctx = NewExprComparisonContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*ExprComparisonContext).lhs = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, NumScriptParserRULE_expression)
+				p.SetState(102)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
+				}
+				{
+					p.SetState(103)
+
+					var _lt = p.GetTokenStream().LT(1)
+
+					localctx.(*ExprComparisonContext).op = _lt
+
+					_la = p.GetTokenStream().LA(1)
+
+					if !(((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<NumScriptParserOP_EQ)|(1<<NumScriptParserOP_NEQ)|(1<<NumScriptParserOP_LT)|(1<<NumScriptParserOP_LTE)|(1<<NumScriptParserOP_GT)|(1<<NumScriptParserOP_GTE))) != 0) {
+						var _ri = p.GetErrorHandler().RecoverInline(p)
+
+						localctx.(*ExprComparisonContext).op = _ri
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(104)
+
+					var _x = p.expression(8)
+
+					localctx.(*ExprComparisonContext).rhs = _x
+				}
+
+			case 4:
+				localctx = NewExprAndContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*ExprAndContext).lhs = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, NumScriptParserRULE_expression)
+				p.SetState(105)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
+				}
+				{
+					p.SetState(106)
+
+					var _m = p.Match(NumScriptParserOP_AND)
+
+					localctx.(*ExprAndContext).op = _m
+				}
+				{
+					p.SetState(107)
+
+					var _x = p.expression(7)
+
+					localctx.(*ExprAndContext).rhs = _x
+				}
+
+			case 5:
+				localctx = NewExprOrContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*ExprOrContext).lhs = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, NumScriptParserRULE_expression)
+				p.SetState(108)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
+				}
+				{
+					p.SetState(109)
+
+					var _m = p.Match(NumScriptParserOP_OR)
+
+					localctx.(*ExprOrContext).op = _m
+				}
+				{
+					p.SetState(110)
+
+					var _x = p.expression(6)
+
+					localctx.(*ExprOrContext).rhs = _x
+				}
+
+			}
+
+		}
+		p.SetState(115)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 4, p.GetParserRuleContext())
+	}
+
+	return localctx
+}
+
+// IAllotmentPortionContext is an interface to support dynamic dispatch.
+type IAllotmentPortionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsAllotmentPortionContext differentiates from other interfaces.
+	IsAllotmentPortionContext()
+}
+
+type AllotmentPortionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyAllotmentPortionContext() *AllotmentPortionContext {
+	var p = new(AllotmentPortionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = NumScriptParserRULE_allotmentPortion
+	return p
+}
+
+func (*AllotmentPortionContext) IsAllotmentPortionContext() {}
+
+func NewAllotmentPortionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AllotmentPortionContext {
+	var p = new(AllotmentPortionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = NumScriptParserRULE_allotmentPortion
+
+	return p
+}
+
+func (s *AllotmentPortionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *AllotmentPortionContext) CopyFrom(ctx *AllotmentPortionContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+}
+
+func (s *AllotmentPortionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AllotmentPortionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type AllotmentPortionRemainingContext struct {
+	*AllotmentPortionContext
+}
+
+func NewAllotmentPortionRemainingContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AllotmentPortionRemainingContext {
+	var p = new(AllotmentPortionRemainingContext)
+
+	p.AllotmentPortionContext = NewEmptyAllotmentPortionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*AllotmentPortionContext))
+
+	return p
+}
+
+func (s *AllotmentPortionRemainingContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AllotmentPortionRemainingContext) REMAINING() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserREMAINING, 0)
+}
+
+func (s *AllotmentPortionRemainingContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterAllotmentPortionRemaining(s)
+	}
+}
+
+func (s *AllotmentPortionRemainingContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitAllotmentPortionRemaining(s)
+	}
+}
+
+type AllotmentPortionConstContext struct {
+	*AllotmentPortionContext
+}
+
+func NewAllotmentPortionConstContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AllotmentPortionConstContext {
+	var p = new(AllotmentPortionConstContext)
+
+	p.AllotmentPortionContext = NewEmptyAllotmentPortionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*AllotmentPortionContext))
+
+	return p
+}
+
+func (s *AllotmentPortionConstContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AllotmentPortionConstContext) PORTION() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserPORTION, 0)
+}
+
+func (s *AllotmentPortionConstContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterAllotmentPortionConst(s)
+	}
+}
+
+func (s *AllotmentPortionConstContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitAllotmentPortionConst(s)
+	}
+}
+
+type AllotmentPortionVarContext struct {
+	*AllotmentPortionContext
+	por IVariableContext
+}
+
+func NewAllotmentPortionVarContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AllotmentPortionVarContext {
+	var p = new(AllotmentPortionVarContext)
+
+	p.AllotmentPortionContext = NewEmptyAllotmentPortionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*AllotmentPortionContext))
+
+	return p
+}
+
+func (s *AllotmentPortionVarContext) GetPor() IVariableContext { return s.por }
+
+func (s *AllotmentPortionVarContext) SetPor(v IVariableContext) { s.por = v }
+
+func (s *AllotmentPortionVarContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AllotmentPortionVarContext) Variable() IVariableContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IVariableContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IVariableContext)
+}
+
+func (s *AllotmentPortionVarContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterAllotmentPortionVar(s)
+	}
+}
+
+func (s *AllotmentPortionVarContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitAllotmentPortionVar(s)
+	}
+}
+
+func (p *NumScriptParser) AllotmentPortion() (localctx IAllotmentPortionContext) {
+	this := p
+	_ = this
+
+	localctx = NewAllotmentPortionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, NumScriptParserRULE_allotmentPortion)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(119)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case NumScriptParserPORTION:
+		localctx = NewAllotmentPortionConstContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(116)
+			p.Match(NumScriptParserPORTION)
+		}
+
+	case NumScriptParserVARIABLE_NAME:
+		localctx = NewAllotmentPortionVarContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(117)
+
+			var _x = p.Variable()
+
+			localctx.(*AllotmentPortionVarContext).por = _x
+		}
+
+	case NumScriptParserREMAINING:
+		localctx = NewAllotmentPortionRemainingContext(p, localctx)
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(118)
+			p.Match(NumScriptParserREMAINING)
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+// IDestinationInOrderContext is an interface to support dynamic dispatch.
+type IDestinationInOrderContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Get_expression returns the _expression rule contexts.
+	Get_expression() IExpressionContext
+
+	// Get_keptOrDestination returns the _keptOrDestination rule contexts.
+	Get_keptOrDestination() IKeptOrDestinationContext
+
+	// GetRemainingDest returns the remainingDest rule contexts.
+	GetRemainingDest() IKeptOrDestinationContext
+
+	// Set_expression sets the _expression rule contexts.
+	Set_expression(IExpressionContext)
+
+	// Set_keptOrDestination sets the _keptOrDestination rule contexts.
+	Set_keptOrDestination(IKeptOrDestinationContext)
+
+	// SetRemainingDest sets the remainingDest rule contexts.
+	SetRemainingDest(IKeptOrDestinationContext)
+
+	// GetAmounts returns the amounts rule context list.
+	GetAmounts() []IExpressionContext
+
+	// GetDests returns the dests rule context list.
+	GetDests() []IKeptOrDestinationContext
+
+	// SetAmounts sets the amounts rule context list.
+	SetAmounts([]IExpressionContext)
+
+	// SetDests sets the dests rule context list.
+	SetDests([]IKeptOrDestinationContext)
+
+	// IsDestinationInOrderContext differentiates from other interfaces.
+	IsDestinationInOrderContext()
+}
+
+type DestinationInOrderContext struct {
+	*antlr.BaseParserRuleContext
+	parser             antlr.Parser
+	_expression        IExpressionContext
+	amounts            []IExpressionContext
+	_keptOrDestination IKeptOrDestinationContext
+	dests              []IKeptOrDestinationContext
+	remainingDest      IKeptOrDestinationContext
+}
+
+func NewEmptyDestinationInOrderContext() *DestinationInOrderContext {
+	var p = new(DestinationInOrderContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = NumScriptParserRULE_destinationInOrder
+	return p
+}
+
+func (*DestinationInOrderContext) IsDestinationInOrderContext() {}
+
+func NewDestinationInOrderContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DestinationInOrderContext {
+	var p = new(DestinationInOrderContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = NumScriptParserRULE_destinationInOrder
+
+	return p
+}
+
+func (s *DestinationInOrderContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *DestinationInOrderContext) Get_expression() IExpressionContext { return s._expression }
+
+func (s *DestinationInOrderContext) Get_keptOrDestination() IKeptOrDestinationContext {
+	return s._keptOrDestination
+}
+
+func (s *DestinationInOrderContext) GetRemainingDest() IKeptOrDestinationContext {
+	return s.remainingDest
+}
+
+func (s *DestinationInOrderContext) Set_expression(v IExpressionContext) { s._expression = v }
+
+func (s *DestinationInOrderContext) Set_keptOrDestination(v IKeptOrDestinationContext) {
+	s._keptOrDestination = v
+}
+
+func (s *DestinationInOrderContext) SetRemainingDest(v IKeptOrDestinationContext) {
+	s.remainingDest = v
+}
+
+func (s *DestinationInOrderContext) GetAmounts() []IExpressionContext { return s.amounts }
+
+func (s *DestinationInOrderContext) GetDests() []IKeptOrDestinationContext { return s.dests }
+
+func (s *DestinationInOrderContext) SetAmounts(v []IExpressionContext) { s.amounts = v }
+
+func (s *DestinationInOrderContext) SetDests(v []IKeptOrDestinationContext) { s.dests = v }
+
+func (s *DestinationInOrderContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLBRACE, 0)
+}
+
+func (s *DestinationInOrderContext) AllNEWLINE() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserNEWLINE)
+}
+
+func (s *DestinationInOrderContext) NEWLINE(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserNEWLINE, i)
+}
+
+func (s *DestinationInOrderContext) REMAINING() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserREMAINING, 0)
+}
+
+func (s *DestinationInOrderContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRBRACE, 0)
+}
+
+func (s *DestinationInOrderContext) AllKeptOrDestination() []IKeptOrDestinationContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IKeptOrDestinationContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IKeptOrDestinationContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IKeptOrDestinationContext); ok {
+			tst[i] = t.(IKeptOrDestinationContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *DestinationInOrderContext) KeptOrDestination(i int) IKeptOrDestinationContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IKeptOrDestinationContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IKeptOrDestinationContext)
+}
+
+func (s *DestinationInOrderContext) AllMAX() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserMAX)
+}
+
+func (s *DestinationInOrderContext) MAX(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserMAX, i)
+}
+
+func (s *DestinationInOrderContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *DestinationInOrderContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *DestinationInOrderContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DestinationInOrderContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *DestinationInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterDestinationInOrder(s)
+	}
+}
+
+func (s *DestinationInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitDestinationInOrder(s)
+	}
+}
+
+func (p *NumScriptParser) DestinationInOrder() (localctx IDestinationInOrderContext) {
+	this := p
+	_ = this
+
+	localctx = NewDestinationInOrderContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 14, NumScriptParserRULE_destinationInOrder)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(121)
+		p.Match(NumScriptParserLBRACE)
+	}
+	{
+		p.SetState(122)
+		p.Match(NumScriptParserNEWLINE)
+	}
+	p.SetState(128)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for ok := true; ok; ok = _la == NumScriptParserMAX {
+		{
+			p.SetState(123)
+			p.Match(NumScriptParserMAX)
+		}
+		{
+			p.SetState(124)
+
+			var _x = p.expression(0)
+
+			localctx.(*DestinationInOrderContext)._expression = _x
+		}
+		localctx.(*DestinationInOrderContext).amounts = append(localctx.(*DestinationInOrderContext).amounts, localctx.(*DestinationInOrderContext)._expression)
+		{
+			p.SetState(125)
+
+			var _x = p.KeptOrDestination()
+
+			localctx.(*DestinationInOrderContext)._keptOrDestination = _x
+		}
+		localctx.(*DestinationInOrderContext).dests = append(localctx.(*DestinationInOrderContext).dests, localctx.(*DestinationInOrderContext)._keptOrDestination)
+		{
+			p.SetState(126)
+			p.Match(NumScriptParserNEWLINE)
+		}
+
+		p.SetState(130)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(132)
+		p.Match(NumScriptParserREMAINING)
+	}
+	{
+		p.SetState(133)
+
+		var _x = p.KeptOrDestination()
+
+		localctx.(*DestinationInOrderContext).remainingDest = _x
+	}
+	{
+		p.SetState(134)
+		p.Match(NumScriptParserNEWLINE)
+	}
+	{
+		p.SetState(135)
+		p.Match(NumScriptParserRBRACE)
+	}
+
+	return localctx
+}
+
+// IDestinationAllotmentContext is an interface to support dynamic dispatch.
+type IDestinationAllotmentContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Get_allotmentPortion returns the _allotmentPortion rule contexts.
+	Get_allotmentPortion() IAllotmentPortionContext
+
+	// Get_keptOrDestination returns the _keptOrDestination rule contexts.
+	Get_keptOrDestination() IKeptOrDestinationContext
+
+	// Set_allotmentPortion sets the _allotmentPortion rule contexts.
+	Set_allotmentPortion(IAllotmentPortionContext)
+
+	// Set_keptOrDestination sets the _keptOrDestination rule contexts.
+	Set_keptOrDestination(IKeptOrDestinationContext)
+
+	// GetPortions returns the portions rule context list.
+	GetPortions() []IAllotmentPortionContext
+
+	// GetDests returns the dests rule context list.
+	GetDests() []IKeptOrDestinationContext
+
+	// SetPortions sets the portions rule context list.
+	SetPortions([]IAllotmentPortionContext)
+
+	// SetDests sets the dests rule context list.
+	SetDests([]IKeptOrDestinationContext)
+
+	// IsDestinationAllotmentContext differentiates from other interfaces.
+	IsDestinationAllotmentContext()
+}
+
+type DestinationAllotmentContext struct {
+	*antlr.BaseParserRuleContext
+	parser             antlr.Parser
+	_allotmentPortion  IAllotmentPortionContext
+	portions           []IAllotmentPortionContext
+	_keptOrDestination IKeptOrDestinationContext
+	dests              []IKeptOrDestinationContext
+}
+
+func NewEmptyDestinationAllotmentContext() *DestinationAllotmentContext {
+	var p = new(DestinationAllotmentContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = NumScriptParserRULE_destinationAllotment
+	return p
+}
+
+func (*DestinationAllotmentContext) IsDestinationAllotmentContext() {}
+
+func NewDestinationAllotmentContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DestinationAllotmentContext {
+	var p = new(DestinationAllotmentContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = NumScriptParserRULE_destinationAllotment
+
+	return p
+}
+
+func (s *DestinationAllotmentContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *DestinationAllotmentContext) Get_allotmentPortion() IAllotmentPortionContext {
+	return s._allotmentPortion
+}
+
+func (s *DestinationAllotmentContext) Get_keptOrDestination() IKeptOrDestinationContext {
+	return s._keptOrDestination
+}
+
+func (s *DestinationAllotmentContext) Set_allotmentPortion(v IAllotmentPortionContext) {
+	s._allotmentPortion = v
+}
+
+func (s *DestinationAllotmentContext) Set_keptOrDestination(v IKeptOrDestinationContext) {
+	s._keptOrDestination = v
+}
+
+func (s *DestinationAllotmentContext) GetPortions() []IAllotmentPortionContext { return s.portions }
+
+func (s *DestinationAllotmentContext) GetDests() []IKeptOrDestinationContext { return s.dests }
+
+func (s *DestinationAllotmentContext) SetPortions(v []IAllotmentPortionContext) { s.portions = v }
+
+func (s *DestinationAllotmentContext) SetDests(v []IKeptOrDestinationContext) { s.dests = v }
+
+func (s *DestinationAllotmentContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLBRACE, 0)
+}
+
+func (s *DestinationAllotmentContext) AllNEWLINE() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserNEWLINE)
+}
+
+func (s *DestinationAllotmentContext) NEWLINE(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserNEWLINE, i)
+}
+
+func (s *DestinationAllotmentContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRBRACE, 0)
+}
+
+func (s *DestinationAllotmentContext) AllAllotmentPortion() []IAllotmentPortionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IAllotmentPortionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IAllotmentPortionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IAllotmentPortionContext); ok {
+			tst[i] = t.(IAllotmentPortionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *DestinationAllotmentContext) AllotmentPortion(i int) IAllotmentPortionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAllotmentPortionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAllotmentPortionContext)
+}
+
+func (s *DestinationAllotmentContext) AllKeptOrDestination() []IKeptOrDestinationContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IKeptOrDestinationContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IKeptOrDestinationContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IKeptOrDestinationContext); ok {
+			tst[i] = t.(IKeptOrDestinationContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *DestinationAllotmentContext) KeptOrDestination(i int) IKeptOrDestinationContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IKeptOrDestinationContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IKeptOrDestinationContext)
+}
+
+func (s *DestinationAllotmentContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DestinationAllotmentContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *DestinationAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterDestinationAllotment(s)
+	}
+}
+
+func (s *DestinationAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitDestinationAllotment(s)
+	}
+}
+
+func (p *NumScriptParser) DestinationAllotment() (localctx IDestinationAllotmentContext) {
+	this := p
+	_ = this
+
+	localctx = NewDestinationAllotmentContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 16, NumScriptParserRULE_destinationAllotment)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(137)
+		p.Match(NumScriptParserLBRACE)
+	}
+	{
+		p.SetState(138)
+		p.Match(NumScriptParserNEWLINE)
+	}
+	p.SetState(143)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for ok := true; ok; ok = (((_la-52)&-(0x1f+1)) == 0 && ((1<<uint((_la-52)))&((1<<(NumScriptParserPORTION-52))|(1<<(NumScriptParserREMAINING-52))|(1<<(NumScriptParserVARIABLE_NAME-52)))) != 0) {
+		{
+			p.SetState(139)
+
+			var _x = p.AllotmentPortion()
+
+			localctx.(*DestinationAllotmentContext)._allotmentPortion = _x
+		}
+		localctx.(*DestinationAllotmentContext).portions = append(localctx.(*DestinationAllotmentContext).portions, localctx.(*DestinationAllotmentContext)._allotmentPortion)
+		{
+			p.SetState(140)
+
+			var _x = p.KeptOrDestination()
+
+			localctx.(*DestinationAllotmentContext)._keptOrDestination = _x
+		}
+		localctx.(*DestinationAllotmentContext).dests = append(localctx.(*DestinationAllotmentContext).dests, localctx.(*DestinationAllotmentContext)._keptOrDestination)
+		{
+			p.SetState(141)
+			p.Match(NumScriptParserNEWLINE)
+		}
+
+		p.SetState(145)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(147)
+		p.Match(NumScriptParserRBRACE)
+	}
+
+	return localctx
+}
+
+// IKeptOrDestinationContext is an interface to support dynamic dispatch.
+type IKeptOrDestinationContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsKeptOrDestinationContext differentiates from other interfaces.
+	IsKeptOrDestinationContext()
+}
+
+type KeptOrDestinationContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyKeptOrDestinationContext() *KeptOrDestinationContext {
+	var p = new(KeptOrDestinationContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = NumScriptParserRULE_keptOrDestination
+	return p
+}
+
+func (*KeptOrDestinationContext) IsKeptOrDestinationContext() {}
+
+func NewKeptOrDestinationContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *KeptOrDestinationContext {
+	var p = new(KeptOrDestinationContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = NumScriptParserRULE_keptOrDestination
+
+	return p
+}
+
+func (s *KeptOrDestinationContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *KeptOrDestinationContext) CopyFrom(ctx *KeptOrDestinationContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+}
+
+func (s *KeptOrDestinationContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *KeptOrDestinationContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type IsKeptContext struct {
+	*KeptOrDestinationContext
+}
+
+func NewIsKeptContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IsKeptContext {
+	var p = new(IsKeptContext)
+
+	p.KeptOrDestinationContext = NewEmptyKeptOrDestinationContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*KeptOrDestinationContext))
+
+	return p
+}
+
+func (s *IsKeptContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IsKeptContext) KEPT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserKEPT, 0)
+}
+
+func (s *IsKeptContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterIsKept(s)
+	}
+}
+
+func (s *IsKeptContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitIsKept(s)
+	}
+}
+
+type IsDestinationContext struct {
+	*KeptOrDestinationContext
+}
+
+func NewIsDestinationContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IsDestinationContext {
+	var p = new(IsDestinationContext)
+
+	p.KeptOrDestinationContext = NewEmptyKeptOrDestinationContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*KeptOrDestinationContext))
+
+	return p
+}
+
+func (s *IsDestinationContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IsDestinationContext) TO() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserTO, 0)
+}
+
+func (s *IsDestinationContext) Destination() IDestinationContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IDestinationContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDestinationContext)
+}
+
+func (s *IsDestinationContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterIsDestination(s)
+	}
+}
+
+func (s *IsDestinationContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitIsDestination(s)
+	}
+}
+
+func (p *NumScriptParser) KeptOrDestination() (localctx IKeptOrDestinationContext) {
+	this := p
+	_ = this
+
+	localctx = NewKeptOrDestinationContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 18, NumScriptParserRULE_keptOrDestination)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
 			}
-			{
-				p.SetState(68)
+		}
+	}()
 
-				var _x = p.expression(4)
+	p.SetState(152)
+	p.GetErrorHandler().Sync(p)
 
-				localctx.(*ExprAddSubContext).rhs = _x
-			}
+	switch p.GetTokenStream().LA(1) {
+	case NumScriptParserTO:
+		localctx = NewIsDestinationContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(149)
+			p.Match(NumScriptParserTO)
+		}
+		{
+			p.SetState(150)
+			p.Destination()
+		}
 
+	case NumScriptParserKEPT:
+		localctx = NewIsKeptContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(151)
+			p.Match(NumScriptParserKEPT)
 		}
-		p.SetState(73)
-		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext())
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
 }
 
-// IAllotmentPortionContext is an interface to support dynamic dispatch.
-type IAllotmentPortionContext interface {
+// IDestinationContext is an interface to support dynamic dispatch.
+type IDestinationContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsAllotmentPortionContext differentiates from other interfaces.
-	IsAllotmentPortionContext()
+	// IsDestinationContext differentiates from other interfaces.
+	IsDestinationContext()
 }
 
-type AllotmentPortionContext struct {
+type DestinationContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyAllotmentPortionContext() *AllotmentPortionContext {
-	var p = new(AllotmentPortionContext)
+func NewEmptyDestinationContext() *DestinationContext {
+	var p = new(DestinationContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_allotmentPortion
+	p.RuleIndex = NumScriptParserRULE_destination
 	return p
 }
 
-func (*AllotmentPortionContext) IsAllotmentPortionContext() {}
+func (*DestinationContext) IsDestinationContext() {}
 
-func NewAllotmentPortionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AllotmentPortionContext {
-	var p = new(AllotmentPortionContext)
+func NewDestinationContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DestinationContext {
+	var p = new(DestinationContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_allotmentPortion
+	p.RuleIndex = NumScriptParserRULE_destination
 
 	return p
 }
 
-func (s *AllotmentPortionContext) GetParser() antlr.Parser { return s.parser }
+func (s *DestinationContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *AllotmentPortionContext) CopyFrom(ctx *AllotmentPortionContext) {
+func (s *DestinationContext) CopyFrom(ctx *DestinationContext) {
 	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
 }
 
-func (s *AllotmentPortionContext) GetRuleContext() antlr.RuleContext {
+func (s *DestinationContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *AllotmentPortionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *DestinationContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-type AllotmentPortionRemainingContext struct {
-	*AllotmentPortionContext
+type DestAccountContext struct {
+	*DestinationContext
 }
 
-func NewAllotmentPortionRemainingContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AllotmentPortionRemainingContext {
-	var p = new(AllotmentPortionRemainingContext)
+func NewDestAccountContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *DestAccountContext {
+	var p = new(DestAccountContext)
 
-	p.AllotmentPortionContext = NewEmptyAllotmentPortionContext()
+	p.DestinationContext = NewEmptyDestinationContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*AllotmentPortionContext))
+	p.CopyFrom(ctx.(*DestinationContext))
 
 	return p
 }
 
-func (s *AllotmentPortionRemainingContext) GetRuleContext() antlr.RuleContext {
+func (s *DestAccountContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *AllotmentPortionRemainingContext) REMAINING() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserREMAINING, 0)
+func (s *DestAccountContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
 }
 
-func (s *AllotmentPortionRemainingContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *DestAccountContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterAllotmentPortionRemaining(s)
+		listenerT.EnterDestAccount(s)
 	}
 }
 
-func (s *AllotmentPortionRemainingContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *DestAccountContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitAllotmentPortionRemaining(s)
+		listenerT.ExitDestAccount(s)
 	}
 }
 
-type AllotmentPortionConstContext struct {
-	*AllotmentPortionContext
+type DestAllotmentContext struct {
+	*DestinationContext
 }
 
-func NewAllotmentPortionConstContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AllotmentPortionConstContext {
-	var p = new(AllotmentPortionConstContext)
+func NewDestAllotmentContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *DestAllotmentContext {
+	var p = new(DestAllotmentContext)
 
-	p.AllotmentPortionContext = NewEmptyAllotmentPortionContext()
+	p.DestinationContext = NewEmptyDestinationContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*AllotmentPortionContext))
+	p.CopyFrom(ctx.(*DestinationContext))
 
 	return p
 }
 
-func (s *AllotmentPortionConstContext) GetRuleContext() antlr.RuleContext {
+func (s *DestAllotmentContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *AllotmentPortionConstContext) PORTION() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserPORTION, 0)
+func (s *DestAllotmentContext) DestinationAllotment() IDestinationAllotmentContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IDestinationAllotmentContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDestinationAllotmentContext)
 }
 
-func (s *AllotmentPortionConstContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *DestAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterAllotmentPortionConst(s)
+		listenerT.EnterDestAllotment(s)
 	}
 }
 
-func (s *AllotmentPortionConstContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *DestAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitAllotmentPortionConst(s)
+		listenerT.ExitDestAllotment(s)
 	}
 }
 
-type AllotmentPortionVarContext struct {
-	*AllotmentPortionContext
-	por IVariableContext
+type DestInOrderContext struct {
+	*DestinationContext
 }
 
-func NewAllotmentPortionVarContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AllotmentPortionVarContext {
-	var p = new(AllotmentPortionVarContext)
+func NewDestInOrderContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *DestInOrderContext {
+	var p = new(DestInOrderContext)
 
-	p.AllotmentPortionContext = NewEmptyAllotmentPortionContext()
+	p.DestinationContext = NewEmptyDestinationContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*AllotmentPortionContext))
+	p.CopyFrom(ctx.(*DestinationContext))
 
 	return p
 }
 
-func (s *AllotmentPortionVarContext) GetPor() IVariableContext { return s.por }
-
-func (s *AllotmentPortionVarContext) SetPor(v IVariableContext) { s.por = v }
-
-func (s *AllotmentPortionVarContext) GetRuleContext() antlr.RuleContext {
+func (s *DestInOrderContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *AllotmentPortionVarContext) Variable() IVariableContext {
+func (s *DestInOrderContext) DestinationInOrder() IDestinationInOrderContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IVariableContext); ok {
+		if _, ok := ctx.(IDestinationInOrderContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -1467,27 +3637,27 @@ func (s *AllotmentPortionVarContext) Variable() IVariableContext {
 		return nil
 	}
 
-	return t.(IVariableContext)
+	return t.(IDestinationInOrderContext)
 }
 
-func (s *AllotmentPortionVarContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *DestInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterAllotmentPortionVar(s)
+		listenerT.EnterDestInOrder(s)
 	}
 }
 
-func (s *AllotmentPortionVarContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *DestInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitAllotmentPortionVar(s)
+		listenerT.ExitDestInOrder(s)
 	}
 }
 
-func (p *NumScriptParser) AllotmentPortion() (localctx IAllotmentPortionContext) {
+func (p *NumScriptParser) Destination() (localctx IDestinationContext) {
 	this := p
 	_ = this
 
-	localctx = NewAllotmentPortionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, NumScriptParserRULE_allotmentPortion)
+	localctx = NewDestinationContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 20, NumScriptParserRULE_destination)
 
 	defer func() {
 		p.ExitRule()
@@ -1505,228 +3675,128 @@ func (p *NumScriptParser) AllotmentPortion() (localctx IAllotmentPortionContext)
 		}
 	}()
 
-	p.SetState(77)
+	p.SetState(157)
 	p.GetErrorHandler().Sync(p)
-
-	switch p.GetTokenStream().LA(1) {
-	case NumScriptParserPORTION:
-		localctx = NewAllotmentPortionConstContext(p, localctx)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 9, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewDestAccountContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(74)
-			p.Match(NumScriptParserPORTION)
+			p.SetState(154)
+			p.expression(0)
 		}
 
-	case NumScriptParserVARIABLE_NAME:
-		localctx = NewAllotmentPortionVarContext(p, localctx)
+	case 2:
+		localctx = NewDestInOrderContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(75)
-
-			var _x = p.Variable()
-
-			localctx.(*AllotmentPortionVarContext).por = _x
+			p.SetState(155)
+			p.DestinationInOrder()
 		}
 
-	case NumScriptParserREMAINING:
-		localctx = NewAllotmentPortionRemainingContext(p, localctx)
+	case 3:
+		localctx = NewDestAllotmentContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(76)
-			p.Match(NumScriptParserREMAINING)
+			p.SetState(156)
+			p.DestinationAllotment()
 		}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
 }
 
-// IDestinationInOrderContext is an interface to support dynamic dispatch.
-type IDestinationInOrderContext interface {
+// ISourceInOrderContext is an interface to support dynamic dispatch.
+type ISourceInOrderContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// Get_expression returns the _expression rule contexts.
-	Get_expression() IExpressionContext
-
-	// Get_keptOrDestination returns the _keptOrDestination rule contexts.
-	Get_keptOrDestination() IKeptOrDestinationContext
-
-	// GetRemainingDest returns the remainingDest rule contexts.
-	GetRemainingDest() IKeptOrDestinationContext
-
-	// Set_expression sets the _expression rule contexts.
-	Set_expression(IExpressionContext)
-
-	// Set_keptOrDestination sets the _keptOrDestination rule contexts.
-	Set_keptOrDestination(IKeptOrDestinationContext)
-
-	// SetRemainingDest sets the remainingDest rule contexts.
-	SetRemainingDest(IKeptOrDestinationContext)
-
-	// GetAmounts returns the amounts rule context list.
-	GetAmounts() []IExpressionContext
+	// Get_source returns the _source rule contexts.
+	Get_source() ISourceContext
 
-	// GetDests returns the dests rule context list.
-	GetDests() []IKeptOrDestinationContext
+	// Set_source sets the _source rule contexts.
+	Set_source(ISourceContext)
 
-	// SetAmounts sets the amounts rule context list.
-	SetAmounts([]IExpressionContext)
+	// GetSources returns the sources rule context list.
+	GetSources() []ISourceContext
 
-	// SetDests sets the dests rule context list.
-	SetDests([]IKeptOrDestinationContext)
+	// SetSources sets the sources rule context list.
+	SetSources([]ISourceContext)
 
-	// IsDestinationInOrderContext differentiates from other interfaces.
-	IsDestinationInOrderContext()
+	// IsSourceInOrderContext differentiates from other interfaces.
+	IsSourceInOrderContext()
 }
 
-type DestinationInOrderContext struct {
+type SourceInOrderContext struct {
 	*antlr.BaseParserRuleContext
-	parser             antlr.Parser
-	_expression        IExpressionContext
-	amounts            []IExpressionContext
-	_keptOrDestination IKeptOrDestinationContext
-	dests              []IKeptOrDestinationContext
-	remainingDest      IKeptOrDestinationContext
+	parser  antlr.Parser
+	_source ISourceContext
+	sources []ISourceContext
 }
-
-func NewEmptyDestinationInOrderContext() *DestinationInOrderContext {
-	var p = new(DestinationInOrderContext)
+
+func NewEmptySourceInOrderContext() *SourceInOrderContext {
+	var p = new(SourceInOrderContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_destinationInOrder
+	p.RuleIndex = NumScriptParserRULE_sourceInOrder
 	return p
 }
 
-func (*DestinationInOrderContext) IsDestinationInOrderContext() {}
+func (*SourceInOrderContext) IsSourceInOrderContext() {}
 
-func NewDestinationInOrderContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DestinationInOrderContext {
-	var p = new(DestinationInOrderContext)
+func NewSourceInOrderContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceInOrderContext {
+	var p = new(SourceInOrderContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_destinationInOrder
+	p.RuleIndex = NumScriptParserRULE_sourceInOrder
 
 	return p
 }
 
-func (s *DestinationInOrderContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *DestinationInOrderContext) Get_expression() IExpressionContext { return s._expression }
-
-func (s *DestinationInOrderContext) Get_keptOrDestination() IKeptOrDestinationContext {
-	return s._keptOrDestination
-}
-
-func (s *DestinationInOrderContext) GetRemainingDest() IKeptOrDestinationContext {
-	return s.remainingDest
-}
-
-func (s *DestinationInOrderContext) Set_expression(v IExpressionContext) { s._expression = v }
-
-func (s *DestinationInOrderContext) Set_keptOrDestination(v IKeptOrDestinationContext) {
-	s._keptOrDestination = v
-}
-
-func (s *DestinationInOrderContext) SetRemainingDest(v IKeptOrDestinationContext) {
-	s.remainingDest = v
-}
+func (s *SourceInOrderContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *DestinationInOrderContext) GetAmounts() []IExpressionContext { return s.amounts }
+func (s *SourceInOrderContext) Get_source() ISourceContext { return s._source }
 
-func (s *DestinationInOrderContext) GetDests() []IKeptOrDestinationContext { return s.dests }
+func (s *SourceInOrderContext) Set_source(v ISourceContext) { s._source = v }
 
-func (s *DestinationInOrderContext) SetAmounts(v []IExpressionContext) { s.amounts = v }
+func (s *SourceInOrderContext) GetSources() []ISourceContext { return s.sources }
 
-func (s *DestinationInOrderContext) SetDests(v []IKeptOrDestinationContext) { s.dests = v }
+func (s *SourceInOrderContext) SetSources(v []ISourceContext) { s.sources = v }
 
-func (s *DestinationInOrderContext) LBRACE() antlr.TerminalNode {
+func (s *SourceInOrderContext) LBRACE() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserLBRACE, 0)
 }
 
-func (s *DestinationInOrderContext) AllNEWLINE() []antlr.TerminalNode {
+func (s *SourceInOrderContext) AllNEWLINE() []antlr.TerminalNode {
 	return s.GetTokens(NumScriptParserNEWLINE)
 }
 
-func (s *DestinationInOrderContext) NEWLINE(i int) antlr.TerminalNode {
+func (s *SourceInOrderContext) NEWLINE(i int) antlr.TerminalNode {
 	return s.GetToken(NumScriptParserNEWLINE, i)
 }
 
-func (s *DestinationInOrderContext) REMAINING() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserREMAINING, 0)
-}
-
-func (s *DestinationInOrderContext) RBRACE() antlr.TerminalNode {
+func (s *SourceInOrderContext) RBRACE() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserRBRACE, 0)
 }
 
-func (s *DestinationInOrderContext) AllKeptOrDestination() []IKeptOrDestinationContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(IKeptOrDestinationContext); ok {
-			len++
-		}
-	}
-
-	tst := make([]IKeptOrDestinationContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(IKeptOrDestinationContext); ok {
-			tst[i] = t.(IKeptOrDestinationContext)
-			i++
-		}
-	}
-
-	return tst
-}
-
-func (s *DestinationInOrderContext) KeptOrDestination(i int) IKeptOrDestinationContext {
-	var t antlr.RuleContext
-	j := 0
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IKeptOrDestinationContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IKeptOrDestinationContext)
-}
-
-func (s *DestinationInOrderContext) AllMAX() []antlr.TerminalNode {
-	return s.GetTokens(NumScriptParserMAX)
-}
-
-func (s *DestinationInOrderContext) MAX(i int) antlr.TerminalNode {
-	return s.GetToken(NumScriptParserMAX, i)
-}
-
-func (s *DestinationInOrderContext) AllExpression() []IExpressionContext {
+func (s *SourceInOrderContext) AllSource() []ISourceContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
-		if _, ok := ctx.(IExpressionContext); ok {
+		if _, ok := ctx.(ISourceContext); ok {
 			len++
 		}
 	}
 
-	tst := make([]IExpressionContext, len)
+	tst := make([]ISourceContext, len)
 	i := 0
 	for _, ctx := range children {
-		if t, ok := ctx.(IExpressionContext); ok {
-			tst[i] = t.(IExpressionContext)
+		if t, ok := ctx.(ISourceContext); ok {
+			tst[i] = t.(ISourceContext)
 			i++
 		}
 	}
@@ -1734,11 +3804,11 @@ func (s *DestinationInOrderContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *DestinationInOrderContext) Expression(i int) IExpressionContext {
+func (s *SourceInOrderContext) Source(i int) ISourceContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
+		if _, ok := ctx.(ISourceContext); ok {
 			if j == i {
 				t = ctx.(antlr.RuleContext)
 				break
@@ -1751,35 +3821,35 @@ func (s *DestinationInOrderContext) Expression(i int) IExpressionContext {
 		return nil
 	}
 
-	return t.(IExpressionContext)
+	return t.(ISourceContext)
 }
 
-func (s *DestinationInOrderContext) GetRuleContext() antlr.RuleContext {
+func (s *SourceInOrderContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *DestinationInOrderContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *SourceInOrderContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *DestinationInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SourceInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterDestinationInOrder(s)
+		listenerT.EnterSourceInOrder(s)
 	}
 }
 
-func (s *DestinationInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SourceInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitDestinationInOrder(s)
+		listenerT.ExitSourceInOrder(s)
 	}
 }
 
-func (p *NumScriptParser) DestinationInOrder() (localctx IDestinationInOrderContext) {
+func (p *NumScriptParser) SourceInOrder() (localctx ISourceInOrderContext) {
 	this := p
 	_ = this
 
-	localctx = NewDestinationInOrderContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, NumScriptParserRULE_destinationInOrder)
+	localctx = NewSourceInOrderContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 22, NumScriptParserRULE_sourceInOrder)
 	var _la int
 
 	defer func() {
@@ -1800,207 +3870,117 @@ func (p *NumScriptParser) DestinationInOrder() (localctx IDestinationInOrderCont
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(79)
+		p.SetState(159)
 		p.Match(NumScriptParserLBRACE)
 	}
 	{
-		p.SetState(80)
+		p.SetState(160)
 		p.Match(NumScriptParserNEWLINE)
 	}
-	p.SetState(86)
+	p.SetState(164)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for ok := true; ok; ok = _la == NumScriptParserMAX {
-		{
-			p.SetState(81)
-			p.Match(NumScriptParserMAX)
-		}
-		{
-			p.SetState(82)
-
-			var _x = p.expression(0)
-
-			localctx.(*DestinationInOrderContext)._expression = _x
-		}
-		localctx.(*DestinationInOrderContext).amounts = append(localctx.(*DestinationInOrderContext).amounts, localctx.(*DestinationInOrderContext)._expression)
+	for ok := true; ok; ok = _la == NumScriptParserBALANCE || _la == NumScriptParserMAX || (((_la-34)&-(0x1f+1)) == 0 && ((1<<uint((_la-34)))&((1<<(NumScriptParserOP_NOT-34))|(1<<(NumScriptParserLPAREN-34))|(1<<(NumScriptParserLBRACK-34))|(1<<(NumScriptParserLBRACE-34))|(1<<(NumScriptParserTRUE-34))|(1<<(NumScriptParserFALSE-34))|(1<<(NumScriptParserSTRING-34))|(1<<(NumScriptParserPORTION-34))|(1<<(NumScriptParserNUMBER-34))|(1<<(NumScriptParserVARIABLE_NAME-34))|(1<<(NumScriptParserACCOUNT-34))|(1<<(NumScriptParserASSET-34)))) != 0) {
 		{
-			p.SetState(83)
+			p.SetState(161)
 
-			var _x = p.KeptOrDestination()
+			var _x = p.Source()
 
-			localctx.(*DestinationInOrderContext)._keptOrDestination = _x
+			localctx.(*SourceInOrderContext)._source = _x
 		}
-		localctx.(*DestinationInOrderContext).dests = append(localctx.(*DestinationInOrderContext).dests, localctx.(*DestinationInOrderContext)._keptOrDestination)
+		localctx.(*SourceInOrderContext).sources = append(localctx.(*SourceInOrderContext).sources, localctx.(*SourceInOrderContext)._source)
 		{
-			p.SetState(84)
+			p.SetState(162)
 			p.Match(NumScriptParserNEWLINE)
 		}
 
-		p.SetState(88)
+		p.SetState(166)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(90)
-		p.Match(NumScriptParserREMAINING)
-	}
-	{
-		p.SetState(91)
-
-		var _x = p.KeptOrDestination()
-
-		localctx.(*DestinationInOrderContext).remainingDest = _x
-	}
-	{
-		p.SetState(92)
-		p.Match(NumScriptParserNEWLINE)
-	}
-	{
-		p.SetState(93)
+		p.SetState(168)
 		p.Match(NumScriptParserRBRACE)
 	}
 
 	return localctx
 }
 
-// IDestinationAllotmentContext is an interface to support dynamic dispatch.
-type IDestinationAllotmentContext interface {
+// ISourceMaxedContext is an interface to support dynamic dispatch.
+type ISourceMaxedContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// Get_allotmentPortion returns the _allotmentPortion rule contexts.
-	Get_allotmentPortion() IAllotmentPortionContext
-
-	// Get_keptOrDestination returns the _keptOrDestination rule contexts.
-	Get_keptOrDestination() IKeptOrDestinationContext
-
-	// Set_allotmentPortion sets the _allotmentPortion rule contexts.
-	Set_allotmentPortion(IAllotmentPortionContext)
-
-	// Set_keptOrDestination sets the _keptOrDestination rule contexts.
-	Set_keptOrDestination(IKeptOrDestinationContext)
-
-	// GetPortions returns the portions rule context list.
-	GetPortions() []IAllotmentPortionContext
+	// GetMax returns the max rule contexts.
+	GetMax() IExpressionContext
 
-	// GetDests returns the dests rule context list.
-	GetDests() []IKeptOrDestinationContext
+	// GetSrc returns the src rule contexts.
+	GetSrc() ISourceContext
 
-	// SetPortions sets the portions rule context list.
-	SetPortions([]IAllotmentPortionContext)
+	// SetMax sets the max rule contexts.
+	SetMax(IExpressionContext)
 
-	// SetDests sets the dests rule context list.
-	SetDests([]IKeptOrDestinationContext)
+	// SetSrc sets the src rule contexts.
+	SetSrc(ISourceContext)
 
-	// IsDestinationAllotmentContext differentiates from other interfaces.
-	IsDestinationAllotmentContext()
+	// IsSourceMaxedContext differentiates from other interfaces.
+	IsSourceMaxedContext()
 }
 
-type DestinationAllotmentContext struct {
+type SourceMaxedContext struct {
 	*antlr.BaseParserRuleContext
-	parser             antlr.Parser
-	_allotmentPortion  IAllotmentPortionContext
-	portions           []IAllotmentPortionContext
-	_keptOrDestination IKeptOrDestinationContext
-	dests              []IKeptOrDestinationContext
+	parser antlr.Parser
+	max    IExpressionContext
+	src    ISourceContext
 }
 
-func NewEmptyDestinationAllotmentContext() *DestinationAllotmentContext {
-	var p = new(DestinationAllotmentContext)
+func NewEmptySourceMaxedContext() *SourceMaxedContext {
+	var p = new(SourceMaxedContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_destinationAllotment
-	return p
-}
-
-func (*DestinationAllotmentContext) IsDestinationAllotmentContext() {}
-
-func NewDestinationAllotmentContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DestinationAllotmentContext {
-	var p = new(DestinationAllotmentContext)
-
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
-
-	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_destinationAllotment
-
-	return p
-}
-
-func (s *DestinationAllotmentContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *DestinationAllotmentContext) Get_allotmentPortion() IAllotmentPortionContext {
-	return s._allotmentPortion
-}
-
-func (s *DestinationAllotmentContext) Get_keptOrDestination() IKeptOrDestinationContext {
-	return s._keptOrDestination
-}
-
-func (s *DestinationAllotmentContext) Set_allotmentPortion(v IAllotmentPortionContext) {
-	s._allotmentPortion = v
-}
-
-func (s *DestinationAllotmentContext) Set_keptOrDestination(v IKeptOrDestinationContext) {
-	s._keptOrDestination = v
+	p.RuleIndex = NumScriptParserRULE_sourceMaxed
+	return p
 }
 
-func (s *DestinationAllotmentContext) GetPortions() []IAllotmentPortionContext { return s.portions }
+func (*SourceMaxedContext) IsSourceMaxedContext() {}
 
-func (s *DestinationAllotmentContext) GetDests() []IKeptOrDestinationContext { return s.dests }
+func NewSourceMaxedContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceMaxedContext {
+	var p = new(SourceMaxedContext)
 
-func (s *DestinationAllotmentContext) SetPortions(v []IAllotmentPortionContext) { s.portions = v }
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-func (s *DestinationAllotmentContext) SetDests(v []IKeptOrDestinationContext) { s.dests = v }
+	p.parser = parser
+	p.RuleIndex = NumScriptParserRULE_sourceMaxed
 
-func (s *DestinationAllotmentContext) LBRACE() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserLBRACE, 0)
+	return p
 }
 
-func (s *DestinationAllotmentContext) AllNEWLINE() []antlr.TerminalNode {
-	return s.GetTokens(NumScriptParserNEWLINE)
-}
+func (s *SourceMaxedContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *DestinationAllotmentContext) NEWLINE(i int) antlr.TerminalNode {
-	return s.GetToken(NumScriptParserNEWLINE, i)
-}
+func (s *SourceMaxedContext) GetMax() IExpressionContext { return s.max }
 
-func (s *DestinationAllotmentContext) RBRACE() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserRBRACE, 0)
-}
+func (s *SourceMaxedContext) GetSrc() ISourceContext { return s.src }
 
-func (s *DestinationAllotmentContext) AllAllotmentPortion() []IAllotmentPortionContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(IAllotmentPortionContext); ok {
-			len++
-		}
-	}
+func (s *SourceMaxedContext) SetMax(v IExpressionContext) { s.max = v }
 
-	tst := make([]IAllotmentPortionContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(IAllotmentPortionContext); ok {
-			tst[i] = t.(IAllotmentPortionContext)
-			i++
-		}
-	}
+func (s *SourceMaxedContext) SetSrc(v ISourceContext) { s.src = v }
 
-	return tst
+func (s *SourceMaxedContext) MAX() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserMAX, 0)
 }
 
-func (s *DestinationAllotmentContext) AllotmentPortion(i int) IAllotmentPortionContext {
+func (s *SourceMaxedContext) FROM() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserFROM, 0)
+}
+
+func (s *SourceMaxedContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
-	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IAllotmentPortionContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
@@ -2008,40 +3988,15 @@ func (s *DestinationAllotmentContext) AllotmentPortion(i int) IAllotmentPortionC
 		return nil
 	}
 
-	return t.(IAllotmentPortionContext)
-}
-
-func (s *DestinationAllotmentContext) AllKeptOrDestination() []IKeptOrDestinationContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(IKeptOrDestinationContext); ok {
-			len++
-		}
-	}
-
-	tst := make([]IKeptOrDestinationContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(IKeptOrDestinationContext); ok {
-			tst[i] = t.(IKeptOrDestinationContext)
-			i++
-		}
-	}
-
-	return tst
+	return t.(IExpressionContext)
 }
 
-func (s *DestinationAllotmentContext) KeptOrDestination(i int) IKeptOrDestinationContext {
+func (s *SourceMaxedContext) Source() ISourceContext {
 	var t antlr.RuleContext
-	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IKeptOrDestinationContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
+		if _, ok := ctx.(ISourceContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
@@ -2049,36 +4004,35 @@ func (s *DestinationAllotmentContext) KeptOrDestination(i int) IKeptOrDestinatio
 		return nil
 	}
 
-	return t.(IKeptOrDestinationContext)
+	return t.(ISourceContext)
 }
 
-func (s *DestinationAllotmentContext) GetRuleContext() antlr.RuleContext {
+func (s *SourceMaxedContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *DestinationAllotmentContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *SourceMaxedContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *DestinationAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SourceMaxedContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterDestinationAllotment(s)
+		listenerT.EnterSourceMaxed(s)
 	}
 }
 
-func (s *DestinationAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SourceMaxedContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitDestinationAllotment(s)
+		listenerT.ExitSourceMaxed(s)
 	}
 }
 
-func (p *NumScriptParser) DestinationAllotment() (localctx IDestinationAllotmentContext) {
+func (p *NumScriptParser) SourceMaxed() (localctx ISourceMaxedContext) {
 	this := p
 	_ = this
 
-	localctx = NewDestinationAllotmentContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, NumScriptParserRULE_destinationAllotment)
-	var _la int
+	localctx = NewSourceMaxedContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 24, NumScriptParserRULE_sourceMaxed)
 
 	defer func() {
 		p.ExitRule()
@@ -2098,191 +4052,196 @@ func (p *NumScriptParser) DestinationAllotment() (localctx IDestinationAllotment
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(95)
-		p.Match(NumScriptParserLBRACE)
+		p.SetState(170)
+		p.Match(NumScriptParserMAX)
 	}
 	{
-		p.SetState(96)
-		p.Match(NumScriptParserNEWLINE)
-	}
-	p.SetState(101)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
-
-	for ok := true; ok; ok = (((_la-35)&-(0x1f+1)) == 0 && ((1<<uint((_la-35)))&((1<<(NumScriptParserPORTION-35))|(1<<(NumScriptParserREMAINING-35))|(1<<(NumScriptParserVARIABLE_NAME-35)))) != 0) {
-		{
-			p.SetState(97)
-
-			var _x = p.AllotmentPortion()
-
-			localctx.(*DestinationAllotmentContext)._allotmentPortion = _x
-		}
-		localctx.(*DestinationAllotmentContext).portions = append(localctx.(*DestinationAllotmentContext).portions, localctx.(*DestinationAllotmentContext)._allotmentPortion)
-		{
-			p.SetState(98)
+		p.SetState(171)
 
-			var _x = p.KeptOrDestination()
-
-			localctx.(*DestinationAllotmentContext)._keptOrDestination = _x
-		}
-		localctx.(*DestinationAllotmentContext).dests = append(localctx.(*DestinationAllotmentContext).dests, localctx.(*DestinationAllotmentContext)._keptOrDestination)
-		{
-			p.SetState(99)
-			p.Match(NumScriptParserNEWLINE)
-		}
+		var _x = p.expression(0)
 
-		p.SetState(103)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
+		localctx.(*SourceMaxedContext).max = _x
 	}
 	{
-		p.SetState(105)
-		p.Match(NumScriptParserRBRACE)
+		p.SetState(172)
+		p.Match(NumScriptParserFROM)
+	}
+	{
+		p.SetState(173)
+
+		var _x = p.Source()
+
+		localctx.(*SourceMaxedContext).src = _x
 	}
 
 	return localctx
 }
 
-// IKeptOrDestinationContext is an interface to support dynamic dispatch.
-type IKeptOrDestinationContext interface {
+// ISourceAccountOverdraftContext is an interface to support dynamic dispatch.
+type ISourceAccountOverdraftContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsKeptOrDestinationContext differentiates from other interfaces.
-	IsKeptOrDestinationContext()
+	// IsSourceAccountOverdraftContext differentiates from other interfaces.
+	IsSourceAccountOverdraftContext()
 }
 
-type KeptOrDestinationContext struct {
+type SourceAccountOverdraftContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyKeptOrDestinationContext() *KeptOrDestinationContext {
-	var p = new(KeptOrDestinationContext)
+func NewEmptySourceAccountOverdraftContext() *SourceAccountOverdraftContext {
+	var p = new(SourceAccountOverdraftContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_keptOrDestination
+	p.RuleIndex = NumScriptParserRULE_sourceAccountOverdraft
 	return p
 }
 
-func (*KeptOrDestinationContext) IsKeptOrDestinationContext() {}
+func (*SourceAccountOverdraftContext) IsSourceAccountOverdraftContext() {}
 
-func NewKeptOrDestinationContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *KeptOrDestinationContext {
-	var p = new(KeptOrDestinationContext)
+func NewSourceAccountOverdraftContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceAccountOverdraftContext {
+	var p = new(SourceAccountOverdraftContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_keptOrDestination
+	p.RuleIndex = NumScriptParserRULE_sourceAccountOverdraft
 
 	return p
 }
 
-func (s *KeptOrDestinationContext) GetParser() antlr.Parser { return s.parser }
+func (s *SourceAccountOverdraftContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *KeptOrDestinationContext) CopyFrom(ctx *KeptOrDestinationContext) {
+func (s *SourceAccountOverdraftContext) CopyFrom(ctx *SourceAccountOverdraftContext) {
 	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
 }
 
-func (s *KeptOrDestinationContext) GetRuleContext() antlr.RuleContext {
+func (s *SourceAccountOverdraftContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *KeptOrDestinationContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *SourceAccountOverdraftContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-type IsKeptContext struct {
-	*KeptOrDestinationContext
+type SrcAccountOverdraftSpecificContext struct {
+	*SourceAccountOverdraftContext
+	specific IExpressionContext
 }
 
-func NewIsKeptContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IsKeptContext {
-	var p = new(IsKeptContext)
+func NewSrcAccountOverdraftSpecificContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAccountOverdraftSpecificContext {
+	var p = new(SrcAccountOverdraftSpecificContext)
 
-	p.KeptOrDestinationContext = NewEmptyKeptOrDestinationContext()
+	p.SourceAccountOverdraftContext = NewEmptySourceAccountOverdraftContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*KeptOrDestinationContext))
+	p.CopyFrom(ctx.(*SourceAccountOverdraftContext))
 
 	return p
 }
 
-func (s *IsKeptContext) GetRuleContext() antlr.RuleContext {
+func (s *SrcAccountOverdraftSpecificContext) GetSpecific() IExpressionContext { return s.specific }
+
+func (s *SrcAccountOverdraftSpecificContext) SetSpecific(v IExpressionContext) { s.specific = v }
+
+func (s *SrcAccountOverdraftSpecificContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *IsKeptContext) KEPT() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserKEPT, 0)
+func (s *SrcAccountOverdraftSpecificContext) ALLOWING() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserALLOWING, 0)
 }
 
-func (s *IsKeptContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SrcAccountOverdraftSpecificContext) OVERDRAFT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOVERDRAFT, 0)
+}
+
+func (s *SrcAccountOverdraftSpecificContext) UP() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserUP, 0)
+}
+
+func (s *SrcAccountOverdraftSpecificContext) TO() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserTO, 0)
+}
+
+func (s *SrcAccountOverdraftSpecificContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *SrcAccountOverdraftSpecificContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterIsKept(s)
+		listenerT.EnterSrcAccountOverdraftSpecific(s)
 	}
 }
 
-func (s *IsKeptContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SrcAccountOverdraftSpecificContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitIsKept(s)
+		listenerT.ExitSrcAccountOverdraftSpecific(s)
 	}
 }
 
-type IsDestinationContext struct {
-	*KeptOrDestinationContext
+type SrcAccountOverdraftUnboundedContext struct {
+	*SourceAccountOverdraftContext
 }
 
-func NewIsDestinationContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IsDestinationContext {
-	var p = new(IsDestinationContext)
+func NewSrcAccountOverdraftUnboundedContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAccountOverdraftUnboundedContext {
+	var p = new(SrcAccountOverdraftUnboundedContext)
 
-	p.KeptOrDestinationContext = NewEmptyKeptOrDestinationContext()
+	p.SourceAccountOverdraftContext = NewEmptySourceAccountOverdraftContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*KeptOrDestinationContext))
+	p.CopyFrom(ctx.(*SourceAccountOverdraftContext))
 
 	return p
 }
 
-func (s *IsDestinationContext) GetRuleContext() antlr.RuleContext {
+func (s *SrcAccountOverdraftUnboundedContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *IsDestinationContext) TO() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserTO, 0)
+func (s *SrcAccountOverdraftUnboundedContext) ALLOWING() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserALLOWING, 0)
 }
 
-func (s *IsDestinationContext) Destination() IDestinationContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IDestinationContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
+func (s *SrcAccountOverdraftUnboundedContext) UNBOUNDED() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserUNBOUNDED, 0)
+}
 
-	return t.(IDestinationContext)
+func (s *SrcAccountOverdraftUnboundedContext) OVERDRAFT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOVERDRAFT, 0)
 }
 
-func (s *IsDestinationContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SrcAccountOverdraftUnboundedContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterIsDestination(s)
+		listenerT.EnterSrcAccountOverdraftUnbounded(s)
 	}
 }
 
-func (s *IsDestinationContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SrcAccountOverdraftUnboundedContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitIsDestination(s)
+		listenerT.ExitSrcAccountOverdraftUnbounded(s)
 	}
 }
 
-func (p *NumScriptParser) KeptOrDestination() (localctx IKeptOrDestinationContext) {
+func (p *NumScriptParser) SourceAccountOverdraft() (localctx ISourceAccountOverdraftContext) {
 	this := p
 	_ = this
 
-	localctx = NewKeptOrDestinationContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, NumScriptParserRULE_keptOrDestination)
+	localctx = NewSourceAccountOverdraftContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 26, NumScriptParserRULE_sourceAccountOverdraft)
 
 	defer func() {
 		p.ExitRule()
@@ -2300,106 +4259,118 @@ func (p *NumScriptParser) KeptOrDestination() (localctx IKeptOrDestinationContex
 		}
 	}()
 
-	p.SetState(110)
+	p.SetState(183)
 	p.GetErrorHandler().Sync(p)
-
-	switch p.GetTokenStream().LA(1) {
-	case NumScriptParserTO:
-		localctx = NewIsDestinationContext(p, localctx)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 11, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewSrcAccountOverdraftSpecificContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(107)
+			p.SetState(175)
+			p.Match(NumScriptParserALLOWING)
+		}
+		{
+			p.SetState(176)
+			p.Match(NumScriptParserOVERDRAFT)
+		}
+		{
+			p.SetState(177)
+			p.Match(NumScriptParserUP)
+		}
+		{
+			p.SetState(178)
 			p.Match(NumScriptParserTO)
 		}
 		{
-			p.SetState(108)
-			p.Destination()
+			p.SetState(179)
+
+			var _x = p.expression(0)
+
+			localctx.(*SrcAccountOverdraftSpecificContext).specific = _x
+		}
+
+	case 2:
+		localctx = NewSrcAccountOverdraftUnboundedContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(180)
+			p.Match(NumScriptParserALLOWING)
+		}
+		{
+			p.SetState(181)
+			p.Match(NumScriptParserUNBOUNDED)
 		}
-
-	case NumScriptParserKEPT:
-		localctx = NewIsKeptContext(p, localctx)
-		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(109)
-			p.Match(NumScriptParserKEPT)
+			p.SetState(182)
+			p.Match(NumScriptParserOVERDRAFT)
 		}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
 }
 
-// IDestinationContext is an interface to support dynamic dispatch.
-type IDestinationContext interface {
+// ISourceAccountContext is an interface to support dynamic dispatch.
+type ISourceAccountContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsDestinationContext differentiates from other interfaces.
-	IsDestinationContext()
+	// GetAccount returns the account rule contexts.
+	GetAccount() IExpressionContext
+
+	// GetOverdraft returns the overdraft rule contexts.
+	GetOverdraft() ISourceAccountOverdraftContext
+
+	// SetAccount sets the account rule contexts.
+	SetAccount(IExpressionContext)
+
+	// SetOverdraft sets the overdraft rule contexts.
+	SetOverdraft(ISourceAccountOverdraftContext)
+
+	// IsSourceAccountContext differentiates from other interfaces.
+	IsSourceAccountContext()
 }
 
-type DestinationContext struct {
+type SourceAccountContext struct {
 	*antlr.BaseParserRuleContext
-	parser antlr.Parser
+	parser    antlr.Parser
+	account   IExpressionContext
+	overdraft ISourceAccountOverdraftContext
 }
 
-func NewEmptyDestinationContext() *DestinationContext {
-	var p = new(DestinationContext)
+func NewEmptySourceAccountContext() *SourceAccountContext {
+	var p = new(SourceAccountContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_destination
+	p.RuleIndex = NumScriptParserRULE_sourceAccount
 	return p
 }
 
-func (*DestinationContext) IsDestinationContext() {}
+func (*SourceAccountContext) IsSourceAccountContext() {}
 
-func NewDestinationContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DestinationContext {
-	var p = new(DestinationContext)
+func NewSourceAccountContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceAccountContext {
+	var p = new(SourceAccountContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_destination
+	p.RuleIndex = NumScriptParserRULE_sourceAccount
 
 	return p
 }
 
-func (s *DestinationContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *DestinationContext) CopyFrom(ctx *DestinationContext) {
-	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
-}
-
-func (s *DestinationContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *DestinationContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
-}
-
-type DestAccountContext struct {
-	*DestinationContext
-}
+func (s *SourceAccountContext) GetParser() antlr.Parser { return s.parser }
 
-func NewDestAccountContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *DestAccountContext {
-	var p = new(DestAccountContext)
+func (s *SourceAccountContext) GetAccount() IExpressionContext { return s.account }
 
-	p.DestinationContext = NewEmptyDestinationContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*DestinationContext))
+func (s *SourceAccountContext) GetOverdraft() ISourceAccountOverdraftContext { return s.overdraft }
 
-	return p
-}
+func (s *SourceAccountContext) SetAccount(v IExpressionContext) { s.account = v }
 
-func (s *DestAccountContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+func (s *SourceAccountContext) SetOverdraft(v ISourceAccountOverdraftContext) { s.overdraft = v }
 
-func (s *DestAccountContext) Expression() IExpressionContext {
+func (s *SourceAccountContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
@@ -2415,40 +4386,10 @@ func (s *DestAccountContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *DestAccountContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterDestAccount(s)
-	}
-}
-
-func (s *DestAccountContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitDestAccount(s)
-	}
-}
-
-type DestAllotmentContext struct {
-	*DestinationContext
-}
-
-func NewDestAllotmentContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *DestAllotmentContext {
-	var p = new(DestAllotmentContext)
-
-	p.DestinationContext = NewEmptyDestinationContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*DestinationContext))
-
-	return p
-}
-
-func (s *DestAllotmentContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *DestAllotmentContext) DestinationAllotment() IDestinationAllotmentContext {
+func (s *SourceAccountContext) SourceAccountOverdraft() ISourceAccountOverdraftContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IDestinationAllotmentContext); ok {
+		if _, ok := ctx.(ISourceAccountOverdraftContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -2458,73 +4399,36 @@ func (s *DestAllotmentContext) DestinationAllotment() IDestinationAllotmentConte
 		return nil
 	}
 
-	return t.(IDestinationAllotmentContext)
-}
-
-func (s *DestAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterDestAllotment(s)
-	}
-}
-
-func (s *DestAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitDestAllotment(s)
-	}
-}
-
-type DestInOrderContext struct {
-	*DestinationContext
-}
-
-func NewDestInOrderContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *DestInOrderContext {
-	var p = new(DestInOrderContext)
-
-	p.DestinationContext = NewEmptyDestinationContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*DestinationContext))
-
-	return p
+	return t.(ISourceAccountOverdraftContext)
 }
 
-func (s *DestInOrderContext) GetRuleContext() antlr.RuleContext {
+func (s *SourceAccountContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *DestInOrderContext) DestinationInOrder() IDestinationInOrderContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IDestinationInOrderContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IDestinationInOrderContext)
+func (s *SourceAccountContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *DestInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SourceAccountContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterDestInOrder(s)
+		listenerT.EnterSourceAccount(s)
 	}
 }
 
-func (s *DestInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SourceAccountContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitDestInOrder(s)
+		listenerT.ExitSourceAccount(s)
 	}
 }
 
-func (p *NumScriptParser) Destination() (localctx IDestinationContext) {
+func (p *NumScriptParser) SourceAccount() (localctx ISourceAccountContext) {
 	this := p
 	_ = this
 
-	localctx = NewDestinationContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, NumScriptParserRULE_destination)
+	localctx = NewSourceAccountContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 28, NumScriptParserRULE_sourceAccount)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -2542,31 +4446,25 @@ func (p *NumScriptParser) Destination() (localctx IDestinationContext) {
 		}
 	}()
 
-	p.SetState(115)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(185)
+
+		var _x = p.expression(0)
+
+		localctx.(*SourceAccountContext).account = _x
+	}
+	p.SetState(187)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 7, p.GetParserRuleContext()) {
-	case 1:
-		localctx = NewDestAccountContext(p, localctx)
-		p.EnterOuterAlt(localctx, 1)
-		{
-			p.SetState(112)
-			p.expression(0)
-		}
+	_la = p.GetTokenStream().LA(1)
 
-	case 2:
-		localctx = NewDestInOrderContext(p, localctx)
-		p.EnterOuterAlt(localctx, 2)
+	if _la == NumScriptParserALLOWING {
 		{
-			p.SetState(113)
-			p.DestinationInOrder()
-		}
+			p.SetState(186)
 
-	case 3:
-		localctx = NewDestAllotmentContext(p, localctx)
-		p.EnterOuterAlt(localctx, 3)
-		{
-			p.SetState(114)
-			p.DestinationAllotment()
+			var _x = p.SourceAccountOverdraft()
+
+			localctx.(*SourceAccountContext).overdraft = _x
 		}
 
 	}
@@ -2574,113 +4472,172 @@ func (p *NumScriptParser) Destination() (localctx IDestinationContext) {
 	return localctx
 }
 
-// ISourceInOrderContext is an interface to support dynamic dispatch.
-type ISourceInOrderContext interface {
+// ISourceContext is an interface to support dynamic dispatch.
+type ISourceContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// Get_source returns the _source rule contexts.
-	Get_source() ISourceContext
+	// IsSourceContext differentiates from other interfaces.
+	IsSourceContext()
+}
 
-	// Set_source sets the _source rule contexts.
-	Set_source(ISourceContext)
+type SourceContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
 
-	// GetSources returns the sources rule context list.
-	GetSources() []ISourceContext
+func NewEmptySourceContext() *SourceContext {
+	var p = new(SourceContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = NumScriptParserRULE_source
+	return p
+}
 
-	// SetSources sets the sources rule context list.
-	SetSources([]ISourceContext)
+func (*SourceContext) IsSourceContext() {}
 
-	// IsSourceInOrderContext differentiates from other interfaces.
-	IsSourceInOrderContext()
+func NewSourceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceContext {
+	var p = new(SourceContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = NumScriptParserRULE_source
+
+	return p
 }
 
-type SourceInOrderContext struct {
-	*antlr.BaseParserRuleContext
-	parser  antlr.Parser
-	_source ISourceContext
-	sources []ISourceContext
+func (s *SourceContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *SourceContext) CopyFrom(ctx *SourceContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
 }
 
-func NewEmptySourceInOrderContext() *SourceInOrderContext {
-	var p = new(SourceInOrderContext)
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_sourceInOrder
+func (s *SourceContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SourceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type SrcAccountContext struct {
+	*SourceContext
+}
+
+func NewSrcAccountContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAccountContext {
+	var p = new(SrcAccountContext)
+
+	p.SourceContext = NewEmptySourceContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*SourceContext))
+
 	return p
 }
 
-func (*SourceInOrderContext) IsSourceInOrderContext() {}
+func (s *SrcAccountContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SrcAccountContext) SourceAccount() ISourceAccountContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ISourceAccountContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISourceAccountContext)
+}
+
+func (s *SrcAccountContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterSrcAccount(s)
+	}
+}
+
+func (s *SrcAccountContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitSrcAccount(s)
+	}
+}
 
-func NewSourceInOrderContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceInOrderContext {
-	var p = new(SourceInOrderContext)
+type SrcMaxedContext struct {
+	*SourceContext
+}
 
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+func NewSrcMaxedContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcMaxedContext {
+	var p = new(SrcMaxedContext)
 
+	p.SourceContext = NewEmptySourceContext()
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_sourceInOrder
+	p.CopyFrom(ctx.(*SourceContext))
 
 	return p
 }
 
-func (s *SourceInOrderContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *SourceInOrderContext) Get_source() ISourceContext { return s._source }
-
-func (s *SourceInOrderContext) Set_source(v ISourceContext) { s._source = v }
+func (s *SrcMaxedContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-func (s *SourceInOrderContext) GetSources() []ISourceContext { return s.sources }
+func (s *SrcMaxedContext) SourceMaxed() ISourceMaxedContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ISourceMaxedContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
 
-func (s *SourceInOrderContext) SetSources(v []ISourceContext) { s.sources = v }
+	if t == nil {
+		return nil
+	}
 
-func (s *SourceInOrderContext) LBRACE() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserLBRACE, 0)
+	return t.(ISourceMaxedContext)
 }
 
-func (s *SourceInOrderContext) AllNEWLINE() []antlr.TerminalNode {
-	return s.GetTokens(NumScriptParserNEWLINE)
+func (s *SrcMaxedContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterSrcMaxed(s)
+	}
 }
 
-func (s *SourceInOrderContext) NEWLINE(i int) antlr.TerminalNode {
-	return s.GetToken(NumScriptParserNEWLINE, i)
+func (s *SrcMaxedContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitSrcMaxed(s)
+	}
 }
 
-func (s *SourceInOrderContext) RBRACE() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserRBRACE, 0)
+type SrcInOrderContext struct {
+	*SourceContext
 }
 
-func (s *SourceInOrderContext) AllSource() []ISourceContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(ISourceContext); ok {
-			len++
-		}
-	}
+func NewSrcInOrderContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcInOrderContext {
+	var p = new(SrcInOrderContext)
 
-	tst := make([]ISourceContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(ISourceContext); ok {
-			tst[i] = t.(ISourceContext)
-			i++
-		}
-	}
+	p.SourceContext = NewEmptySourceContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*SourceContext))
 
-	return tst
+	return p
 }
 
-func (s *SourceInOrderContext) Source(i int) ISourceContext {
+func (s *SrcInOrderContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SrcInOrderContext) SourceInOrder() ISourceInOrderContext {
 	var t antlr.RuleContext
-	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
+		if _, ok := ctx.(ISourceInOrderContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
@@ -2688,36 +4645,27 @@ func (s *SourceInOrderContext) Source(i int) ISourceContext {
 		return nil
 	}
 
-	return t.(ISourceContext)
-}
-
-func (s *SourceInOrderContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *SourceInOrderContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
+	return t.(ISourceInOrderContext)
 }
 
-func (s *SourceInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SrcInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSourceInOrder(s)
+		listenerT.EnterSrcInOrder(s)
 	}
 }
 
-func (s *SourceInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SrcInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSourceInOrder(s)
+		listenerT.ExitSrcInOrder(s)
 	}
 }
 
-func (p *NumScriptParser) SourceInOrder() (localctx ISourceInOrderContext) {
+func (p *NumScriptParser) Source() (localctx ISourceContext) {
 	this := p
 	_ = this
 
-	localctx = NewSourceInOrderContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, NumScriptParserRULE_sourceInOrder)
-	var _la int
+	localctx = NewSourceContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 30, NumScriptParserRULE_source)
 
 	defer func() {
 		p.ExitRule()
@@ -2735,119 +4683,182 @@ func (p *NumScriptParser) SourceInOrder() (localctx ISourceInOrderContext) {
 		}
 	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(117)
-		p.Match(NumScriptParserLBRACE)
-	}
-	{
-		p.SetState(118)
-		p.Match(NumScriptParserNEWLINE)
-	}
-	p.SetState(122)
+	p.SetState(192)
 	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
 
-	for ok := true; ok; ok = (((_la-15)&-(0x1f+1)) == 0 && ((1<<uint((_la-15)))&((1<<(NumScriptParserMAX-15))|(1<<(NumScriptParserLBRACK-15))|(1<<(NumScriptParserLBRACE-15))|(1<<(NumScriptParserSTRING-15))|(1<<(NumScriptParserNUMBER-15))|(1<<(NumScriptParserVARIABLE_NAME-15))|(1<<(NumScriptParserACCOUNT-15))|(1<<(NumScriptParserASSET-15)))) != 0) {
+	switch p.GetTokenStream().LA(1) {
+	case NumScriptParserBALANCE, NumScriptParserOP_NOT, NumScriptParserLPAREN, NumScriptParserLBRACK, NumScriptParserTRUE, NumScriptParserFALSE, NumScriptParserSTRING, NumScriptParserPORTION, NumScriptParserNUMBER, NumScriptParserVARIABLE_NAME, NumScriptParserACCOUNT, NumScriptParserASSET:
+		localctx = NewSrcAccountContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(119)
-
-			var _x = p.Source()
+			p.SetState(189)
+			p.SourceAccount()
+		}
 
-			localctx.(*SourceInOrderContext)._source = _x
+	case NumScriptParserMAX:
+		localctx = NewSrcMaxedContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(190)
+			p.SourceMaxed()
 		}
-		localctx.(*SourceInOrderContext).sources = append(localctx.(*SourceInOrderContext).sources, localctx.(*SourceInOrderContext)._source)
+
+	case NumScriptParserLBRACE:
+		localctx = NewSrcInOrderContext(p, localctx)
+		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(120)
-			p.Match(NumScriptParserNEWLINE)
+			p.SetState(191)
+			p.SourceInOrder()
 		}
 
-		p.SetState(124)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
-	}
-	{
-		p.SetState(126)
-		p.Match(NumScriptParserRBRACE)
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
 }
 
-// ISourceMaxedContext is an interface to support dynamic dispatch.
-type ISourceMaxedContext interface {
+// ISourceAllotmentContext is an interface to support dynamic dispatch.
+type ISourceAllotmentContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// GetMax returns the max rule contexts.
-	GetMax() IExpressionContext
+	// Get_allotmentPortion returns the _allotmentPortion rule contexts.
+	Get_allotmentPortion() IAllotmentPortionContext
 
-	// GetSrc returns the src rule contexts.
-	GetSrc() ISourceContext
+	// Get_source returns the _source rule contexts.
+	Get_source() ISourceContext
 
-	// SetMax sets the max rule contexts.
-	SetMax(IExpressionContext)
+	// Set_allotmentPortion sets the _allotmentPortion rule contexts.
+	Set_allotmentPortion(IAllotmentPortionContext)
 
-	// SetSrc sets the src rule contexts.
-	SetSrc(ISourceContext)
+	// Set_source sets the _source rule contexts.
+	Set_source(ISourceContext)
 
-	// IsSourceMaxedContext differentiates from other interfaces.
-	IsSourceMaxedContext()
+	// GetPortions returns the portions rule context list.
+	GetPortions() []IAllotmentPortionContext
+
+	// GetSources returns the sources rule context list.
+	GetSources() []ISourceContext
+
+	// SetPortions sets the portions rule context list.
+	SetPortions([]IAllotmentPortionContext)
+
+	// SetSources sets the sources rule context list.
+	SetSources([]ISourceContext)
+
+	// IsSourceAllotmentContext differentiates from other interfaces.
+	IsSourceAllotmentContext()
 }
 
-type SourceMaxedContext struct {
+type SourceAllotmentContext struct {
 	*antlr.BaseParserRuleContext
-	parser antlr.Parser
-	max    IExpressionContext
-	src    ISourceContext
+	parser            antlr.Parser
+	_allotmentPortion IAllotmentPortionContext
+	portions          []IAllotmentPortionContext
+	_source           ISourceContext
+	sources           []ISourceContext
 }
 
-func NewEmptySourceMaxedContext() *SourceMaxedContext {
-	var p = new(SourceMaxedContext)
+func NewEmptySourceAllotmentContext() *SourceAllotmentContext {
+	var p = new(SourceAllotmentContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_sourceMaxed
+	p.RuleIndex = NumScriptParserRULE_sourceAllotment
 	return p
 }
 
-func (*SourceMaxedContext) IsSourceMaxedContext() {}
+func (*SourceAllotmentContext) IsSourceAllotmentContext() {}
 
-func NewSourceMaxedContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceMaxedContext {
-	var p = new(SourceMaxedContext)
+func NewSourceAllotmentContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceAllotmentContext {
+	var p = new(SourceAllotmentContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_sourceMaxed
+	p.RuleIndex = NumScriptParserRULE_sourceAllotment
 
 	return p
 }
 
-func (s *SourceMaxedContext) GetParser() antlr.Parser { return s.parser }
+func (s *SourceAllotmentContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SourceMaxedContext) GetMax() IExpressionContext { return s.max }
+func (s *SourceAllotmentContext) Get_allotmentPortion() IAllotmentPortionContext {
+	return s._allotmentPortion
+}
 
-func (s *SourceMaxedContext) GetSrc() ISourceContext { return s.src }
+func (s *SourceAllotmentContext) Get_source() ISourceContext { return s._source }
 
-func (s *SourceMaxedContext) SetMax(v IExpressionContext) { s.max = v }
+func (s *SourceAllotmentContext) Set_allotmentPortion(v IAllotmentPortionContext) {
+	s._allotmentPortion = v
+}
+
+func (s *SourceAllotmentContext) Set_source(v ISourceContext) { s._source = v }
+
+func (s *SourceAllotmentContext) GetPortions() []IAllotmentPortionContext { return s.portions }
+
+func (s *SourceAllotmentContext) GetSources() []ISourceContext { return s.sources }
+
+func (s *SourceAllotmentContext) SetPortions(v []IAllotmentPortionContext) { s.portions = v }
+
+func (s *SourceAllotmentContext) SetSources(v []ISourceContext) { s.sources = v }
+
+func (s *SourceAllotmentContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLBRACE, 0)
+}
+
+func (s *SourceAllotmentContext) AllNEWLINE() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserNEWLINE)
+}
+
+func (s *SourceAllotmentContext) NEWLINE(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserNEWLINE, i)
+}
+
+func (s *SourceAllotmentContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRBRACE, 0)
+}
+
+func (s *SourceAllotmentContext) AllFROM() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserFROM)
+}
+
+func (s *SourceAllotmentContext) FROM(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserFROM, i)
+}
 
-func (s *SourceMaxedContext) SetSrc(v ISourceContext) { s.src = v }
+func (s *SourceAllotmentContext) AllAllotmentPortion() []IAllotmentPortionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IAllotmentPortionContext); ok {
+			len++
+		}
+	}
 
-func (s *SourceMaxedContext) MAX() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserMAX, 0)
-}
+	tst := make([]IAllotmentPortionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IAllotmentPortionContext); ok {
+			tst[i] = t.(IAllotmentPortionContext)
+			i++
+		}
+	}
 
-func (s *SourceMaxedContext) FROM() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserFROM, 0)
+	return tst
 }
 
-func (s *SourceMaxedContext) Expression() IExpressionContext {
+func (s *SourceAllotmentContext) AllotmentPortion(i int) IAllotmentPortionContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(IAllotmentPortionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -2855,15 +4866,40 @@ func (s *SourceMaxedContext) Expression() IExpressionContext {
 		return nil
 	}
 
-	return t.(IExpressionContext)
+	return t.(IAllotmentPortionContext)
 }
 
-func (s *SourceMaxedContext) Source() ISourceContext {
+func (s *SourceAllotmentContext) AllSource() []ISourceContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ISourceContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]ISourceContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ISourceContext); ok {
+			tst[i] = t.(ISourceContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *SourceAllotmentContext) Source(i int) ISourceContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(ISourceContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -2874,32 +4910,33 @@ func (s *SourceMaxedContext) Source() ISourceContext {
 	return t.(ISourceContext)
 }
 
-func (s *SourceMaxedContext) GetRuleContext() antlr.RuleContext {
+func (s *SourceAllotmentContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SourceMaxedContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *SourceAllotmentContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SourceMaxedContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SourceAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSourceMaxed(s)
+		listenerT.EnterSourceAllotment(s)
 	}
 }
 
-func (s *SourceMaxedContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SourceAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSourceMaxed(s)
+		listenerT.ExitSourceAllotment(s)
 	}
 }
 
-func (p *NumScriptParser) SourceMaxed() (localctx ISourceMaxedContext) {
+func (p *NumScriptParser) SourceAllotment() (localctx ISourceAllotmentContext) {
 	this := p
 	_ = this
 
-	localctx = NewSourceMaxedContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, NumScriptParserRULE_sourceMaxed)
+	localctx = NewSourceAllotmentContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 32, NumScriptParserRULE_sourceAllotment)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -2919,149 +4956,127 @@ func (p *NumScriptParser) SourceMaxed() (localctx ISourceMaxedContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(128)
-		p.Match(NumScriptParserMAX)
+		p.SetState(194)
+		p.Match(NumScriptParserLBRACE)
 	}
 	{
-		p.SetState(129)
+		p.SetState(195)
+		p.Match(NumScriptParserNEWLINE)
+	}
+	p.SetState(201)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-		var _x = p.expression(0)
+	for ok := true; ok; ok = (((_la-52)&-(0x1f+1)) == 0 && ((1<<uint((_la-52)))&((1<<(NumScriptParserPORTION-52))|(1<<(NumScriptParserREMAINING-52))|(1<<(NumScriptParserVARIABLE_NAME-52)))) != 0) {
+		{
+			p.SetState(196)
 
-		localctx.(*SourceMaxedContext).max = _x
-	}
-	{
-		p.SetState(130)
-		p.Match(NumScriptParserFROM)
-	}
-	{
-		p.SetState(131)
+			var _x = p.AllotmentPortion()
 
-		var _x = p.Source()
+			localctx.(*SourceAllotmentContext)._allotmentPortion = _x
+		}
+		localctx.(*SourceAllotmentContext).portions = append(localctx.(*SourceAllotmentContext).portions, localctx.(*SourceAllotmentContext)._allotmentPortion)
+		{
+			p.SetState(197)
+			p.Match(NumScriptParserFROM)
+		}
+		{
+			p.SetState(198)
 
-		localctx.(*SourceMaxedContext).src = _x
+			var _x = p.Source()
+
+			localctx.(*SourceAllotmentContext)._source = _x
+		}
+		localctx.(*SourceAllotmentContext).sources = append(localctx.(*SourceAllotmentContext).sources, localctx.(*SourceAllotmentContext)._source)
+		{
+			p.SetState(199)
+			p.Match(NumScriptParserNEWLINE)
+		}
+
+		p.SetState(203)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(205)
+		p.Match(NumScriptParserRBRACE)
 	}
 
 	return localctx
 }
 
-// ISourceContext is an interface to support dynamic dispatch.
-type ISourceContext interface {
+// IValueAwareSourceContext is an interface to support dynamic dispatch.
+type IValueAwareSourceContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsSourceContext differentiates from other interfaces.
-	IsSourceContext()
+	// IsValueAwareSourceContext differentiates from other interfaces.
+	IsValueAwareSourceContext()
 }
 
-type SourceContext struct {
+type ValueAwareSourceContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptySourceContext() *SourceContext {
-	var p = new(SourceContext)
+func NewEmptyValueAwareSourceContext() *ValueAwareSourceContext {
+	var p = new(ValueAwareSourceContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_source
+	p.RuleIndex = NumScriptParserRULE_valueAwareSource
 	return p
 }
 
-func (*SourceContext) IsSourceContext() {}
+func (*ValueAwareSourceContext) IsValueAwareSourceContext() {}
 
-func NewSourceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceContext {
-	var p = new(SourceContext)
+func NewValueAwareSourceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ValueAwareSourceContext {
+	var p = new(ValueAwareSourceContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_source
+	p.RuleIndex = NumScriptParserRULE_valueAwareSource
 
 	return p
 }
 
-func (s *SourceContext) GetParser() antlr.Parser { return s.parser }
+func (s *ValueAwareSourceContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SourceContext) CopyFrom(ctx *SourceContext) {
+func (s *ValueAwareSourceContext) CopyFrom(ctx *ValueAwareSourceContext) {
 	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
 }
 
-func (s *SourceContext) GetRuleContext() antlr.RuleContext {
+func (s *ValueAwareSourceContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SourceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ValueAwareSourceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-type SrcAccountContext struct {
-	*SourceContext
-}
-
-func NewSrcAccountContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAccountContext {
-	var p = new(SrcAccountContext)
-
-	p.SourceContext = NewEmptySourceContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*SourceContext))
-
-	return p
-}
-
-func (s *SrcAccountContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *SrcAccountContext) Expression() IExpressionContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IExpressionContext)
-}
-
-func (s *SrcAccountContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSrcAccount(s)
-	}
-}
-
-func (s *SrcAccountContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSrcAccount(s)
-	}
-}
-
-type SrcMaxedContext struct {
-	*SourceContext
+type SrcContext struct {
+	*ValueAwareSourceContext
 }
 
-func NewSrcMaxedContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcMaxedContext {
-	var p = new(SrcMaxedContext)
+func NewSrcContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcContext {
+	var p = new(SrcContext)
 
-	p.SourceContext = NewEmptySourceContext()
+	p.ValueAwareSourceContext = NewEmptyValueAwareSourceContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*SourceContext))
+	p.CopyFrom(ctx.(*ValueAwareSourceContext))
 
 	return p
 }
 
-func (s *SrcMaxedContext) GetRuleContext() antlr.RuleContext {
+func (s *SrcContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SrcMaxedContext) SourceMaxed() ISourceMaxedContext {
+func (s *SrcContext) Source() ISourceContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceMaxedContext); ok {
+		if _, ok := ctx.(ISourceContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -3071,43 +5086,43 @@ func (s *SrcMaxedContext) SourceMaxed() ISourceMaxedContext {
 		return nil
 	}
 
-	return t.(ISourceMaxedContext)
+	return t.(ISourceContext)
 }
 
-func (s *SrcMaxedContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SrcContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSrcMaxed(s)
+		listenerT.EnterSrc(s)
 	}
 }
 
-func (s *SrcMaxedContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SrcContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSrcMaxed(s)
+		listenerT.ExitSrc(s)
 	}
 }
 
-type SrcInOrderContext struct {
-	*SourceContext
+type SrcAllotmentContext struct {
+	*ValueAwareSourceContext
 }
 
-func NewSrcInOrderContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcInOrderContext {
-	var p = new(SrcInOrderContext)
+func NewSrcAllotmentContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAllotmentContext {
+	var p = new(SrcAllotmentContext)
 
-	p.SourceContext = NewEmptySourceContext()
+	p.ValueAwareSourceContext = NewEmptyValueAwareSourceContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*SourceContext))
+	p.CopyFrom(ctx.(*ValueAwareSourceContext))
 
 	return p
 }
 
-func (s *SrcInOrderContext) GetRuleContext() antlr.RuleContext {
+func (s *SrcAllotmentContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SrcInOrderContext) SourceInOrder() ISourceInOrderContext {
+func (s *SrcAllotmentContext) SourceAllotment() ISourceAllotmentContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceInOrderContext); ok {
+		if _, ok := ctx.(ISourceAllotmentContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -3117,27 +5132,27 @@ func (s *SrcInOrderContext) SourceInOrder() ISourceInOrderContext {
 		return nil
 	}
 
-	return t.(ISourceInOrderContext)
+	return t.(ISourceAllotmentContext)
 }
 
-func (s *SrcInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SrcAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSrcInOrder(s)
+		listenerT.EnterSrcAllotment(s)
 	}
 }
 
-func (s *SrcInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SrcAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSrcInOrder(s)
+		listenerT.ExitSrcAllotment(s)
 	}
 }
 
-func (p *NumScriptParser) Source() (localctx ISourceContext) {
+func (p *NumScriptParser) ValueAwareSource() (localctx IValueAwareSourceContext) {
 	this := p
 	_ = this
 
-	localctx = NewSourceContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 24, NumScriptParserRULE_source)
+	localctx = NewValueAwareSourceContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 34, NumScriptParserRULE_valueAwareSource)
 
 	defer func() {
 		p.ExitRule()
@@ -3155,206 +5170,120 @@ func (p *NumScriptParser) Source() (localctx ISourceContext) {
 		}
 	}()
 
-	p.SetState(136)
+	p.SetState(209)
 	p.GetErrorHandler().Sync(p)
-
-	switch p.GetTokenStream().LA(1) {
-	case NumScriptParserLBRACK, NumScriptParserSTRING, NumScriptParserNUMBER, NumScriptParserVARIABLE_NAME, NumScriptParserACCOUNT, NumScriptParserASSET:
-		localctx = NewSrcAccountContext(p, localctx)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 15, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewSrcContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(133)
-			p.expression(0)
+			p.SetState(207)
+			p.Source()
 		}
 
-	case NumScriptParserMAX:
-		localctx = NewSrcMaxedContext(p, localctx)
+	case 2:
+		localctx = NewSrcAllotmentContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(134)
-			p.SourceMaxed()
-		}
-
-	case NumScriptParserLBRACE:
-		localctx = NewSrcInOrderContext(p, localctx)
-		p.EnterOuterAlt(localctx, 3)
-		{
-			p.SetState(135)
-			p.SourceInOrder()
+			p.SetState(208)
+			p.SourceAllotment()
 		}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
 }
 
-// ISourceAllotmentContext is an interface to support dynamic dispatch.
-type ISourceAllotmentContext interface {
+// IStatementBlockContext is an interface to support dynamic dispatch.
+type IStatementBlockContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// Get_allotmentPortion returns the _allotmentPortion rule contexts.
-	Get_allotmentPortion() IAllotmentPortionContext
-
-	// Get_source returns the _source rule contexts.
-	Get_source() ISourceContext
-
-	// Set_allotmentPortion sets the _allotmentPortion rule contexts.
-	Set_allotmentPortion(IAllotmentPortionContext)
-
-	// Set_source sets the _source rule contexts.
-	Set_source(ISourceContext)
-
-	// GetPortions returns the portions rule context list.
-	GetPortions() []IAllotmentPortionContext
+	// Get_statement returns the _statement rule contexts.
+	Get_statement() IStatementContext
 
-	// GetSources returns the sources rule context list.
-	GetSources() []ISourceContext
+	// Set_statement sets the _statement rule contexts.
+	Set_statement(IStatementContext)
 
-	// SetPortions sets the portions rule context list.
-	SetPortions([]IAllotmentPortionContext)
+	// GetStmts returns the stmts rule context list.
+	GetStmts() []IStatementContext
 
-	// SetSources sets the sources rule context list.
-	SetSources([]ISourceContext)
+	// SetStmts sets the stmts rule context list.
+	SetStmts([]IStatementContext)
 
-	// IsSourceAllotmentContext differentiates from other interfaces.
-	IsSourceAllotmentContext()
+	// IsStatementBlockContext differentiates from other interfaces.
+	IsStatementBlockContext()
 }
 
-type SourceAllotmentContext struct {
+type StatementBlockContext struct {
 	*antlr.BaseParserRuleContext
-	parser            antlr.Parser
-	_allotmentPortion IAllotmentPortionContext
-	portions          []IAllotmentPortionContext
-	_source           ISourceContext
-	sources           []ISourceContext
+	parser     antlr.Parser
+	_statement IStatementContext
+	stmts      []IStatementContext
 }
 
-func NewEmptySourceAllotmentContext() *SourceAllotmentContext {
-	var p = new(SourceAllotmentContext)
+func NewEmptyStatementBlockContext() *StatementBlockContext {
+	var p = new(StatementBlockContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_sourceAllotment
+	p.RuleIndex = NumScriptParserRULE_statementBlock
 	return p
 }
 
-func (*SourceAllotmentContext) IsSourceAllotmentContext() {}
+func (*StatementBlockContext) IsStatementBlockContext() {}
 
-func NewSourceAllotmentContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceAllotmentContext {
-	var p = new(SourceAllotmentContext)
+func NewStatementBlockContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *StatementBlockContext {
+	var p = new(StatementBlockContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_sourceAllotment
+	p.RuleIndex = NumScriptParserRULE_statementBlock
 
 	return p
 }
 
-func (s *SourceAllotmentContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *SourceAllotmentContext) Get_allotmentPortion() IAllotmentPortionContext {
-	return s._allotmentPortion
-}
-
-func (s *SourceAllotmentContext) Get_source() ISourceContext { return s._source }
-
-func (s *SourceAllotmentContext) Set_allotmentPortion(v IAllotmentPortionContext) {
-	s._allotmentPortion = v
-}
-
-func (s *SourceAllotmentContext) Set_source(v ISourceContext) { s._source = v }
+func (s *StatementBlockContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SourceAllotmentContext) GetPortions() []IAllotmentPortionContext { return s.portions }
+func (s *StatementBlockContext) Get_statement() IStatementContext { return s._statement }
 
-func (s *SourceAllotmentContext) GetSources() []ISourceContext { return s.sources }
+func (s *StatementBlockContext) Set_statement(v IStatementContext) { s._statement = v }
 
-func (s *SourceAllotmentContext) SetPortions(v []IAllotmentPortionContext) { s.portions = v }
+func (s *StatementBlockContext) GetStmts() []IStatementContext { return s.stmts }
 
-func (s *SourceAllotmentContext) SetSources(v []ISourceContext) { s.sources = v }
+func (s *StatementBlockContext) SetStmts(v []IStatementContext) { s.stmts = v }
 
-func (s *SourceAllotmentContext) LBRACE() antlr.TerminalNode {
+func (s *StatementBlockContext) LBRACE() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserLBRACE, 0)
 }
 
-func (s *SourceAllotmentContext) AllNEWLINE() []antlr.TerminalNode {
-	return s.GetTokens(NumScriptParserNEWLINE)
-}
-
-func (s *SourceAllotmentContext) NEWLINE(i int) antlr.TerminalNode {
-	return s.GetToken(NumScriptParserNEWLINE, i)
-}
-
-func (s *SourceAllotmentContext) RBRACE() antlr.TerminalNode {
+func (s *StatementBlockContext) RBRACE() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserRBRACE, 0)
 }
 
-func (s *SourceAllotmentContext) AllFROM() []antlr.TerminalNode {
-	return s.GetTokens(NumScriptParserFROM)
-}
-
-func (s *SourceAllotmentContext) FROM(i int) antlr.TerminalNode {
-	return s.GetToken(NumScriptParserFROM, i)
-}
-
-func (s *SourceAllotmentContext) AllAllotmentPortion() []IAllotmentPortionContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(IAllotmentPortionContext); ok {
-			len++
-		}
-	}
-
-	tst := make([]IAllotmentPortionContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(IAllotmentPortionContext); ok {
-			tst[i] = t.(IAllotmentPortionContext)
-			i++
-		}
-	}
-
-	return tst
+func (s *StatementBlockContext) AllNEWLINE() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserNEWLINE)
 }
 
-func (s *SourceAllotmentContext) AllotmentPortion(i int) IAllotmentPortionContext {
-	var t antlr.RuleContext
-	j := 0
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IAllotmentPortionContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IAllotmentPortionContext)
+func (s *StatementBlockContext) NEWLINE(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserNEWLINE, i)
 }
 
-func (s *SourceAllotmentContext) AllSource() []ISourceContext {
+func (s *StatementBlockContext) AllStatement() []IStatementContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
-		if _, ok := ctx.(ISourceContext); ok {
+		if _, ok := ctx.(IStatementContext); ok {
 			len++
 		}
 	}
 
-	tst := make([]ISourceContext, len)
+	tst := make([]IStatementContext, len)
 	i := 0
 	for _, ctx := range children {
-		if t, ok := ctx.(ISourceContext); ok {
-			tst[i] = t.(ISourceContext)
+		if t, ok := ctx.(IStatementContext); ok {
+			tst[i] = t.(IStatementContext)
 			i++
 		}
 	}
@@ -3362,11 +5291,11 @@ func (s *SourceAllotmentContext) AllSource() []ISourceContext {
 	return tst
 }
 
-func (s *SourceAllotmentContext) Source(i int) ISourceContext {
+func (s *StatementBlockContext) Statement(i int) IStatementContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceContext); ok {
+		if _, ok := ctx.(IStatementContext); ok {
 			if j == i {
 				t = ctx.(antlr.RuleContext)
 				break
@@ -3379,35 +5308,35 @@ func (s *SourceAllotmentContext) Source(i int) ISourceContext {
 		return nil
 	}
 
-	return t.(ISourceContext)
+	return t.(IStatementContext)
 }
 
-func (s *SourceAllotmentContext) GetRuleContext() antlr.RuleContext {
+func (s *StatementBlockContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SourceAllotmentContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *StatementBlockContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SourceAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *StatementBlockContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSourceAllotment(s)
+		listenerT.EnterStatementBlock(s)
 	}
 }
 
-func (s *SourceAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *StatementBlockContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSourceAllotment(s)
+		listenerT.ExitStatementBlock(s)
 	}
 }
 
-func (p *NumScriptParser) SourceAllotment() (localctx ISourceAllotmentContext) {
+func (p *NumScriptParser) StatementBlock() (localctx IStatementBlockContext) {
 	this := p
 	_ = this
 
-	localctx = NewSourceAllotmentContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 26, NumScriptParserRULE_sourceAllotment)
+	localctx = NewStatementBlockContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 36, NumScriptParserRULE_statementBlock)
 	var _la int
 
 	defer func() {
@@ -3428,127 +5357,153 @@ func (p *NumScriptParser) SourceAllotment() (localctx ISourceAllotmentContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(138)
+		p.SetState(211)
 		p.Match(NumScriptParserLBRACE)
 	}
-	{
-		p.SetState(139)
-		p.Match(NumScriptParserNEWLINE)
-	}
-	p.SetState(145)
+	p.SetState(213)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for ok := true; ok; ok = (((_la-35)&-(0x1f+1)) == 0 && ((1<<uint((_la-35)))&((1<<(NumScriptParserPORTION-35))|(1<<(NumScriptParserREMAINING-35))|(1<<(NumScriptParserVARIABLE_NAME-35)))) != 0) {
+	for ok := true; ok; ok = _la == NumScriptParserNEWLINE {
 		{
-			p.SetState(140)
+			p.SetState(212)
+			p.Match(NumScriptParserNEWLINE)
+		}
 
-			var _x = p.AllotmentPortion()
+		p.SetState(215)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	p.SetState(225)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-			localctx.(*SourceAllotmentContext)._allotmentPortion = _x
-		}
-		localctx.(*SourceAllotmentContext).portions = append(localctx.(*SourceAllotmentContext).portions, localctx.(*SourceAllotmentContext)._allotmentPortion)
+	for ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<NumScriptParserSET_TX_META)|(1<<NumScriptParserSET_ACCOUNT_META)|(1<<NumScriptParserPRINT)|(1<<NumScriptParserFAIL)|(1<<NumScriptParserASSERT)|(1<<NumScriptParserIF)|(1<<NumScriptParserSEND))) != 0 {
 		{
-			p.SetState(141)
-			p.Match(NumScriptParserFROM)
-		}
-		{
-			p.SetState(142)
+			p.SetState(217)
 
-			var _x = p.Source()
+			var _x = p.Statement()
 
-			localctx.(*SourceAllotmentContext)._source = _x
+			localctx.(*StatementBlockContext)._statement = _x
 		}
-		localctx.(*SourceAllotmentContext).sources = append(localctx.(*SourceAllotmentContext).sources, localctx.(*SourceAllotmentContext)._source)
-		{
-			p.SetState(143)
-			p.Match(NumScriptParserNEWLINE)
+		localctx.(*StatementBlockContext).stmts = append(localctx.(*StatementBlockContext).stmts, localctx.(*StatementBlockContext)._statement)
+		p.SetState(219)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		for ok := true; ok; ok = _la == NumScriptParserNEWLINE {
+			{
+				p.SetState(218)
+				p.Match(NumScriptParserNEWLINE)
+			}
+
+			p.SetState(221)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
 		}
 
-		p.SetState(147)
+		p.SetState(227)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(149)
+		p.SetState(228)
 		p.Match(NumScriptParserRBRACE)
 	}
 
 	return localctx
 }
 
-// IValueAwareSourceContext is an interface to support dynamic dispatch.
-type IValueAwareSourceContext interface {
+// IIfStatementContext is an interface to support dynamic dispatch.
+type IIfStatementContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsValueAwareSourceContext differentiates from other interfaces.
-	IsValueAwareSourceContext()
+	// GetCond returns the cond rule contexts.
+	GetCond() IExpressionContext
+
+	// GetThen returns the then rule contexts.
+	GetThen() IStatementBlockContext
+
+	// GetOtherwise returns the otherwise rule contexts.
+	GetOtherwise() IStatementBlockContext
+
+	// GetElseIf returns the elseIf rule contexts.
+	GetElseIf() IIfStatementContext
+
+	// SetCond sets the cond rule contexts.
+	SetCond(IExpressionContext)
+
+	// SetThen sets the then rule contexts.
+	SetThen(IStatementBlockContext)
+
+	// SetOtherwise sets the otherwise rule contexts.
+	SetOtherwise(IStatementBlockContext)
+
+	// SetElseIf sets the elseIf rule contexts.
+	SetElseIf(IIfStatementContext)
+
+	// IsIfStatementContext differentiates from other interfaces.
+	IsIfStatementContext()
 }
 
-type ValueAwareSourceContext struct {
+type IfStatementContext struct {
 	*antlr.BaseParserRuleContext
-	parser antlr.Parser
+	parser    antlr.Parser
+	cond      IExpressionContext
+	then      IStatementBlockContext
+	otherwise IStatementBlockContext
+	elseIf    IIfStatementContext
 }
 
-func NewEmptyValueAwareSourceContext() *ValueAwareSourceContext {
-	var p = new(ValueAwareSourceContext)
+func NewEmptyIfStatementContext() *IfStatementContext {
+	var p = new(IfStatementContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_valueAwareSource
+	p.RuleIndex = NumScriptParserRULE_ifStatement
 	return p
 }
 
-func (*ValueAwareSourceContext) IsValueAwareSourceContext() {}
+func (*IfStatementContext) IsIfStatementContext() {}
 
-func NewValueAwareSourceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ValueAwareSourceContext {
-	var p = new(ValueAwareSourceContext)
+func NewIfStatementContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *IfStatementContext {
+	var p = new(IfStatementContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_valueAwareSource
+	p.RuleIndex = NumScriptParserRULE_ifStatement
 
 	return p
 }
 
-func (s *ValueAwareSourceContext) GetParser() antlr.Parser { return s.parser }
+func (s *IfStatementContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ValueAwareSourceContext) CopyFrom(ctx *ValueAwareSourceContext) {
-	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
-}
+func (s *IfStatementContext) GetCond() IExpressionContext { return s.cond }
 
-func (s *ValueAwareSourceContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+func (s *IfStatementContext) GetThen() IStatementBlockContext { return s.then }
 
-func (s *ValueAwareSourceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
-}
+func (s *IfStatementContext) GetOtherwise() IStatementBlockContext { return s.otherwise }
 
-type SrcContext struct {
-	*ValueAwareSourceContext
-}
+func (s *IfStatementContext) GetElseIf() IIfStatementContext { return s.elseIf }
 
-func NewSrcContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcContext {
-	var p = new(SrcContext)
+func (s *IfStatementContext) SetCond(v IExpressionContext) { s.cond = v }
 
-	p.ValueAwareSourceContext = NewEmptyValueAwareSourceContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*ValueAwareSourceContext))
+func (s *IfStatementContext) SetThen(v IStatementBlockContext) { s.then = v }
 
-	return p
-}
+func (s *IfStatementContext) SetOtherwise(v IStatementBlockContext) { s.otherwise = v }
 
-func (s *SrcContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *IfStatementContext) SetElseIf(v IIfStatementContext) { s.elseIf = v }
+
+func (s *IfStatementContext) IF() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserIF, 0)
 }
 
-func (s *SrcContext) Source() ISourceContext {
+func (s *IfStatementContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceContext); ok {
+		if _, ok := ctx.(IExpressionContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -3558,43 +5513,58 @@ func (s *SrcContext) Source() ISourceContext {
 		return nil
 	}
 
-	return t.(ISourceContext)
+	return t.(IExpressionContext)
 }
 
-func (s *SrcContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSrc(s)
+func (s *IfStatementContext) AllStatementBlock() []IStatementBlockContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IStatementBlockContext); ok {
+			len++
+		}
 	}
-}
 
-func (s *SrcContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSrc(s)
+	tst := make([]IStatementBlockContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IStatementBlockContext); ok {
+			tst[i] = t.(IStatementBlockContext)
+			i++
+		}
 	}
-}
 
-type SrcAllotmentContext struct {
-	*ValueAwareSourceContext
+	return tst
 }
 
-func NewSrcAllotmentContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAllotmentContext {
-	var p = new(SrcAllotmentContext)
+func (s *IfStatementContext) StatementBlock(i int) IStatementBlockContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IStatementBlockContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
 
-	p.ValueAwareSourceContext = NewEmptyValueAwareSourceContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*ValueAwareSourceContext))
+	if t == nil {
+		return nil
+	}
 
-	return p
+	return t.(IStatementBlockContext)
 }
 
-func (s *SrcAllotmentContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *IfStatementContext) ELSE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserELSE, 0)
 }
 
-func (s *SrcAllotmentContext) SourceAllotment() ISourceAllotmentContext {
+func (s *IfStatementContext) IfStatement() IIfStatementContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceAllotmentContext); ok {
+		if _, ok := ctx.(IIfStatementContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -3604,27 +5574,36 @@ func (s *SrcAllotmentContext) SourceAllotment() ISourceAllotmentContext {
 		return nil
 	}
 
-	return t.(ISourceAllotmentContext)
+	return t.(IIfStatementContext)
 }
 
-func (s *SrcAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *IfStatementContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IfStatementContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *IfStatementContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSrcAllotment(s)
+		listenerT.EnterIfStatement(s)
 	}
 }
 
-func (s *SrcAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *IfStatementContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSrcAllotment(s)
+		listenerT.ExitIfStatement(s)
 	}
 }
 
-func (p *NumScriptParser) ValueAwareSource() (localctx IValueAwareSourceContext) {
+func (p *NumScriptParser) IfStatement() (localctx IIfStatementContext) {
 	this := p
 	_ = this
 
-	localctx = NewValueAwareSourceContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 28, NumScriptParserRULE_valueAwareSource)
+	localctx = NewIfStatementContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 38, NumScriptParserRULE_ifStatement)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -3642,23 +5621,58 @@ func (p *NumScriptParser) ValueAwareSource() (localctx IValueAwareSourceContext)
 		}
 	}()
 
-	p.SetState(153)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(230)
+		p.Match(NumScriptParserIF)
+	}
+	{
+		p.SetState(231)
+
+		var _x = p.expression(0)
+
+		localctx.(*IfStatementContext).cond = _x
+	}
+	{
+		p.SetState(232)
+
+		var _x = p.StatementBlock()
+
+		localctx.(*IfStatementContext).then = _x
+	}
+	p.SetState(238)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 11, p.GetParserRuleContext()) {
-	case 1:
-		localctx = NewSrcContext(p, localctx)
-		p.EnterOuterAlt(localctx, 1)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == NumScriptParserELSE {
 		{
-			p.SetState(151)
-			p.Source()
+			p.SetState(233)
+			p.Match(NumScriptParserELSE)
 		}
+		p.SetState(236)
+		p.GetErrorHandler().Sync(p)
 
-	case 2:
-		localctx = NewSrcAllotmentContext(p, localctx)
-		p.EnterOuterAlt(localctx, 2)
-		{
-			p.SetState(152)
-			p.SourceAllotment()
+		switch p.GetTokenStream().LA(1) {
+		case NumScriptParserLBRACE:
+			{
+				p.SetState(234)
+
+				var _x = p.StatementBlock()
+
+				localctx.(*IfStatementContext).otherwise = _x
+			}
+
+		case NumScriptParserIF:
+			{
+				p.SetState(235)
+
+				var _x = p.IfStatement()
+
+				localctx.(*IfStatementContext).elseIf = _x
+			}
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
 
 	}
@@ -3771,6 +5785,70 @@ func (s *PrintContext) ExitRule(listener antlr.ParseTreeListener) {
 	}
 }
 
+type AssertContext struct {
+	*StatementContext
+	cond   IExpressionContext
+	reason antlr.Token
+}
+
+func NewAssertContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AssertContext {
+	var p = new(AssertContext)
+
+	p.StatementContext = NewEmptyStatementContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatementContext))
+
+	return p
+}
+
+func (s *AssertContext) GetReason() antlr.Token { return s.reason }
+
+func (s *AssertContext) SetReason(v antlr.Token) { s.reason = v }
+
+func (s *AssertContext) GetCond() IExpressionContext { return s.cond }
+
+func (s *AssertContext) SetCond(v IExpressionContext) { s.cond = v }
+
+func (s *AssertContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AssertContext) ASSERT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserASSERT, 0)
+}
+
+func (s *AssertContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *AssertContext) STRING() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSTRING, 0)
+}
+
+func (s *AssertContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterAssert(s)
+	}
+}
+
+func (s *AssertContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitAssert(s)
+	}
+}
+
 type SetTxMetaContext struct {
 	*StatementContext
 	key   antlr.Token
@@ -3789,38 +5867,187 @@ func NewSetTxMetaContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SetT
 
 func (s *SetTxMetaContext) GetKey() antlr.Token { return s.key }
 
-func (s *SetTxMetaContext) SetKey(v antlr.Token) { s.key = v }
+func (s *SetTxMetaContext) SetKey(v antlr.Token) { s.key = v }
+
+func (s *SetTxMetaContext) GetValue() IExpressionContext { return s.value }
+
+func (s *SetTxMetaContext) SetValue(v IExpressionContext) { s.value = v }
+
+func (s *SetTxMetaContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SetTxMetaContext) SET_TX_META() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSET_TX_META, 0)
+}
+
+func (s *SetTxMetaContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLPAREN, 0)
+}
+
+func (s *SetTxMetaContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRPAREN, 0)
+}
+
+func (s *SetTxMetaContext) STRING() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSTRING, 0)
+}
+
+func (s *SetTxMetaContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *SetTxMetaContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterSetTxMeta(s)
+	}
+}
+
+func (s *SetTxMetaContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitSetTxMeta(s)
+	}
+}
+
+type IfContext struct {
+	*StatementContext
+	stmt IIfStatementContext
+}
+
+func NewIfContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IfContext {
+	var p = new(IfContext)
+
+	p.StatementContext = NewEmptyStatementContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatementContext))
+
+	return p
+}
+
+func (s *IfContext) GetStmt() IIfStatementContext { return s.stmt }
+
+func (s *IfContext) SetStmt(v IIfStatementContext) { s.stmt = v }
+
+func (s *IfContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IfContext) IfStatement() IIfStatementContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIfStatementContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIfStatementContext)
+}
+
+func (s *IfContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterIf(s)
+	}
+}
+
+func (s *IfContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitIf(s)
+	}
+}
+
+type SetAccountMetaContext struct {
+	*StatementContext
+	acc   IExpressionContext
+	key   antlr.Token
+	value IExpressionContext
+}
+
+func NewSetAccountMetaContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SetAccountMetaContext {
+	var p = new(SetAccountMetaContext)
+
+	p.StatementContext = NewEmptyStatementContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatementContext))
+
+	return p
+}
+
+func (s *SetAccountMetaContext) GetKey() antlr.Token { return s.key }
+
+func (s *SetAccountMetaContext) SetKey(v antlr.Token) { s.key = v }
+
+func (s *SetAccountMetaContext) GetAcc() IExpressionContext { return s.acc }
+
+func (s *SetAccountMetaContext) GetValue() IExpressionContext { return s.value }
 
-func (s *SetTxMetaContext) GetValue() IExpressionContext { return s.value }
+func (s *SetAccountMetaContext) SetAcc(v IExpressionContext) { s.acc = v }
 
-func (s *SetTxMetaContext) SetValue(v IExpressionContext) { s.value = v }
+func (s *SetAccountMetaContext) SetValue(v IExpressionContext) { s.value = v }
 
-func (s *SetTxMetaContext) GetRuleContext() antlr.RuleContext {
+func (s *SetAccountMetaContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SetTxMetaContext) SET_TX_META() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserSET_TX_META, 0)
+func (s *SetAccountMetaContext) SET_ACCOUNT_META() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSET_ACCOUNT_META, 0)
 }
 
-func (s *SetTxMetaContext) LPAREN() antlr.TerminalNode {
+func (s *SetAccountMetaContext) LPAREN() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserLPAREN, 0)
 }
 
-func (s *SetTxMetaContext) RPAREN() antlr.TerminalNode {
+func (s *SetAccountMetaContext) RPAREN() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserRPAREN, 0)
 }
 
-func (s *SetTxMetaContext) STRING() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserSTRING, 0)
+func (s *SetAccountMetaContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
 }
 
-func (s *SetTxMetaContext) Expression() IExpressionContext {
+func (s *SetAccountMetaContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -3831,20 +6058,25 @@ func (s *SetTxMetaContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *SetTxMetaContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SetAccountMetaContext) STRING() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSTRING, 0)
+}
+
+func (s *SetAccountMetaContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSetTxMeta(s)
+		listenerT.EnterSetAccountMeta(s)
 	}
 }
 
-func (s *SetTxMetaContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SetAccountMetaContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSetTxMeta(s)
+		listenerT.ExitSetAccountMeta(s)
 	}
 }
 
 type FailContext struct {
 	*StatementContext
+	reason antlr.Token
 }
 
 func NewFailContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *FailContext {
@@ -3857,6 +6089,10 @@ func NewFailContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *FailConte
 	return p
 }
 
+func (s *FailContext) GetReason() antlr.Token { return s.reason }
+
+func (s *FailContext) SetReason(v antlr.Token) { s.reason = v }
+
 func (s *FailContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -3865,6 +6101,10 @@ func (s *FailContext) FAIL() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserFAIL, 0)
 }
 
+func (s *FailContext) STRING() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSTRING, 0)
+}
+
 func (s *FailContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
 		listenerT.EnterFail(s)
@@ -4032,7 +6272,8 @@ func (p *NumScriptParser) Statement() (localctx IStatementContext) {
 	_ = this
 
 	localctx = NewStatementContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 30, NumScriptParserRULE_statement)
+	p.EnterRule(localctx, 40, NumScriptParserRULE_statement)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -4050,7 +6291,7 @@ func (p *NumScriptParser) Statement() (localctx IStatementContext) {
 		}
 	}()
 
-	p.SetState(193)
+	p.SetState(296)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
@@ -4058,11 +6299,11 @@ func (p *NumScriptParser) Statement() (localctx IStatementContext) {
 		localctx = NewPrintContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(155)
+			p.SetState(240)
 			p.Match(NumScriptParserPRINT)
 		}
 		{
-			p.SetState(156)
+			p.SetState(241)
 
 			var _x = p.expression(0)
 
@@ -4073,57 +6314,142 @@ func (p *NumScriptParser) Statement() (localctx IStatementContext) {
 		localctx = NewSetTxMetaContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(157)
+			p.SetState(242)
 			p.Match(NumScriptParserSET_TX_META)
 		}
 		{
-			p.SetState(158)
+			p.SetState(243)
 			p.Match(NumScriptParserLPAREN)
 		}
 		{
-			p.SetState(159)
+			p.SetState(244)
 
 			var _m = p.Match(NumScriptParserSTRING)
 
 			localctx.(*SetTxMetaContext).key = _m
 		}
 		{
-			p.SetState(160)
-			p.Match(NumScriptParserT__1)
+			p.SetState(245)
+			p.Match(NumScriptParserT__0)
 		}
 		{
-			p.SetState(161)
+			p.SetState(246)
 
 			var _x = p.expression(0)
 
 			localctx.(*SetTxMetaContext).value = _x
 		}
 		{
-			p.SetState(162)
+			p.SetState(247)
+			p.Match(NumScriptParserRPAREN)
+		}
+
+	case NumScriptParserSET_ACCOUNT_META:
+		localctx = NewSetAccountMetaContext(p, localctx)
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(249)
+			p.Match(NumScriptParserSET_ACCOUNT_META)
+		}
+		{
+			p.SetState(250)
+			p.Match(NumScriptParserLPAREN)
+		}
+		{
+			p.SetState(251)
+
+			var _x = p.expression(0)
+
+			localctx.(*SetAccountMetaContext).acc = _x
+		}
+		{
+			p.SetState(252)
+			p.Match(NumScriptParserT__0)
+		}
+		{
+			p.SetState(253)
+
+			var _m = p.Match(NumScriptParserSTRING)
+
+			localctx.(*SetAccountMetaContext).key = _m
+		}
+		{
+			p.SetState(254)
+			p.Match(NumScriptParserT__0)
+		}
+		{
+			p.SetState(255)
+
+			var _x = p.expression(0)
+
+			localctx.(*SetAccountMetaContext).value = _x
+		}
+		{
+			p.SetState(256)
 			p.Match(NumScriptParserRPAREN)
 		}
 
 	case NumScriptParserFAIL:
 		localctx = NewFailContext(p, localctx)
-		p.EnterOuterAlt(localctx, 3)
+		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(164)
+			p.SetState(258)
 			p.Match(NumScriptParserFAIL)
 		}
+		p.SetState(260)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == NumScriptParserSTRING {
+			{
+				p.SetState(259)
+
+				var _m = p.Match(NumScriptParserSTRING)
+
+				localctx.(*FailContext).reason = _m
+			}
+
+		}
+
+	case NumScriptParserASSERT:
+		localctx = NewAssertContext(p, localctx)
+		p.EnterOuterAlt(localctx, 5)
+		{
+			p.SetState(262)
+			p.Match(NumScriptParserASSERT)
+		}
+		{
+			p.SetState(263)
+
+			var _x = p.expression(0)
+
+			localctx.(*AssertContext).cond = _x
+		}
+		{
+			p.SetState(264)
+			p.Match(NumScriptParserT__0)
+		}
+		{
+			p.SetState(265)
+
+			var _m = p.Match(NumScriptParserSTRING)
+
+			localctx.(*AssertContext).reason = _m
+		}
 
 	case NumScriptParserSEND:
 		localctx = NewSendContext(p, localctx)
-		p.EnterOuterAlt(localctx, 4)
+		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(165)
+			p.SetState(267)
 			p.Match(NumScriptParserSEND)
 		}
-		p.SetState(168)
+		p.SetState(270)
 		p.GetErrorHandler().Sync(p)
-		switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 12, p.GetParserRuleContext()) {
+		switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 22, p.GetParserRuleContext()) {
 		case 1:
 			{
-				p.SetState(166)
+				p.SetState(268)
 
 				var _x = p.expression(0)
 
@@ -4132,7 +6458,7 @@ func (p *NumScriptParser) Statement() (localctx IStatementContext) {
 
 		case 2:
 			{
-				p.SetState(167)
+				p.SetState(269)
 
 				var _x = p.MonetaryAll()
 
@@ -4141,47 +6467,47 @@ func (p *NumScriptParser) Statement() (localctx IStatementContext) {
 
 		}
 		{
-			p.SetState(170)
+			p.SetState(272)
 			p.Match(NumScriptParserLPAREN)
 		}
 		{
-			p.SetState(171)
+			p.SetState(273)
 			p.Match(NumScriptParserNEWLINE)
 		}
-		p.SetState(188)
+		p.SetState(290)
 		p.GetErrorHandler().Sync(p)
 
 		switch p.GetTokenStream().LA(1) {
 		case NumScriptParserSOURCE:
 			{
-				p.SetState(172)
+				p.SetState(274)
 				p.Match(NumScriptParserSOURCE)
 			}
 			{
-				p.SetState(173)
+				p.SetState(275)
 				p.Match(NumScriptParserEQ)
 			}
 			{
-				p.SetState(174)
+				p.SetState(276)
 
 				var _x = p.ValueAwareSource()
 
 				localctx.(*SendContext).src = _x
 			}
 			{
-				p.SetState(175)
+				p.SetState(277)
 				p.Match(NumScriptParserNEWLINE)
 			}
 			{
-				p.SetState(176)
+				p.SetState(278)
 				p.Match(NumScriptParserDESTINATION)
 			}
 			{
-				p.SetState(177)
+				p.SetState(279)
 				p.Match(NumScriptParserEQ)
 			}
 			{
-				p.SetState(178)
+				p.SetState(280)
 
 				var _x = p.Destination()
 
@@ -4190,34 +6516,34 @@ func (p *NumScriptParser) Statement() (localctx IStatementContext) {
 
 		case NumScriptParserDESTINATION:
 			{
-				p.SetState(180)
+				p.SetState(282)
 				p.Match(NumScriptParserDESTINATION)
 			}
 			{
-				p.SetState(181)
+				p.SetState(283)
 				p.Match(NumScriptParserEQ)
 			}
 			{
-				p.SetState(182)
+				p.SetState(284)
 
 				var _x = p.Destination()
 
 				localctx.(*SendContext).dest = _x
 			}
 			{
-				p.SetState(183)
+				p.SetState(285)
 				p.Match(NumScriptParserNEWLINE)
 			}
 			{
-				p.SetState(184)
+				p.SetState(286)
 				p.Match(NumScriptParserSOURCE)
 			}
 			{
-				p.SetState(185)
+				p.SetState(287)
 				p.Match(NumScriptParserEQ)
 			}
 			{
-				p.SetState(186)
+				p.SetState(288)
 
 				var _x = p.ValueAwareSource()
 
@@ -4228,14 +6554,25 @@ func (p *NumScriptParser) Statement() (localctx IStatementContext) {
 			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
 		{
-			p.SetState(190)
+			p.SetState(292)
 			p.Match(NumScriptParserNEWLINE)
 		}
 		{
-			p.SetState(191)
+			p.SetState(293)
 			p.Match(NumScriptParserRPAREN)
 		}
 
+	case NumScriptParserIF:
+		localctx = NewIfContext(p, localctx)
+		p.EnterOuterAlt(localctx, 7)
+		{
+			p.SetState(295)
+
+			var _x = p.IfStatement()
+
+			localctx.(*IfContext).stmt = _x
+		}
+
 	default:
 		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
@@ -4305,6 +6642,10 @@ func (s *Type_Context) TY_PORTION() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserTY_PORTION, 0)
 }
 
+func (s *Type_Context) TY_BOOL() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserTY_BOOL, 0)
+}
+
 func (s *Type_Context) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -4330,7 +6671,7 @@ func (p *NumScriptParser) Type_() (localctx IType_Context) {
 	_ = this
 
 	localctx = NewType_Context(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 32, NumScriptParserRULE_type_)
+	p.EnterRule(localctx, 42, NumScriptParserRULE_type_)
 	var _la int
 
 	defer func() {
@@ -4351,10 +6692,10 @@ func (p *NumScriptParser) Type_() (localctx IType_Context) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(195)
+		p.SetState(298)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((_la-28)&-(0x1f+1)) == 0 && ((1<<uint((_la-28)))&((1<<(NumScriptParserTY_ACCOUNT-28))|(1<<(NumScriptParserTY_ASSET-28))|(1<<(NumScriptParserTY_NUMBER-28))|(1<<(NumScriptParserTY_MONETARY-28))|(1<<(NumScriptParserTY_PORTION-28))|(1<<(NumScriptParserTY_STRING-28)))) != 0) {
+		if !(((_la-42)&-(0x1f+1)) == 0 && ((1<<uint((_la-42)))&((1<<(NumScriptParserTY_ACCOUNT-42))|(1<<(NumScriptParserTY_ASSET-42))|(1<<(NumScriptParserTY_NUMBER-42))|(1<<(NumScriptParserTY_MONETARY-42))|(1<<(NumScriptParserTY_PORTION-42))|(1<<(NumScriptParserTY_STRING-42))|(1<<(NumScriptParserTY_BOOL-42)))) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -4372,18 +6713,6 @@ type IOriginContext interface {
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// GetKey returns the key token.
-	GetKey() antlr.Token
-
-	// SetKey sets the key token.
-	SetKey(antlr.Token)
-
-	// GetAcc returns the acc rule contexts.
-	GetAcc() IExpressionContext
-
-	// SetAcc sets the acc rule contexts.
-	SetAcc(IExpressionContext)
-
 	// IsOriginContext differentiates from other interfaces.
 	IsOriginContext()
 }
@@ -4391,8 +6720,6 @@ type IOriginContext interface {
 type OriginContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
-	acc    IExpressionContext
-	key    antlr.Token
 }
 
 func NewEmptyOriginContext() *OriginContext {
@@ -4417,27 +6744,110 @@ func NewOriginContext(parser antlr.Parser, parent antlr.ParserRuleContext, invok
 
 func (s *OriginContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *OriginContext) GetKey() antlr.Token { return s.key }
+func (s *OriginContext) CopyFrom(ctx *OriginContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+}
+
+func (s *OriginContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *OriginContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type OriginAccountBalanceContext struct {
+	*OriginContext
+	bal IBalanceContext
+}
+
+func NewOriginAccountBalanceContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *OriginAccountBalanceContext {
+	var p = new(OriginAccountBalanceContext)
+
+	p.OriginContext = NewEmptyOriginContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*OriginContext))
+
+	return p
+}
+
+func (s *OriginAccountBalanceContext) GetBal() IBalanceContext { return s.bal }
+
+func (s *OriginAccountBalanceContext) SetBal(v IBalanceContext) { s.bal = v }
+
+func (s *OriginAccountBalanceContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *OriginAccountBalanceContext) Balance() IBalanceContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IBalanceContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IBalanceContext)
+}
+
+func (s *OriginAccountBalanceContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterOriginAccountBalance(s)
+	}
+}
+
+func (s *OriginAccountBalanceContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitOriginAccountBalance(s)
+	}
+}
+
+type OriginAccountMetaContext struct {
+	*OriginContext
+	acc IExpressionContext
+	key antlr.Token
+}
+
+func NewOriginAccountMetaContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *OriginAccountMetaContext {
+	var p = new(OriginAccountMetaContext)
+
+	p.OriginContext = NewEmptyOriginContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*OriginContext))
+
+	return p
+}
+
+func (s *OriginAccountMetaContext) GetKey() antlr.Token { return s.key }
+
+func (s *OriginAccountMetaContext) SetKey(v antlr.Token) { s.key = v }
 
-func (s *OriginContext) SetKey(v antlr.Token) { s.key = v }
+func (s *OriginAccountMetaContext) GetAcc() IExpressionContext { return s.acc }
 
-func (s *OriginContext) GetAcc() IExpressionContext { return s.acc }
+func (s *OriginAccountMetaContext) SetAcc(v IExpressionContext) { s.acc = v }
 
-func (s *OriginContext) SetAcc(v IExpressionContext) { s.acc = v }
+func (s *OriginAccountMetaContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-func (s *OriginContext) META() antlr.TerminalNode {
+func (s *OriginAccountMetaContext) META() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserMETA, 0)
 }
 
-func (s *OriginContext) LPAREN() antlr.TerminalNode {
+func (s *OriginAccountMetaContext) LPAREN() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserLPAREN, 0)
 }
 
-func (s *OriginContext) RPAREN() antlr.TerminalNode {
+func (s *OriginAccountMetaContext) RPAREN() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserRPAREN, 0)
 }
 
-func (s *OriginContext) Expression() IExpressionContext {
+func (s *OriginAccountMetaContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
@@ -4453,27 +6863,19 @@ func (s *OriginContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *OriginContext) STRING() antlr.TerminalNode {
+func (s *OriginAccountMetaContext) STRING() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserSTRING, 0)
 }
 
-func (s *OriginContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *OriginContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
-}
-
-func (s *OriginContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *OriginAccountMetaContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterOrigin(s)
+		listenerT.EnterOriginAccountMeta(s)
 	}
 }
 
-func (s *OriginContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *OriginAccountMetaContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitOrigin(s)
+		listenerT.ExitOriginAccountMeta(s)
 	}
 }
 
@@ -4482,7 +6884,7 @@ func (p *NumScriptParser) Origin() (localctx IOriginContext) {
 	_ = this
 
 	localctx = NewOriginContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 34, NumScriptParserRULE_origin)
+	p.EnterRule(localctx, 44, NumScriptParserRULE_origin)
 
 	defer func() {
 		p.ExitRule()
@@ -4500,36 +6902,57 @@ func (p *NumScriptParser) Origin() (localctx IOriginContext) {
 		}
 	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(197)
-		p.Match(NumScriptParserMETA)
-	}
-	{
-		p.SetState(198)
-		p.Match(NumScriptParserLPAREN)
-	}
-	{
-		p.SetState(199)
+	p.SetState(308)
+	p.GetErrorHandler().Sync(p)
 
-		var _x = p.expression(0)
+	switch p.GetTokenStream().LA(1) {
+	case NumScriptParserMETA:
+		localctx = NewOriginAccountMetaContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(300)
+			p.Match(NumScriptParserMETA)
+		}
+		{
+			p.SetState(301)
+			p.Match(NumScriptParserLPAREN)
+		}
+		{
+			p.SetState(302)
 
-		localctx.(*OriginContext).acc = _x
-	}
-	{
-		p.SetState(200)
-		p.Match(NumScriptParserT__1)
-	}
-	{
-		p.SetState(201)
+			var _x = p.expression(0)
 
-		var _m = p.Match(NumScriptParserSTRING)
+			localctx.(*OriginAccountMetaContext).acc = _x
+		}
+		{
+			p.SetState(303)
+			p.Match(NumScriptParserT__0)
+		}
+		{
+			p.SetState(304)
 
-		localctx.(*OriginContext).key = _m
-	}
-	{
-		p.SetState(202)
-		p.Match(NumScriptParserRPAREN)
+			var _m = p.Match(NumScriptParserSTRING)
+
+			localctx.(*OriginAccountMetaContext).key = _m
+		}
+		{
+			p.SetState(305)
+			p.Match(NumScriptParserRPAREN)
+		}
+
+	case NumScriptParserBALANCE:
+		localctx = NewOriginAccountBalanceContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(307)
+
+			var _x = p.Balance()
+
+			localctx.(*OriginAccountBalanceContext).bal = _x
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
@@ -4683,7 +7106,7 @@ func (p *NumScriptParser) VarDecl() (localctx IVarDeclContext) {
 	_ = this
 
 	localctx = NewVarDeclContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 36, NumScriptParserRULE_varDecl)
+	p.EnterRule(localctx, 46, NumScriptParserRULE_varDecl)
 	var _la int
 
 	defer func() {
@@ -4704,30 +7127,30 @@ func (p *NumScriptParser) VarDecl() (localctx IVarDeclContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(204)
+		p.SetState(310)
 
 		var _x = p.Type_()
 
 		localctx.(*VarDeclContext).ty = _x
 	}
 	{
-		p.SetState(205)
+		p.SetState(311)
 
 		var _x = p.Variable()
 
 		localctx.(*VarDeclContext).name = _x
 	}
-	p.SetState(208)
+	p.SetState(314)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == NumScriptParserEQ {
 		{
-			p.SetState(206)
+			p.SetState(312)
 			p.Match(NumScriptParserEQ)
 		}
 		{
-			p.SetState(207)
+			p.SetState(313)
 
 			var _x = p.Origin()
 
@@ -4885,7 +7308,7 @@ func (p *NumScriptParser) VarListDecl() (localctx IVarListDeclContext) {
 	_ = this
 
 	localctx = NewVarListDeclContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 38, NumScriptParserRULE_varListDecl)
+	p.EnterRule(localctx, 48, NumScriptParserRULE_varListDecl)
 	var _la int
 
 	defer func() {
@@ -4906,55 +7329,55 @@ func (p *NumScriptParser) VarListDecl() (localctx IVarListDeclContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(210)
+		p.SetState(316)
 		p.Match(NumScriptParserVARS)
 	}
 	{
-		p.SetState(211)
+		p.SetState(317)
 		p.Match(NumScriptParserLBRACE)
 	}
 	{
-		p.SetState(212)
+		p.SetState(318)
 		p.Match(NumScriptParserNEWLINE)
 	}
-	p.SetState(219)
+	p.SetState(325)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for ok := true; ok; ok = (((_la-28)&-(0x1f+1)) == 0 && ((1<<uint((_la-28)))&((1<<(NumScriptParserTY_ACCOUNT-28))|(1<<(NumScriptParserTY_ASSET-28))|(1<<(NumScriptParserTY_NUMBER-28))|(1<<(NumScriptParserTY_MONETARY-28))|(1<<(NumScriptParserTY_PORTION-28))|(1<<(NumScriptParserTY_STRING-28)))) != 0) {
+	for ok := true; ok; ok = (((_la-42)&-(0x1f+1)) == 0 && ((1<<uint((_la-42)))&((1<<(NumScriptParserTY_ACCOUNT-42))|(1<<(NumScriptParserTY_ASSET-42))|(1<<(NumScriptParserTY_NUMBER-42))|(1<<(NumScriptParserTY_MONETARY-42))|(1<<(NumScriptParserTY_PORTION-42))|(1<<(NumScriptParserTY_STRING-42))|(1<<(NumScriptParserTY_BOOL-42)))) != 0) {
 		{
-			p.SetState(213)
+			p.SetState(319)
 
 			var _x = p.VarDecl()
 
 			localctx.(*VarListDeclContext)._varDecl = _x
 		}
 		localctx.(*VarListDeclContext).v = append(localctx.(*VarListDeclContext).v, localctx.(*VarListDeclContext)._varDecl)
-		p.SetState(215)
+		p.SetState(321)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 
 		for ok := true; ok; ok = _la == NumScriptParserNEWLINE {
 			{
-				p.SetState(214)
+				p.SetState(320)
 				p.Match(NumScriptParserNEWLINE)
 			}
 
-			p.SetState(217)
+			p.SetState(323)
 			p.GetErrorHandler().Sync(p)
 			_la = p.GetTokenStream().LA(1)
 		}
 
-		p.SetState(221)
+		p.SetState(327)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(223)
+		p.SetState(329)
 		p.Match(NumScriptParserRBRACE)
 	}
 	{
-		p.SetState(224)
+		p.SetState(330)
 		p.Match(NumScriptParserNEWLINE)
 	}
 
@@ -5126,7 +7549,7 @@ func (p *NumScriptParser) Script() (localctx IScriptContext) {
 	_ = this
 
 	localctx = NewScriptContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 40, NumScriptParserRULE_script)
+	p.EnterRule(localctx, 50, NumScriptParserRULE_script)
 	var _la int
 
 	defer func() {
@@ -5148,27 +7571,27 @@ func (p *NumScriptParser) Script() (localctx IScriptContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(229)
+	p.SetState(335)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == NumScriptParserNEWLINE {
 		{
-			p.SetState(226)
+			p.SetState(332)
 			p.Match(NumScriptParserNEWLINE)
 		}
 
-		p.SetState(231)
+		p.SetState(337)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
-	p.SetState(233)
+	p.SetState(339)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == NumScriptParserVARS {
 		{
-			p.SetState(232)
+			p.SetState(338)
 
 			var _x = p.VarListDecl()
 
@@ -5177,25 +7600,25 @@ func (p *NumScriptParser) Script() (localctx IScriptContext) {
 
 	}
 	{
-		p.SetState(235)
+		p.SetState(341)
 
 		var _x = p.Statement()
 
 		localctx.(*ScriptContext)._statement = _x
 	}
 	localctx.(*ScriptContext).stmts = append(localctx.(*ScriptContext).stmts, localctx.(*ScriptContext)._statement)
-	p.SetState(240)
+	p.SetState(346)
 	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 20, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 31, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
 			{
-				p.SetState(236)
+				p.SetState(342)
 				p.Match(NumScriptParserNEWLINE)
 			}
 			{
-				p.SetState(237)
+				p.SetState(343)
 
 				var _x = p.Statement()
 
@@ -5204,26 +7627,26 @@ func (p *NumScriptParser) Script() (localctx IScriptContext) {
 			localctx.(*ScriptContext).stmts = append(localctx.(*ScriptContext).stmts, localctx.(*ScriptContext)._statement)
 
 		}
-		p.SetState(242)
+		p.SetState(348)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 20, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 31, p.GetParserRuleContext())
 	}
-	p.SetState(246)
+	p.SetState(352)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == NumScriptParserNEWLINE {
 		{
-			p.SetState(243)
+			p.SetState(349)
 			p.Match(NumScriptParserNEWLINE)
 		}
 
-		p.SetState(248)
+		p.SetState(354)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(249)
+		p.SetState(355)
 		p.Match(NumScriptParserEOF)
 	}
 
@@ -5232,7 +7655,7 @@ func (p *NumScriptParser) Script() (localctx IScriptContext) {
 
 func (p *NumScriptParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
-	case 4:
+	case 5:
 		var t *ExpressionContext = nil
 		if localctx != nil {
 			t = localctx.(*ExpressionContext)
@@ -5250,7 +7673,19 @@ func (p *NumScriptParser) Expression_Sempred(localctx antlr.RuleContext, predInd
 
 	switch predIndex {
 	case 0:
-		return p.Precpred(p.GetParserRuleContext(), 3)
+		return p.Precpred(p.GetParserRuleContext(), 9)
+
+	case 1:
+		return p.Precpred(p.GetParserRuleContext(), 8)
+
+	case 2:
+		return p.Precpred(p.GetParserRuleContext(), 7)
+
+	case 3:
+		return p.Precpred(p.GetParserRuleContext(), 6)
+
+	case 4:
+		return p.Precpred(p.GetParserRuleContext(), 5)
 
 	default:
 		panic("No predicate with index: " + fmt.Sprint(predIndex))
@@ -0,0 +1,183 @@
+package vm
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/script/compiler"
+)
+
+// Fails if the number of goroutines doesn't go back to what it was before the test
+func checkNoLeak(t *testing.T, before int) {
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			n := runtime.Stack(buf, true)
+			t.Fatalf("leaked goroutines: %d > %d\n%s", runtime.NumGoroutine(), before, buf[:n])
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestResolveResourcesCancel(t *testing.T) {
+	p, err := compiler.Compile(`vars {
+		portion $fee = meta(@a, "fee")
+		portion $tax = meta(@a, "tax")
+	}
+	set_tx_meta("fee", $fee)
+	set_tx_meta("tax", $tax)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := runtime.NumGoroutine()
+
+	m := NewMachine(p)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := m.ResolveResourcesContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := <-ch
+	if req.Error != nil || req.Key != "fee" {
+		t.Fatalf("unexpected request: %v", req)
+	}
+	cancel()
+	for req := range ch {
+		if !errors.Is(req.Error, context.Canceled) {
+			t.Fatalf("unexpected request: %v", req)
+		}
+	}
+	// answering after cancellation must not block the caller
+	req.Response <- core.String("too late")
+
+	checkNoLeak(t, before)
+	if len(m.Resources) == len(m.UnresolvedResources) {
+		t.Fatal("resources should not be resolved")
+	}
+}
+
+func TestResolveBalancesCancel(t *testing.T) {
+	p, err := compiler.Compile(`send [COIN 10] (
+		source = @a
+		destination = @b
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := runtime.NumGoroutine()
+
+	m := NewMachine(p)
+	{
+		ch, _ := m.ResolveResources()
+		for range ch {
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = m.ResolveBalancesContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the caller stops draining the channel
+	cancel()
+
+	checkNoLeak(t, before)
+}
+
+func TestExecuteCancel(t *testing.T) {
+	p, err := compiler.Compile(`print 1
+	print 2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := runtime.NumGoroutine()
+
+	m := NewMachine(p)
+	// a printer that never reads
+	blocked := make(chan struct{})
+	m.Printer = func(c chan core.Value) {
+		<-blocked
+	}
+	{
+		ch, _ := m.ResolveResources()
+		for range ch {
+		}
+	}
+	{
+		ch, _ := m.ResolveBalances()
+		for range ch {
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = m.ExecuteContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	close(blocked)
+
+	checkNoLeak(t, before)
+}
+
+func TestExecuteCancelReset(t *testing.T) {
+	p, err := compiler.Compile(`print 1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := runtime.NumGoroutine()
+
+	m := NewMachine(p)
+	// a printer that only starts reading once the machine is reused
+	blocked := make(chan struct{})
+	m.Printer = func(c chan core.Value) {
+		<-blocked
+		for range c {
+		}
+	}
+	{
+		ch, _ := m.ResolveResources()
+		for range ch {
+		}
+	}
+	{
+		ch, _ := m.ResolveBalances()
+		for range ch {
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = m.ExecuteContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the printer still running must not race with the machine being reused
+	m.Reset()
+	m.Printer = nil
+	close(blocked)
+
+	checkNoLeak(t, before)
+}
+
+func TestRunCancel(t *testing.T) {
+	p, err := compiler.Compile(`send [COIN 10] (
+		source = @a
+		destination = @b
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Run(ctx, p, map[string]core.Value{}, mapStore{
+		balances: map[string]map[string]*core.MonetaryInt{
+			"a": {"COIN": core.NewMonetaryInt(10)},
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/vm/program"
+)
+
+// Returned by Execute along with EXIT_FAIL_OVERFLOW
+// when an arithmetic operation overflows or underflows.
+type OverflowError struct {
+	P        uint
+	Opcode   byte
+	Operands []core.Value
+	Msg      string
+}
+
+func (e *OverflowError) Error() string {
+	operands := make([]string, len(e.Operands))
+	for i, v := range e.Operands {
+		operands[i] = fmt.Sprint(v)
+	}
+	return fmt.Sprintf("%v at instruction %d (%v %v)",
+		e.Msg, e.P, program.OpcodeName(e.Opcode), strings.Join(operands, ", "))
+}
+
+var ErrInvalidProgram = errors.New("invalid program")
+
+// Returned by Execute along with EXIT_FAIL_INVALID when the program
+// doesn't match what an instruction expects, e.g. a type mismatch on the stack.
+// Matches ErrInvalidProgram with errors.Is.
+type InvalidProgramError struct {
+	P        uint
+	Opcode   byte
+	Expected string
+	Actual   string
+	Msg      string
+}
+
+func (e *InvalidProgramError) Error() string {
+	s := fmt.Sprintf("%v at instruction %d (%v): %v", ErrInvalidProgram, e.P, program.OpcodeName(e.Opcode), e.Msg)
+	if e.Expected != "" {
+		s += fmt.Sprintf(": expected %v, got %v", e.Expected, e.Actual)
+	}
+	return s
+}
+
+func (e *InvalidProgramError) Is(target error) bool {
+	return target == ErrInvalidProgram
+}
+
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// Returned by Execute along with EXIT_FAIL_INSUFFICIENT_FUNDS when a source
+// can't provide the requested amount. Matches ErrInsufficientFunds with errors.Is.
+type ExecutionError struct {
+	P         uint
+	Opcode    byte
+	Asset     core.Asset
+	Requested *core.MonetaryInt
+	Available *core.MonetaryInt
+	Accounts  []core.Account // accounts that made up the funding, in order
+}
+
+func (e *ExecutionError) Error() string {
+	accounts := make([]string, len(e.Accounts))
+	for i, a := range e.Accounts {
+		accounts[i] = a.String()
+	}
+	if len(accounts) == 0 {
+		accounts = []string{"no account"}
+	}
+	return fmt.Sprintf("%v at instruction %d (%v): requested %v from %v, but only %v available",
+		ErrInsufficientFunds, e.P, program.OpcodeName(e.Opcode),
+		core.Monetary{Asset: e.Asset, Amount: e.Requested},
+		strings.Join(accounts, ", "),
+		core.Monetary{Asset: e.Asset, Amount: e.Available})
+}
+
+func (e *ExecutionError) Is(target error) bool {
+	return target == ErrInsufficientFunds
+}
+
+var ErrAssetMismatch = errors.New("asset mismatch")
+
+// Returned by Execute along with EXIT_FAIL_ASSET_MISMATCH when two monetaries
+// whose assets are only known during execution don't have the same asset.
+// Matches ErrAssetMismatch with errors.Is.
+type AssetMismatchError struct {
+	P      uint
+	Opcode byte
+	Lhs    core.Asset
+	Rhs    core.Asset
+	Msg    string
+}
+
+func (e *AssetMismatchError) Error() string {
+	return fmt.Sprintf("%v at instruction %d (%v): %v", ErrAssetMismatch, e.P, program.OpcodeName(e.Opcode), e.Msg)
+}
+
+func (e *AssetMismatchError) Is(target error) bool {
+	return target == ErrAssetMismatch
+}
+
+var ErrFailed = errors.New("failed")
+
+// Returned by Execute along with EXIT_FAIL when the program fails with a reason,
+// with fail or a failed assertion. Matches ErrFailed with errors.Is.
+type FailError struct {
+	P      uint
+	Reason string
+}
+
+func (e *FailError) Error() string {
+	return fmt.Sprintf("%v at instruction %d: %v", ErrFailed, e.P, e.Reason)
+}
+
+func (e *FailError) Is(target error) bool {
+	return target == ErrFailed
+}
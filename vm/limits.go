@@ -0,0 +1,66 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/numary/machine/vm/program"
+)
+
+// Limits on what an execution may consume, zero meaning unlimited.
+// Exceeding one stops the execution with its own exit code.
+type Limits struct {
+	MaxInstructions uint // EXIT_FAIL_INSTRUCTIONS_LIMIT
+	MaxStackDepth   uint // EXIT_FAIL_STACK_LIMIT
+	MaxPostings     uint // EXIT_FAIL_POSTINGS_LIMIT
+	MaxFundingParts uint // EXIT_FAIL_FUNDING_LIMIT, parts of a single funding
+	MaxPrinted      uint // EXIT_FAIL_PRINT_LIMIT
+}
+
+// What an execution consumed
+type Usage struct {
+	Instructions uint `json:"instructions"`  // number of instructions executed
+	StackDepth   uint `json:"stack_depth"`   // maximum depth of the stack
+	Postings     uint `json:"postings"`      // number of postings emitted
+	FundingParts uint `json:"funding_parts"` // maximum number of parts of a funding
+	Printed      uint `json:"printed"`       // number of values printed
+}
+
+// Returned by Execute along with the exit code of the limit exceeded
+type LimitError struct {
+	P      uint
+	Opcode byte
+	Limit  string
+	Max    uint
+	Actual uint
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%v limit exceeded at instruction %d (%v): %d > %d",
+		e.Limit, e.P, program.OpcodeName(e.Opcode), e.Actual, e.Max)
+}
+
+func (m *Machine) checkLimits(p uint, op byte) (byte, error) {
+	limit := func(name string, max uint, actual uint) *LimitError {
+		return &LimitError{
+			P:      p,
+			Opcode: op,
+			Limit:  name,
+			Max:    max,
+			Actual: actual,
+		}
+	}
+	l, u := m.Limits, m.Usage
+	switch {
+	case l.MaxInstructions != 0 && u.Instructions > l.MaxInstructions:
+		return EXIT_FAIL_INSTRUCTIONS_LIMIT, limit("instructions", l.MaxInstructions, u.Instructions)
+	case l.MaxStackDepth != 0 && u.StackDepth > l.MaxStackDepth:
+		return EXIT_FAIL_STACK_LIMIT, limit("stack depth", l.MaxStackDepth, u.StackDepth)
+	case l.MaxPostings != 0 && u.Postings > l.MaxPostings:
+		return EXIT_FAIL_POSTINGS_LIMIT, limit("postings", l.MaxPostings, u.Postings)
+	case l.MaxFundingParts != 0 && u.FundingParts > l.MaxFundingParts:
+		return EXIT_FAIL_FUNDING_LIMIT, limit("funding parts", l.MaxFundingParts, u.FundingParts)
+	case l.MaxPrinted != 0 && u.Printed > l.MaxPrinted:
+		return EXIT_FAIL_PRINT_LIMIT, limit("printed values", l.MaxPrinted, u.Printed)
+	}
+	return 0, nil
+}
@@ -0,0 +1,113 @@
+package vm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/script/compiler"
+)
+
+const limitsCode = `print 1 + 2
+send [COIN 30] (
+	source = {
+		@a
+		@b
+		@c
+	}
+	destination = {
+		max [COIN 10] to @d
+		remaining to @e
+	}
+)
+print "done"`
+
+func executeWithLimits(t *testing.T, limits Limits) (*Machine, byte, error) {
+	p, err := compiler.Compile(limitsCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMachine(p)
+	m.Limits = limits
+	{
+		ch, _ := m.ResolveResources()
+		for range ch {
+		}
+	}
+	err = m.SetBalances(map[string]map[string]*core.MonetaryInt{
+		"a": {"COIN": core.NewMonetaryInt(10)},
+		"b": {"COIN": core.NewMonetaryInt(10)},
+		"c": {"COIN": core.NewMonetaryInt(10)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exit_code, err := m.Execute()
+	return m, exit_code, err
+}
+
+func TestUsage(t *testing.T) {
+	m, exit_code, err := executeWithLimits(t, Limits{})
+	if err != nil || exit_code != EXIT_OK {
+		t.Fatalf("unexpected result: %v, %v", exit_code, err)
+	}
+	u := m.Usage
+	if u.Postings != 3 || u.Printed != 2 || u.FundingParts != 3 {
+		t.Fatalf("unexpected usage: %+v", u)
+	}
+	if u.Instructions == 0 || u.StackDepth == 0 {
+		t.Fatalf("unexpected usage: %+v", u)
+	}
+
+	// a program fits in the limits it consumed
+	_, exit_code, err = executeWithLimits(t, Limits{
+		MaxInstructions: u.Instructions,
+		MaxStackDepth:   u.StackDepth,
+		MaxPostings:     u.Postings,
+		MaxFundingParts: u.FundingParts,
+		MaxPrinted:      u.Printed,
+	})
+	if err != nil || exit_code != EXIT_OK {
+		t.Fatalf("unexpected result: %v, %v", exit_code, err)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	m, _, _ := executeWithLimits(t, Limits{})
+	u := m.Usage
+	for _, c := range []struct {
+		Limits   Limits
+		ExitCode byte
+		Msg      string
+	}{
+		{Limits{MaxInstructions: u.Instructions - 1}, EXIT_FAIL_INSTRUCTIONS_LIMIT, "instructions limit exceeded"},
+		{Limits{MaxStackDepth: u.StackDepth - 1}, EXIT_FAIL_STACK_LIMIT, "stack depth limit exceeded"},
+		{Limits{MaxPostings: 2}, EXIT_FAIL_POSTINGS_LIMIT, "postings limit exceeded"},
+		{Limits{MaxFundingParts: 2}, EXIT_FAIL_FUNDING_LIMIT, "funding parts limit exceeded"},
+		{Limits{MaxPrinted: 1}, EXIT_FAIL_PRINT_LIMIT, "printed values limit exceeded at instruction"},
+	} {
+		m, exit_code, err := executeWithLimits(t, c.Limits)
+		if exit_code != c.ExitCode {
+			t.Fatalf("%v: unexpected exit code: %v", c.Msg, exit_code)
+		}
+		var limit_err *LimitError
+		if !errors.As(err, &limit_err) || !strings.Contains(err.Error(), c.Msg) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := m.SourceSpan(); !ok {
+			t.Fatalf("%v: expected a source span", c.Msg)
+		}
+	}
+}
+
+func TestPostingsLimitResult(t *testing.T) {
+	// the second send emits two postings, only the first of which fits
+	m, exit_code, err := executeWithLimits(t, Limits{MaxPostings: 2})
+	if exit_code != EXIT_FAIL_POSTINGS_LIMIT || err == nil {
+		t.Fatalf("unexpected result: %v, %v", exit_code, err)
+	}
+	if len(m.Postings) != 2 || len(m.Result().Postings) != 2 {
+		t.Fatalf("unexpected postings: %v", m.Postings)
+	}
+}
@@ -2,19 +2,25 @@
 	Provides `Machine`, which executes programs and outputs postings.
 	1: Create New Machine
 	2: Set Variables (with `core.Value`s or JSON)
-	3: Resolve Resources (answer requests on channel)
-	4: Resolve Balances (answer requests on channel)
-	6: Execute
+	3: Resolve Resources (answer requests on channel, or by waves with `ResolveResourcesInWaves`)
+	4: Resolve Balances (answer requests on channel, or all at once with `NeededBalances` and `SetBalances`)
+	6: Execute (`ExecuteResult` returns an `ExecutionResult` with everything it produced)
+	Steps 3, 4 and 6 have variants taking a `context.Context` to abort them.
+	Alternatively, `Run` does all of the above, fetching balances and metadata from a `Store`.
+	A machine can execute its program again after `Reset`, and `MachinePool` keeps reset machines around.
 */
 package vm
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math/big"
+	"sort"
 
-	"github.com/logrusorgru/aurora"
 	ledger "github.com/numary/ledger/pkg/core"
 	"github.com/numary/machine/core"
 	"github.com/numary/machine/vm/program"
@@ -25,8 +31,16 @@ const (
 	EXIT_FAIL
 	EXIT_FAIL_INVALID
 	EXIT_FAIL_INSUFFICIENT_FUNDS
+	EXIT_FAIL_OVERFLOW
+	EXIT_FAIL_INSTRUCTIONS_LIMIT
+	EXIT_FAIL_STACK_LIMIT
+	EXIT_FAIL_POSTINGS_LIMIT
+	EXIT_FAIL_FUNDING_LIMIT
+	EXIT_FAIL_PRINT_LIMIT
+	EXIT_FAIL_ASSET_MISMATCH
 )
 
+// Deprecated: printed values are written to Machine.Output and collected in Machine.Printed
 func StdOutPrinter(c chan core.Value) {
 	for v := range c {
 		fmt.Println("OUT:", v)
@@ -34,40 +48,93 @@ func StdOutPrinter(c chan core.Value) {
 }
 
 func NewMachine(p *program.Program) *Machine {
-	printc := make(chan core.Value)
-
 	m := Machine{
 		Program:             p,
 		UnresolvedResources: p.Resources,
 		Resources:           make([]core.Value, 0),
-		print_chan:          printc,
-		Printer:             StdOutPrinter,
 		TxMeta:              map[string]core.Value{},
+		AccountMeta:         map[string]map[string]core.Value{},
+		Printed:             []core.Value{},
 	}
 
 	return &m
 }
 
+type Posting struct {
+	Source      string            `json:"source"`
+	Destination string            `json:"destination"`
+	Amount      *core.MonetaryInt `json:"amount"`
+	Asset       string            `json:"asset"`
+}
+
 type Machine struct {
 	P                   uint
 	Program             *program.Program
 	Vars                map[string]core.Value
-	UnresolvedResources []program.Resource
-	Resources           []core.Value // Constants and Variables
+	UnresolvedResources []program.Resource // shared with Program, never modified
+	Resources           []core.Value       // Constants and Variables
 	resolve_called      bool
-	Balances            map[string]map[string]uint64 // keeps tracks of balances througout execution
+	Balances            map[string]map[string]*core.MonetaryInt // keeps tracks of balances througout execution, negative when overdrawn
 	set_balance_called  bool
 	Stack               []core.Value
-	Postings            []ledger.Posting      // accumulates postings throughout execution
-	TxMeta              map[string]core.Value // accumulates transaction meta throughout execution
-	Printer             func(chan core.Value)
+	Postings            []Posting                        // accumulates postings throughout execution
+	TxMeta              map[string]core.Value            // accumulates transaction meta throughout execution
+	AccountMeta         map[string]map[string]core.Value // accumulates account meta throughout execution, by account
+	Printed             []core.Value                     // accumulates printed values throughout execution
+	Output              io.Writer                        // if set, printed values are also written to it as they are printed
+	Printer             func(chan core.Value)            // Deprecated: use Printed or Output
 	print_chan          chan core.Value
-	Debug               bool
+	Tracer              Tracer // nil unless the execution is traced
+	Limits              Limits // checked throughout execution
+	Usage               Usage  // updated throughout execution
+	exit_code           byte
+	err                 error
+}
+
+// Clears the execution state so that the machine can execute its program again,
+// e.g. with other variables. Output, Printer, Tracer and Limits are kept.
+// The stack and resources buffers are reused, while what an execution hands
+// out (postings, metadata, printed values, results) is left untouched.
+func (m *Machine) Reset() {
+	for i := range m.Stack {
+		m.Stack[i] = nil
+	}
+	for i := range m.Resources {
+		m.Resources[i] = nil
+	}
+	m.P = 0
+	m.Vars = nil
+	m.Resources = m.Resources[:0]
+	m.resolve_called = false
+	m.Balances = nil
+	m.set_balance_called = false
+	m.Stack = m.Stack[:0]
+	m.Postings = nil
+	m.TxMeta = map[string]core.Value{}
+	m.AccountMeta = map[string]map[string]core.Value{}
+	m.Printed = []core.Value{}
+	m.print_chan = nil
+	m.Usage = Usage{}
+	m.exit_code = 0
+	m.err = nil
 }
 
 func (m *Machine) GetTxMetaJson() ledger.Metadata {
+	return metadataJson(m.TxMeta)
+}
+
+// Returns the account metadata set by the program as stored by the ledger, by account
+func (m *Machine) GetAccountMetaJson() map[string]ledger.Metadata {
+	res := make(map[string]ledger.Metadata, len(m.AccountMeta))
+	for account, meta := range m.AccountMeta {
+		res[account] = metadataJson(meta)
+	}
+	return res
+}
+
+func metadataJson(values map[string]core.Value) ledger.Metadata {
 	meta := make(ledger.Metadata)
-	for k, v := range m.TxMeta {
+	for k, v := range values {
 		val_json, _ := json.Marshal(v)
 		v, _ := json.Marshal(core.ValueJSON{
 			Type:  v.GetType().String(),
@@ -86,21 +153,25 @@ func (m *Machine) getResource(addr core.Address) (*core.Value, bool) {
 	return &m.Resources[a], true
 }
 
-func (m *Machine) withdrawAll(account core.Account, asset core.Asset) (*core.Funding, error) {
+// Withdraws the balance of an account, and up to overdraft past it.
+// World always has an unbounded overdraft, even when only known during execution.
+func (m *Machine) withdrawAll(account core.Account, asset core.Asset, overdraft *core.MonetaryInt) (*core.Funding, error) {
 	if account == "world" {
-		return &core.Funding{
-			Asset:    asset,
-			Infinite: true,
-		}, nil
+		return m.withdrawUnbounded(account, asset), nil
 	}
 	if acc_balance, ok := m.Balances[string(account)]; ok {
 		if balance, ok := acc_balance[string(asset)]; ok {
-			acc_balance[string(asset)] = 0
+			amount := core.NewMonetaryInt(0)
+			// the account may already be overdrawn past what is allowed
+			if available := balance.Add(overdraft); available.Gt(amount) {
+				amount = available
+				acc_balance[string(asset)] = overdraft.Neg()
+			}
 			return &core.Funding{
 				Asset: asset,
 				Parts: []core.FundingPart{{
 					Account: account,
-					Amount:  balance,
+					Amount:  amount,
 				}},
 			}, nil
 		}
@@ -108,54 +179,128 @@ func (m *Machine) withdrawAll(account core.Account, asset core.Asset) (*core.Fun
 	return nil, fmt.Errorf("missing %v balance from %v", asset, account)
 }
 
-func (m *Machine) credit(account core.Account, funding core.Funding) {
-	if account == "world" {
-		return
+// Withdraws the balance of an account with an unbounded overdraft, as an infinite funding.
+// What is taken past the balance is only debited by take, once the amount is known.
+// The balance of such an account isn't needed, e.g. for @world.
+func (m *Machine) withdrawUnbounded(account core.Account, asset core.Asset) *core.Funding {
+	funding := core.Funding{
+		Asset:           asset,
+		Infinite:        true,
+		InfiniteAccount: account,
+	}
+	if balance, ok := m.Balances[string(account)][string(asset)]; ok && balance.Gt(core.NewMonetaryInt(0)) {
+		m.Balances[string(account)][string(asset)] = core.NewMonetaryInt(0)
+		funding.Parts = []core.FundingPart{{
+			Account: account,
+			Amount:  balance,
+		}}
+	}
+	return &funding
+}
+
+// Debits the account with an unbounded overdraft of funding with what taking amount overdraws it by
+func (m *Machine) overdraw(funding core.Funding, amount *core.MonetaryInt) {
+	if balance, ok := m.Balances[string(funding.InfiniteAccount)][string(funding.Asset)]; ok {
+		m.Balances[string(funding.InfiniteAccount)][string(funding.Asset)] = balance.Sub(funding.Overdrawn(amount))
+	}
+}
+
+func (m *Machine) credit(account core.Account, funding core.Funding) error {
+	for _, part := range funding.Parts {
+		if part.Amount.Ltz() {
+			return fmt.Errorf("%w credited to %v: %v", core.ErrNegativeAmount, account, part.Amount)
+		}
 	}
 	if acc_balance, ok := m.Balances[string(account)]; ok {
 		if _, ok := acc_balance[string(funding.Asset)]; ok {
 			for _, part := range funding.Parts {
-				acc_balance[string(funding.Asset)] += part.Amount
+				acc_balance[string(funding.Asset)] = acc_balance[string(funding.Asset)].Add(part.Amount)
 			}
 		}
 	}
+	return nil
 }
 
-func (m *Machine) repay(funding core.Funding) {
+func (m *Machine) repay(funding core.Funding) error {
+	for _, part := range funding.Parts {
+		if part.Amount.Ltz() {
+			return fmt.Errorf("%w repaid to %v: %v", core.ErrNegativeAmount, part.Account, part.Amount)
+		}
+	}
 	for _, part := range funding.Parts {
-		if part.Account == "world" {
-			continue
+		// balances of accounts with an unbounded overdraft may not be tracked
+		if balance, ok := m.Balances[string(part.Account)][string(funding.Asset)]; ok {
+			m.Balances[string(part.Account)][string(funding.Asset)] = balance.Add(part.Amount)
 		}
-		m.Balances[string(part.Account)][string(funding.Asset)] += part.Amount
+	}
+	return nil
+}
+
+func (m *Machine) overflow(op byte, msg string, operands ...core.Value) *OverflowError {
+	return &OverflowError{
+		P:        m.P,
+		Opcode:   op,
+		Operands: operands,
+		Msg:      msg,
+	}
+}
+
+func (m *Machine) assetMismatch(op byte, lhs, rhs core.Asset, msg string) *AssetMismatchError {
+	return &AssetMismatchError{
+		P:      m.P,
+		Opcode: op,
+		Lhs:    lhs,
+		Rhs:    rhs,
+		Msg:    msg,
 	}
 }
 
-func (m *Machine) tick() (bool, byte) {
+func (m *Machine) tick(ctx context.Context) (bool, byte, error) {
+	select {
+	case <-ctx.Done():
+		return true, 0, ctx.Err()
+	default:
+	}
+
 	op := m.Program.Instructions[m.P]
+	start := m.P
 
-	if m.Debug {
-		fmt.Println("STATE ---------------------------------------------------------------------")
-		fmt.Printf("    %v\n", aurora.Blue(m.Stack))
-		fmt.Printf("    %v\n", aurora.Cyan(m.Balances))
-		fmt.Printf("    %v\n", program.OpcodeName(op))
+	m.Usage.Instructions++
+	if exit_code, err := m.checkLimits(start, op); err != nil {
+		return true, exit_code, err
+	}
+
+	var balances_before map[string]map[string]*core.MonetaryInt
+	if m.Tracer != nil {
+		m.Tracer.BeforeInstruction(start, op, append([]core.Value(nil), m.Stack...), m.Balances)
+		balances_before = copyBalances(m.Balances)
 	}
 
 	switch op {
 	case program.OP_APUSH:
+		if int(m.P)+3 > len(m.Program.Instructions) {
+			m.invalidProgram("truncated operand")
+		}
 		bytes := m.Program.Instructions[m.P+1 : m.P+3]
 		v, ok := m.getResource(core.Address(binary.LittleEndian.Uint16(bytes)))
 		if !ok {
-			return true, EXIT_FAIL
+			m.invalidProgram(fmt.Sprintf("invalid resource address: #%d", binary.LittleEndian.Uint16(bytes)))
 		}
-		m.Stack = append(m.Stack, *v)
+		m.pushValue(*v)
 		m.P += 2
 	case program.OP_IPUSH:
+		if int(m.P)+9 > len(m.Program.Instructions) {
+			m.invalidProgram("truncated operand")
+		}
 		bytes := m.Program.Instructions[m.P+1 : m.P+9]
 		v := core.Number(binary.LittleEndian.Uint64(bytes))
 		m.Stack = append(m.Stack, v)
 		m.P += 8
 	case program.OP_BUMP:
 		n := m.popNumber()
+		if n >= uint64(len(m.Stack)) {
+			m.invalidProgram(fmt.Sprintf("tried to bump %d values on a stack of %d", n, len(m.Stack)))
+		}
 		idx := len(m.Stack) - int(n) - 1
 		v := m.Stack[idx]
 		m.Stack = append(m.Stack[:idx], m.Stack[idx+1:]...)
@@ -163,16 +308,39 @@ func (m *Machine) tick() (bool, byte) {
 	case program.OP_IADD:
 		b := m.popNumber()
 		a := m.popNumber()
+		if a+b < a {
+			return true, EXIT_FAIL_OVERFLOW, m.overflow(op, "integer overflow", core.Number(a), core.Number(b))
+		}
 		m.pushValue(core.Number(a + b))
 	case program.OP_ISUB:
 		b := m.popNumber()
 		a := m.popNumber()
+		if b > a {
+			return true, EXIT_FAIL_OVERFLOW, m.overflow(op, "integer underflow", core.Number(a), core.Number(b))
+		}
 		m.pushValue(core.Number(a - b))
 	case program.OP_PRINT:
 		a := m.popValue()
-		m.print_chan <- a
+		m.Usage.Printed++
+		if exit_code, err := m.checkLimits(start, op); err != nil {
+			return true, exit_code, err
+		}
+		m.Printed = append(m.Printed, a)
+		if m.Output != nil {
+			_, err := fmt.Fprintln(m.Output, "OUT:", a)
+			if err != nil {
+				return true, EXIT_FAIL, fmt.Errorf("failed to print %v: %w", a, err)
+			}
+		}
+		if m.Printer != nil {
+			select {
+			case m.print_chan <- a:
+			case <-ctx.Done():
+				return true, 0, ctx.Err()
+			}
+		}
 	case program.OP_FAIL:
-		return true, EXIT_FAIL
+		return true, EXIT_FAIL, nil
 	case program.OP_ASSET:
 		v := m.popValue()
 		switch v := v.(type) {
@@ -183,7 +351,7 @@ func (m *Machine) tick() (bool, byte) {
 		case core.Funding:
 			m.pushValue(v.Asset)
 		default:
-			return true, EXIT_FAIL_INVALID
+			return true, EXIT_FAIL_INVALID, m.invalidProgramError(fmt.Sprintf("cannot get the asset of %v", v))
 		}
 
 	case program.OP_MONETARY_NEW:
@@ -191,22 +359,68 @@ func (m *Machine) tick() (bool, byte) {
 		asset := m.popAsset()
 		m.pushValue(core.Monetary{
 			Asset:  asset,
-			Amount: amount,
+			Amount: core.NewMonetaryIntFromBigInt(new(big.Int).SetUint64(amount)),
 		})
 
 	case program.OP_MONETARY_ADD:
 		b := m.popMonetary()
 		a := m.popMonetary()
 		if a.Asset != b.Asset {
-			return true, EXIT_FAIL_INVALID
+			return true, EXIT_FAIL_ASSET_MISMATCH, m.assetMismatch(op, a.Asset, b.Asset, fmt.Sprintf("%v + %v", a.Asset, b.Asset))
+		}
+		m.pushValue(core.Monetary{
+			Asset:  a.Asset,
+			Amount: a.Amount.Add(b.Amount),
+		})
+
+	case program.OP_MONETARY_SUB:
+		b := m.popMonetary()
+		a := m.popMonetary()
+		if a.Asset != b.Asset {
+			return true, EXIT_FAIL_ASSET_MISMATCH, m.assetMismatch(op, a.Asset, b.Asset, fmt.Sprintf("%v - %v", a.Asset, b.Asset))
+		}
+		m.pushValue(core.Monetary{
+			Asset:  a.Asset,
+			Amount: a.Amount.Sub(b.Amount),
+		})
+
+	case program.OP_MONETARY_MUL:
+		n := m.popNumber()
+		a := m.popMonetary()
+		amount := a.Amount.BigInt()
+		amount.Mul(amount, new(big.Int).SetUint64(n))
+		m.pushValue(core.Monetary{
+			Asset:  a.Asset,
+			Amount: core.NewMonetaryIntFromBigInt(amount),
+		})
+
+	case program.OP_MONETARY_MUL_DOWN, program.OP_MONETARY_MUL_UP:
+		portion := m.popPortion()
+		a := m.popMonetary()
+		if portion.Remaining {
+			return true, EXIT_FAIL_INVALID, m.invalidProgramError("cannot multiply by the remaining portion")
+		}
+		// Div rounds toward negative infinity as the denominator is positive,
+		// rounding up is done by negating the amount before and after
+		amount := a.Amount.BigInt()
+		amount.Mul(amount, portion.Specific.Num())
+		if op == program.OP_MONETARY_MUL_UP {
+			amount.Neg(amount)
+		}
+		amount.Div(amount, portion.Specific.Denom())
+		if op == program.OP_MONETARY_MUL_UP {
+			amount.Neg(amount)
 		}
 		m.pushValue(core.Monetary{
 			Asset:  a.Asset,
-			Amount: a.Amount + b.Amount,
+			Amount: core.NewMonetaryIntFromBigInt(amount),
 		})
 
 	case program.OP_MAKE_ALLOTMENT:
 		n := m.popNumber()
+		if n > uint64(len(m.Stack)) {
+			m.invalidProgram(fmt.Sprintf("tried to make an allotment of %d portions on a stack of %d", n, len(m.Stack)))
+		}
 		portions := make([]core.Portion, n)
 		for i := uint64(0); i < n; i++ {
 			p := m.popPortion()
@@ -214,43 +428,87 @@ func (m *Machine) tick() (bool, byte) {
 		}
 		allotment, err := core.NewAllotment(portions)
 		if err != nil {
-			return true, EXIT_FAIL_INVALID
+			return true, EXIT_FAIL_INVALID, m.invalidProgramError(err.Error())
 		}
 		m.pushValue(*allotment)
 	case program.OP_TAKE_ALL:
 		asset := m.popAsset()
 		account := m.popAccount()
-		funding, err := m.withdrawAll(account, asset)
+		funding, err := m.withdrawAll(account, asset, core.NewMonetaryInt(0))
 		if err != nil {
-			return true, EXIT_FAIL_INVALID
+			return true, EXIT_FAIL_INVALID, m.invalidProgramError(err.Error())
 		}
 		m.pushValue(*funding)
+	case program.OP_TAKE_ALL_OVERDRAFT:
+		overdraft := m.popMonetary()
+		asset := m.popAsset()
+		account := m.popAccount()
+		if overdraft.Asset != asset {
+			return true, EXIT_FAIL_ASSET_MISMATCH, m.assetMismatch(op, overdraft.Asset, asset, fmt.Sprintf("overdraft of %v on %v", overdraft.Asset, asset))
+		}
+		if overdraft.Amount.Ltz() {
+			return true, EXIT_FAIL_OVERFLOW, m.overflow(op, "negative overdraft", account, overdraft)
+		}
+		funding, err := m.withdrawAll(account, asset, overdraft.Amount)
+		if err != nil {
+			return true, EXIT_FAIL_INVALID, m.invalidProgramError(err.Error())
+		}
+		m.pushValue(*funding)
+	case program.OP_TAKE_ALL_UNBOUNDED:
+		asset := m.popAsset()
+		account := m.popAccount()
+		m.pushValue(*m.withdrawUnbounded(account, asset))
 	case program.OP_TAKE:
 		mon := m.popMonetary()
 		funding := m.popFunding()
 		if funding.Asset != mon.Asset {
-			return true, EXIT_FAIL_INVALID
+			return true, EXIT_FAIL_INVALID, m.invalidProgramError(fmt.Sprintf("asset mismatch: cannot take %v from a funding of %v", mon.Asset, funding.Asset))
+		}
+		if mon.Amount.Ltz() {
+			return true, EXIT_FAIL_OVERFLOW, m.overflow(op, "negative monetary amount", funding, mon)
 		}
 		result, remainder, err := funding.Take(mon.Amount)
-		if err != nil {
-			return true, EXIT_FAIL_INSUFFICIENT_FUNDS
+		if errors.Is(err, core.ErrInsufficientFunding) {
+			available, err := funding.Total()
+			if err != nil {
+				return true, EXIT_FAIL_OVERFLOW, m.overflow(op, err.Error(), funding)
+			}
+			return true, EXIT_FAIL_INSUFFICIENT_FUNDS, &ExecutionError{
+				P:         m.P,
+				Opcode:    op,
+				Asset:     funding.Asset,
+				Requested: mon.Amount,
+				Available: available,
+				Accounts:  funding.Accounts(),
+			}
+		} else if err != nil {
+			return true, EXIT_FAIL_INVALID, m.invalidProgramError(err.Error())
 		}
+		m.overdraw(funding, mon.Amount)
 		m.pushValue(remainder)
 		m.pushValue(result)
 	case program.OP_TAKE_MAX:
 		mon := m.popMonetary()
 		funding := m.popFunding()
 		if funding.Asset != mon.Asset {
-			return true, EXIT_FAIL_INVALID
+			return true, EXIT_FAIL_INVALID, m.invalidProgramError(fmt.Sprintf("asset mismatch: cannot take %v from a funding of %v", mon.Asset, funding.Asset))
+		}
+		if mon.Amount.Ltz() {
+			return true, EXIT_FAIL_OVERFLOW, m.overflow(op, "negative monetary amount", funding, mon)
 		}
 		result, remainder := funding.TakeMax(mon.Amount)
+		m.overdraw(funding, mon.Amount)
 		m.pushValue(remainder)
 		m.pushValue(result)
 
 	case program.OP_FUNDING_ASSEMBLE:
-		n := int(m.popNumber())
+		num := m.popNumber()
+		if num > uint64(len(m.Stack)) {
+			m.invalidProgram(fmt.Sprintf("tried to assemble %d fundings on a stack of %d", num, len(m.Stack)))
+		}
+		n := int(num)
 		if n == 0 {
-			return true, EXIT_FAIL_INVALID
+			return true, EXIT_FAIL_INVALID, m.invalidProgramError("cannot assemble zero fundings")
 		}
 		first := m.popFunding()
 		result := core.Funding{
@@ -261,14 +519,14 @@ func (m *Machine) tick() (bool, byte) {
 		for i := 1; i < n; i++ {
 			f := m.popFunding()
 			if f.Asset != result.Asset {
-				return true, EXIT_FAIL_INVALID
+				return true, EXIT_FAIL_INVALID, m.invalidProgramError(fmt.Sprintf("asset mismatch: cannot assemble fundings of %v and %v", f.Asset, result.Asset))
 			}
 			fundings_rev[i] = f
 		}
 		for i := 0; i < n; i++ {
 			res, err := result.Concat(fundings_rev[n-1-i])
 			if err != nil {
-				return true, EXIT_FAIL_INVALID
+				return true, EXIT_FAIL_INVALID, m.invalidProgramError(err.Error())
 			}
 			result = res
 		}
@@ -277,8 +535,10 @@ func (m *Machine) tick() (bool, byte) {
 	case program.OP_FUNDING_SUM:
 		funding := m.popFunding()
 		sum, err := funding.Total()
-		if err != nil {
-			return true, EXIT_FAIL_INVALID
+		if errors.Is(err, core.ErrNegativeAmount) {
+			return true, EXIT_FAIL_OVERFLOW, m.overflow(op, err.Error(), funding)
+		} else if err != nil {
+			return true, EXIT_FAIL_INVALID, m.invalidProgramError(err.Error())
 		}
 		m.pushValue(funding)
 		m.pushValue(core.Monetary{
@@ -290,13 +550,16 @@ func (m *Machine) tick() (bool, byte) {
 		funding := m.popFunding()
 		result, err := funding.Reverse()
 		if err != nil {
-			return true, EXIT_FAIL_INVALID
+			return true, EXIT_FAIL_INVALID, m.invalidProgramError(err.Error())
 		}
 		m.pushValue(*result)
 
 	case program.OP_ALLOC:
 		allotment := m.popAllotment()
 		monetary := m.popMonetary()
+		if monetary.Amount.Ltz() {
+			return true, EXIT_FAIL_OVERFLOW, m.overflow(op, "negative monetary amount", monetary, allotment)
+		}
 		total := monetary.Amount
 		parts := allotment.Allocate(total)
 		for i := len(parts) - 1; i >= 0; i-- {
@@ -307,46 +570,211 @@ func (m *Machine) tick() (bool, byte) {
 		}
 
 	case program.OP_REPAY:
-		m.repay(m.popFunding())
+		funding := m.popFunding()
+		err := m.repay(funding)
+		if err != nil {
+			return true, EXIT_FAIL_OVERFLOW, m.overflow(op, err.Error(), funding)
+		}
 
 	case program.OP_SEND:
 		dest := m.popAccount()
 		funding := m.popFunding()
-		m.credit(dest, funding)
+		err := m.credit(dest, funding)
+		if err != nil {
+			return true, EXIT_FAIL_OVERFLOW, m.overflow(op, err.Error(), funding, dest)
+		}
 		for _, part := range funding.Parts {
 			src := part.Account
 			amt := part.Amount
-			if amt == 0 {
+			if amt.IsZero() {
 				continue
 			}
-			m.Postings = append(m.Postings, ledger.Posting{
+			posting := Posting{
 				Source:      string(src),
 				Destination: string(dest),
 				Asset:       string(funding.Asset),
-				Amount:      int64(amt),
-			})
+				Amount:      amt,
+			}
+			m.Usage.Postings++
+			if exit_code, err := m.checkLimits(start, op); err != nil {
+				return true, exit_code, err
+			}
+			m.Postings = append(m.Postings, posting)
+			if m.Tracer != nil {
+				m.Tracer.Posting(posting)
+			}
 		}
 	case program.OP_TX_META:
 		k := m.popString()
 		v := m.popValue()
 		m.TxMeta[string(k)] = v
+		if m.Tracer != nil {
+			m.Tracer.SetTxMeta(string(k), v)
+		}
+	case program.OP_ACCOUNT_META:
+		k := m.popString()
+		a := m.popAccount()
+		v := m.popValue()
+		if _, ok := m.AccountMeta[string(a)]; !ok {
+			m.AccountMeta[string(a)] = map[string]core.Value{}
+		}
+		m.AccountMeta[string(a)][string(k)] = v
+		if m.Tracer != nil {
+			m.Tracer.SetAccountMeta(string(a), string(k), v)
+		}
+	case program.OP_JUMP, program.OP_JUMP_IF_FALSE:
+		if int(m.P)+3 > len(m.Program.Instructions) {
+			m.invalidProgram("truncated operand")
+		}
+		target := uint(binary.LittleEndian.Uint16(m.Program.Instructions[m.P+1 : m.P+3]))
+		if target <= m.P+2 || int(target) > len(m.Program.Instructions) {
+			m.invalidProgram(fmt.Sprintf("invalid jump target: %d", target))
+		}
+		if op == program.OP_JUMP || !m.popBool() {
+			// moved onto the target with the other instructions below
+			m.P = target - 1
+		} else {
+			m.P += 2
+		}
+	case program.OP_EQ:
+		b := m.popValue()
+		a := m.popValue()
+		m.pushValue(core.Bool(core.ValueEquals(a, b)))
+	case program.OP_LT, program.OP_GT:
+		b := m.popValue()
+		a := m.popValue()
+		var cmp int
+		switch a := a.(type) {
+		case core.Number:
+			b, ok := b.(core.Number)
+			if !ok {
+				m.unexpectedType(core.TYPE_NUMBER, b)
+			}
+			if a < b {
+				cmp = -1
+			} else if a > b {
+				cmp = 1
+			}
+		case core.Monetary:
+			b, ok := b.(core.Monetary)
+			if !ok {
+				m.unexpectedType(core.TYPE_MONETARY, b)
+			}
+			if a.Asset != b.Asset {
+				return true, EXIT_FAIL_ASSET_MISMATCH, m.assetMismatch(op, a.Asset, b.Asset, fmt.Sprintf("cannot compare %v with %v", a.Asset, b.Asset))
+			}
+			cmp = a.Amount.Cmp(b.Amount)
+		default:
+			m.unexpectedType(core.TYPE_NUMBER, a)
+		}
+		if op == program.OP_LT {
+			m.pushValue(core.Bool(cmp < 0))
+		} else {
+			m.pushValue(core.Bool(cmp > 0))
+		}
+	case program.OP_NOT:
+		m.pushValue(!m.popBool())
+	case program.OP_AND:
+		b := m.popBool()
+		a := m.popBool()
+		m.pushValue(a && b)
+	case program.OP_OR:
+		b := m.popBool()
+		a := m.popBool()
+		m.pushValue(a || b)
+	case program.OP_FAIL_REASON:
+		reason := m.popString()
+		return true, EXIT_FAIL, &FailError{P: m.P, Reason: string(reason)}
+	case program.OP_ASSERT:
+		reason := m.popString()
+		if !m.popBool() {
+			return true, EXIT_FAIL, &FailError{P: m.P, Reason: string(reason)}
+		}
 
 	default:
-		return true, EXIT_FAIL_INVALID
+		return true, EXIT_FAIL_INVALID, m.invalidProgramError("unknown opcode")
+	}
+
+	if m.Tracer != nil {
+		m.Tracer.AfterInstruction(start, op, append([]core.Value(nil), m.Stack...), balanceDiff(balances_before, m.Balances))
+	}
+
+	if uint(len(m.Stack)) > m.Usage.StackDepth {
+		m.Usage.StackDepth = uint(len(m.Stack))
+	}
+	if exit_code, err := m.checkLimits(start, op); err != nil {
+		m.P = start
+		return true, exit_code, err
 	}
 
 	m.P += 1
 
 	if int(m.P) >= len(m.Program.Instructions) {
-		return true, EXIT_OK
+		return true, EXIT_OK, nil
 	}
 
-	return false, 0
+	return false, 0, nil
+}
+
+// Executes the program and returns the exit code, see ExecuteResult
+// to get everything the execution produced at once
+func (m *Machine) Execute() (exit_code byte, err error) {
+	return m.ExecuteContext(context.Background())
 }
 
-func (m *Machine) Execute() (byte, error) {
-	go m.Printer(m.print_chan)
-	defer close(m.print_chan)
+// Like ExecuteContext, but returns the result of the execution instead of
+// only its exit code. The error is also held by the result.
+func (m *Machine) ExecuteResult(ctx context.Context) (*ExecutionResult, error) {
+	_, err := m.ExecuteContext(ctx)
+	return m.Result(), err
+}
+
+// Like Execute, but stops with ctx.Err() when ctx is done
+func (m *Machine) ExecuteContext(ctx context.Context) (exit_code byte, err error) {
+	// runs last, once the other deferred calls have set the results
+	defer func() {
+		m.exit_code, m.err = exit_code, err
+	}()
+
+	if m.Printer != nil {
+		// the printer may outlive the call when ctx is done, and must not
+		// touch the machine, which may have been reset or reused by then
+		printer, print_chan := m.Printer, make(chan core.Value)
+		m.print_chan = print_chan
+		printer_done := make(chan struct{})
+		go func() {
+			defer close(printer_done)
+			printer(print_chan)
+		}()
+		// the printer must have handled every value when we return,
+		// and sees the channel closed in any case so that it can stop
+		defer func() {
+			close(print_chan)
+			select {
+			case <-printer_done:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	// a malformed program must not crash the caller
+	defer func() {
+		if r := recover(); r != nil {
+			exit_code = EXIT_FAIL_INVALID
+			if e, ok := r.(*InvalidProgramError); ok {
+				err = e
+			} else {
+				e := &InvalidProgramError{
+					P:   m.P,
+					Msg: fmt.Sprint(r),
+				}
+				if int(m.P) < len(m.Program.Instructions) {
+					e.Opcode = m.Program.Instructions[m.P]
+				}
+				err = e
+			}
+		}
+	}()
 
 	if len(m.Resources) != len(m.UnresolvedResources) {
 		return 0, errors.New("resources haven't been initialized")
@@ -355,10 +783,16 @@ func (m *Machine) Execute() (byte, error) {
 	}
 
 	for {
-		finished, exit_code := m.tick()
+		finished, exit_code, err := m.tick(ctx)
 		if finished {
-			if len(m.Stack) != 0 {
-				return EXIT_FAIL_INVALID, nil
+			if err != nil {
+				return exit_code, err
+			}
+			if exit_code == EXIT_OK && len(m.Stack) != 0 {
+				return EXIT_FAIL_INVALID, &InvalidProgramError{
+					P:   m.P,
+					Msg: fmt.Sprintf("stack not empty at end of execution: %v", m.Stack),
+				}
 			} else {
 				return exit_code, nil
 			}
@@ -366,14 +800,30 @@ func (m *Machine) Execute() (byte, error) {
 	}
 }
 
+// Returns the span of source code of the current instruction,
+// which is the failing one when Execute didn't exit with EXIT_OK.
+// Only available when the program was compiled with a source map.
+func (m *Machine) SourceSpan() (program.SourceSpan, bool) {
+	if int(m.P) >= len(m.Program.Instructions) {
+		return program.SourceSpan{}, false
+	}
+	return m.Program.SourceMap.Lookup(int(m.P))
+}
+
 type BalanceRequest struct {
 	Account  string
 	Asset    string
-	Response chan uint64
+	Response chan *core.MonetaryInt
 	Error    error
 }
 
 func (m *Machine) ResolveBalances() (chan BalanceRequest, error) {
+	return m.ResolveBalancesContext(context.Background())
+}
+
+// Like ResolveBalances, but stops when ctx is done: the channel is then closed,
+// after a request carrying ctx.Err() if the caller is still draining it.
+func (m *Machine) ResolveBalancesContext(ctx context.Context) (chan BalanceRequest, error) {
 	if len(m.Resources) != len(m.UnresolvedResources) {
 		return nil, errors.New("tried to resolve balances before resources")
 	}
@@ -384,65 +834,161 @@ func (m *Machine) ResolveBalances() (chan BalanceRequest, error) {
 	ch := make(chan BalanceRequest)
 	go func() {
 		defer close(ch)
-		m.Balances = make(map[string]map[string]uint64)
-		// for every account that we need balances of, check if it's there
-		for addr, needed_assets := range m.Program.NeededBalances {
-			account, ok := m.getResource(addr)
-			if !ok {
-				ch <- BalanceRequest{
-					Error: errors.New("invalid program (resolve balances: invalid address of account)"),
-				}
-				return
+		err := m.resolveBalances(func(account core.Account, asset core.Asset) (*core.MonetaryInt, error) {
+			// buffered so that answering never blocks the caller if we're gone
+			resp := make(chan *core.MonetaryInt, 1)
+			select {
+			case ch <- BalanceRequest{
+				Account:  string(account),
+				Asset:    string(asset),
+				Response: resp,
+			}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
 			}
-			if account, ok := (*account).(core.Account); ok {
-				if string(account) == "world" {
-					continue
-				}
-				m.Balances[string(account)] = make(map[string]uint64)
-				// for every asset, send request
-				for addr := range needed_assets {
-					mon, ok := m.getResource(addr)
-					if !ok {
-						ch <- BalanceRequest{
-							Error: errors.New("invalid program (resolve balances: invalid address of monetary)"),
-						}
-						return
-					}
-					if ha, ok := (*mon).(core.HasAsset); ok {
-						asset := ha.GetAsset()
-						resp := make(chan uint64)
-						ch <- BalanceRequest{
-							Account:  string(account),
-							Asset:    string(asset),
-							Response: resp,
-						}
-						balance, ok := <-resp
-						close(resp)
-						if !ok {
-							ch <- BalanceRequest{
-								Error: errors.New("error on response channel"),
-							}
-							return
-						}
-						m.Balances[string(account)][string(asset)] = balance
-					} else {
-						ch <- BalanceRequest{
-							Error: errors.New("invalid program (resolve balances: not an asset)"),
-						}
-						return
-					}
+			select {
+			case balance, ok := <-resp:
+				if !ok {
+					return nil, errors.New("error on response channel")
 				}
-			} else {
-				ch <- BalanceRequest{
-					Error: errors.New("incorrect program (resolve balances: not an account)"),
+				close(resp)
+				return balance, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		})
+		if err != nil {
+			req := BalanceRequest{
+				Error: err,
+			}
+			// deliver the error to a waiting caller even if ctx is done,
+			// but don't wait for a caller who stopped draining the channel
+			select {
+			case ch <- req:
+			default:
+				select {
+				case ch <- req:
+				case <-ctx.Done():
 				}
-				return
 			}
 		}
 	}()
 	return ch, nil
 }
 
+// Balances needed by a program, as a list of assets by account
+type BalanceQuery map[string][]string
+
+// Returns the (account, asset) pairs whose balances the program needs.
+// Resources must have been resolved.
+func (m *Machine) NeededBalances() (BalanceQuery, error) {
+	if len(m.Resources) != len(m.UnresolvedResources) {
+		return nil, errors.New("tried to resolve balances before resources")
+	}
+	query := BalanceQuery{}
+	for addr, needed_assets := range m.Program.NeededBalances {
+		account, ok := m.getResource(addr)
+		if !ok {
+			return nil, errors.New("invalid program (resolve balances: invalid address of account)")
+		}
+		if account, ok := (*account).(core.Account); ok {
+			if string(account) == "world" {
+				continue
+			}
+			assets := query[string(account)]
+			for addr := range needed_assets {
+				mon, ok := m.getResource(addr)
+				if !ok {
+					return nil, errors.New("invalid program (resolve balances: invalid address of monetary)")
+				}
+				if ha, ok := (*mon).(core.HasAsset); ok {
+					assets = append(assets, string(ha.GetAsset()))
+				} else {
+					return nil, errors.New("invalid program (resolve balances: not an asset)")
+				}
+			}
+			query[string(account)] = assets
+		} else {
+			return nil, errors.New("incorrect program (resolve balances: not an account)")
+		}
+	}
+	for account, assets := range query {
+		sort.Strings(assets)
+		dedup := assets[:0]
+		for i, asset := range assets {
+			if i == 0 || asset != assets[i-1] {
+				dedup = append(dedup, asset)
+			}
+		}
+		query[account] = dedup
+	}
+	return query, nil
+}
+
+// Sets the balances of all the accounts at once, instead of answering the
+// requests of ResolveBalances one by one. They must cover NeededBalances.
+func (m *Machine) SetBalances(balances map[string]map[string]*core.MonetaryInt) error {
+	if len(m.Resources) != len(m.UnresolvedResources) {
+		return errors.New("tried to resolve balances before resources")
+	}
+	if m.set_balance_called {
+		return errors.New("tried to set balances twice")
+	}
+	m.set_balance_called = true
+	return m.resolveBalances(func(account core.Account, asset core.Asset) (*core.MonetaryInt, error) {
+		balance, ok := balances[string(account)][string(asset)]
+		if !ok {
+			return nil, fmt.Errorf("missing %v balance of %v", asset, account)
+		}
+		return balance, nil
+	})
+}
+
+// Fetches the balances needed by the program with get_balance
+func (m *Machine) resolveBalances(get_balance func(core.Account, core.Asset) (*core.MonetaryInt, error)) error {
+	query, err := m.NeededBalances()
+	if err != nil {
+		return err
+	}
+	accounts := make([]string, 0, len(query))
+	for account := range query {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	m.Balances = make(map[string]map[string]*core.MonetaryInt)
+	for _, account := range accounts {
+		m.Balances[account] = make(map[string]*core.MonetaryInt)
+		for _, asset := range query[account] {
+			balance, err := get_balance(core.Account(account), core.Asset(asset))
+			if err != nil {
+				return err
+			}
+			if balance == nil {
+				return fmt.Errorf("invalid balance for %v %v: %v", core.Account(account), asset, balance)
+			}
+			m.Balances[account][asset] = balance
+		}
+	}
+	for addr, res := range m.UnresolvedResources {
+		if res, ok := res.(program.AccountBalance); ok {
+			account, ok := m.Resources[res.Account].(core.Account)
+			if !ok {
+				return errors.New("invalid program (resolve balances: balance of a non-account)")
+			}
+			asset, ok := m.Resources[res.Asset].(core.Asset)
+			if !ok {
+				return errors.New("invalid program (resolve balances: balance of a non-asset)")
+			}
+			balance, ok := m.Balances[string(account)][string(asset)]
+			if !ok {
+				return fmt.Errorf("cannot read the %v balance of %v", asset, account)
+			}
+			m.Resources[addr] = core.Monetary{Asset: asset, Amount: balance}
+		}
+	}
+	return nil
+}
+
 type MetadataRequest struct {
 	Account  string
 	Key      string
@@ -451,6 +997,12 @@ type MetadataRequest struct {
 }
 
 func (m *Machine) ResolveResources() (chan MetadataRequest, error) {
+	return m.ResolveResourcesContext(context.Background())
+}
+
+// Like ResolveResources, but stops when ctx is done: the channel is then closed,
+// after a request carrying ctx.Err() if the caller is still draining it.
+func (m *Machine) ResolveResourcesContext(ctx context.Context) (chan MetadataRequest, error) {
 	if m.resolve_called {
 		return nil, errors.New("tried to call ResolveResources twice")
 	}
@@ -458,64 +1010,155 @@ func (m *Machine) ResolveResources() (chan MetadataRequest, error) {
 	ch := make(chan MetadataRequest)
 	go func() {
 		defer close(ch)
-		for len(m.Resources) != len(m.UnresolvedResources) {
-			idx := len(m.Resources)
-			res := m.UnresolvedResources[idx]
-			var val core.Value
-			switch res := res.(type) {
-			case program.Constant:
-				val = res.Inner
-			case program.Parameter:
-				var ok bool
-				val, ok = m.Vars[res.Name]
-				if !ok {
-					ch <- MetadataRequest{
-						Error: fmt.Errorf("missing variable: %v", res.Name),
-					}
-					return
-				}
-			case program.Metadata:
-				source_account, ok := m.getResource(res.SourceAccount)
-				if !ok {
-					ch <- MetadataRequest{
-						Error: errors.New("tried to request metadata of an account which has not yet been solved"),
-					}
-					return
-				}
-				if (*source_account).GetType() != core.TYPE_ACCOUNT {
-					ch <- MetadataRequest{
-						Error: fmt.Errorf("tried to request metadata on wrong entity: %v instead of ACCOUNT", (*source_account).GetType()),
-					}
-					return
-				}
-				account := (*source_account).(core.Account)
-				resp := make(chan core.Value)
-				ch <- MetadataRequest{
-					Account:  string(account),
-					Key:      res.Key,
-					Response: resp,
-				}
-				val = <-resp
+		err := m.resolveResources(func(account core.Account, key string) (core.Value, error) {
+			// buffered so that answering never blocks the caller if we're gone
+			resp := make(chan core.Value, 1)
+			select {
+			case ch <- MetadataRequest{
+				Account:  string(account),
+				Key:      key,
+				Response: resp,
+			}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			select {
+			case val := <-resp:
 				close(resp)
-				if val == nil {
-					ch <- MetadataRequest{
-						Error: errors.New("tried to set nil as resource"),
-					}
-					return
-				}
-				if val.GetType() != res.Typ {
-					ch <- MetadataRequest{
-						Error: fmt.Errorf("wrong type: expected %v, got %v", res.Typ, val.GetType()),
-					}
-					return
+				return val, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		})
+		if err != nil {
+			req := MetadataRequest{
+				Error: err,
+			}
+			// deliver the error to a waiting caller even if ctx is done,
+			// but don't wait for a caller who stopped draining the channel
+			select {
+			case ch <- req:
+			default:
+				select {
+				case ch <- req:
+				case <-ctx.Done():
 				}
 			}
-			m.Resources = append(m.Resources, val)
 		}
 	}()
 	return ch, nil
 }
 
+// Resolves constants, variables and metadata, fetching the latter with get_metadata.
+// Balances read by the program are only resolved along with the needed balances.
+func (m *Machine) resolveResources(get_metadata func(core.Account, string) (core.Value, error)) error {
+	for len(m.Resources) != len(m.UnresolvedResources) {
+		idx := len(m.Resources)
+		res := m.UnresolvedResources[idx]
+		var val core.Value
+		switch res := res.(type) {
+		case program.Constant:
+			val = res.Inner
+		case program.Parameter:
+			var ok bool
+			val, ok = m.Vars[res.Name]
+			if !ok {
+				return fmt.Errorf("missing variable: %v", res.Name)
+			}
+		case program.Metadata:
+			source_account, ok := m.getResource(res.SourceAccount)
+			if !ok {
+				return errors.New("tried to request metadata of an account which has not yet been solved")
+			}
+			if (*source_account).GetType() != core.TYPE_ACCOUNT {
+				return fmt.Errorf("tried to request metadata on wrong entity: %v instead of ACCOUNT", (*source_account).GetType())
+			}
+			account := (*source_account).(core.Account)
+			var err error
+			val, err = get_metadata(account, res.Key)
+			if err != nil {
+				return err
+			}
+			if val == nil {
+				return errors.New("tried to set nil as resource")
+			}
+			if val.GetType() != res.Typ {
+				return fmt.Errorf("wrong type: expected %v, got %v", res.Typ, val.GetType())
+			}
+		}
+		m.Resources = append(m.Resources, val)
+	}
+	return nil
+}
+
+// Metadata lookups, as a list of keys by account
+type MetadataQuery map[string][]string
+
+// Resolves resources like ResolveResources, but looks up metadata in waves of
+// independent lookups instead of one at a time: fetch is called once per wave
+// and must return the values of all the metadata in the query, by account and key.
+func (m *Machine) ResolveResourcesInWaves(fetch func(MetadataQuery) (map[string]map[string]core.Value, error)) error {
+	if m.resolve_called {
+		return errors.New("tried to call ResolveResources twice")
+	}
+	m.resolve_called = true
+
+	waves, err := m.Program.MetadataWaves()
+	if err != nil {
+		return err
+	}
+	values := make([]core.Value, len(m.UnresolvedResources))
+	for i, res := range m.UnresolvedResources {
+		switch res := res.(type) {
+		case program.Constant:
+			values[i] = res.Inner
+		case program.Parameter:
+			val, ok := m.Vars[res.Name]
+			if !ok {
+				return fmt.Errorf("missing variable: %v", res.Name)
+			}
+			values[i] = val
+		}
+	}
+
+	for _, wave := range waves {
+		query := MetadataQuery{}
+		seen := map[string]map[string]struct{}{}
+		for _, addr := range wave {
+			res := m.UnresolvedResources[addr].(program.Metadata)
+			account, ok := values[res.SourceAccount].(core.Account)
+			if !ok {
+				return fmt.Errorf("tried to request metadata on wrong entity: %v instead of ACCOUNT", values[res.SourceAccount])
+			}
+			if _, ok := seen[string(account)]; !ok {
+				seen[string(account)] = map[string]struct{}{}
+			}
+			if _, ok := seen[string(account)][res.Key]; !ok {
+				seen[string(account)][res.Key] = struct{}{}
+				query[string(account)] = append(query[string(account)], res.Key)
+			}
+		}
+		result, err := fetch(query)
+		if err != nil {
+			return err
+		}
+		for _, addr := range wave {
+			res := m.UnresolvedResources[addr].(program.Metadata)
+			account := values[res.SourceAccount].(core.Account)
+			val, ok := result[string(account)][res.Key]
+			if !ok || val == nil {
+				return fmt.Errorf("missing metadata %q of %v", res.Key, account)
+			}
+			if val.GetType() != res.Typ {
+				return fmt.Errorf("wrong type: expected %v, got %v", res.Typ, val.GetType())
+			}
+			values[addr] = val
+		}
+	}
+	m.Resources = values
+	return nil
+}
+
 func (m *Machine) SetVars(vars map[string]core.Value) error {
 	v, err := m.Program.ParseVariables(vars)
 	if err != nil {
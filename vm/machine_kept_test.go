@@ -3,7 +3,6 @@ package vm
 import (
 	"testing"
 
-	ledger "github.com/numary/ledger/pkg/core"
 	"github.com/numary/machine/core"
 )
 
@@ -23,29 +22,29 @@ send [GEM 100] (
 )`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"a": {
-				"GEM": 1,
+				"GEM": core.NewMonetaryInt(1),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "GEM",
-					Amount:      1,
+					Amount:      core.NewMonetaryInt(1),
 					Source:      "a",
 					Destination: "x",
 				},
 				{
 					Asset:       "GEM",
-					Amount:      24,
+					Amount:      core.NewMonetaryInt(24),
 					Source:      "world",
 					Destination: "x",
 				},
 				{
 					Asset:       "GEM",
-					Amount:      25,
+					Amount:      core.NewMonetaryInt(25),
 					Source:      "world",
 					Destination: "y",
 				},
@@ -80,53 +79,53 @@ func TestKeptComplex(t *testing.T) {
 		)`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"foo": {
-				"GEM": 20,
+				"GEM": core.NewMonetaryInt(20),
 			},
 			"bar": {
-				"GEM": 40,
+				"GEM": core.NewMonetaryInt(40),
 			},
 			"baz": {
-				"GEM": 40,
+				"GEM": core.NewMonetaryInt(40),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "GEM",
-					Amount:      2,
+					Amount:      core.NewMonetaryInt(2),
 					Source:      "foo",
 					Destination: "arst",
 				},
 				{
 					Asset:       "GEM",
-					Amount:      18,
+					Amount:      core.NewMonetaryInt(18),
 					Source:      "foo",
 					Destination: "thing",
 				},
 				{
 					Asset:       "GEM",
-					Amount:      24,
+					Amount:      core.NewMonetaryInt(24),
 					Source:      "bar",
 					Destination: "thing",
 				},
 				{
 					Asset:       "GEM",
-					Amount:      16,
+					Amount:      core.NewMonetaryInt(16),
 					Source:      "bar",
 					Destination: "qux",
 				},
 				{
 					Asset:       "GEM",
-					Amount:      4,
+					Amount:      core.NewMonetaryInt(4),
 					Source:      "baz",
 					Destination: "qux",
 				},
 				{
 					Asset:       "GEM",
-					Amount:      25,
+					Amount:      core.NewMonetaryInt(25),
 					Source:      "baz",
 					Destination: "quz",
 				},
@@ -1,16 +1,19 @@
 package vm
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"os"
+	"reflect"
 	"strings"
-	"sync"
 	"testing"
 
-	ledger "github.com/numary/ledger/pkg/core"
 	"github.com/numary/machine/core"
 	"github.com/numary/machine/script/compiler"
+	"github.com/numary/machine/vm/program"
 )
 
 const (
@@ -19,7 +22,7 @@ const (
 
 type CaseResult struct {
 	Printed  []core.Value
-	Postings []ledger.Posting
+	Postings []Posting
 	Metadata map[string]core.Value
 	ExitCode byte
 	Error    string
@@ -42,7 +45,7 @@ func test(
 	code string,
 	variables map[string]core.Value,
 	meta map[string]map[string]core.Value,
-	balances map[string]map[string]uint64,
+	balances map[string]map[string]*core.MonetaryInt,
 	expected CaseResult,
 ) {
 	testimpl(t, code, expected, func(m *Machine) (byte, error) {
@@ -85,7 +88,7 @@ func testJSON(
 	code string,
 	variables string,
 	meta map[string]map[string]core.Value,
-	balances map[string]map[string]uint64,
+	balances map[string]map[string]*core.MonetaryInt,
 	expected CaseResult,
 ) {
 	testimpl(t, code, expected, func(m *Machine) (byte, error) {
@@ -142,18 +145,16 @@ func testimpl(t *testing.T, code string, expected CaseResult, exec func(*Machine
 		return
 	}
 
-	printed := []core.Value{}
-
-	var wg sync.WaitGroup
-	wg.Add(1)
+	err = program.Verify(p)
+	if err != nil {
+		t.Error(fmt.Errorf("verification error: %v", err))
+		return
+	}
 
 	machine := NewMachine(p)
-	machine.Debug = DEBUG
-	machine.Printer = func(c chan core.Value) {
-		for v := range c {
-			printed = append(printed, v)
-		}
-		wg.Done()
+	if DEBUG {
+		machine.Tracer = NewTextTracer(os.Stdout)
+		machine.Output = os.Stdout
 	}
 	exit_code, err := exec(machine)
 
@@ -161,6 +162,9 @@ func testimpl(t *testing.T, code string, expected CaseResult, exec func(*Machine
 		if !strings.Contains(err.Error(), expected.Error) {
 			t.Error(fmt.Errorf("unexpected execution error: %v", err))
 			return
+		} else if expected.ExitCode != 0 && exit_code != expected.ExitCode {
+			t.Error(fmt.Errorf("unexpected exit code: %v", exit_code))
+			return
 		} else {
 			return
 		}
@@ -185,7 +189,7 @@ func testimpl(t *testing.T, code string, expected CaseResult, exec func(*Machine
 		return
 	} else {
 		for i := range machine.Postings {
-			if machine.Postings[i] != expected.Postings[i] {
+			if !postingEquals(machine.Postings[i], expected.Postings[i]) {
 				t.Error(fmt.Errorf("unexpected postings output: %v", machine.Postings[i]))
 				return
 			}
@@ -197,7 +201,7 @@ func testimpl(t *testing.T, code string, expected CaseResult, exec func(*Machine
 		return
 	} else {
 		for k := range machine.TxMeta {
-			if machine.TxMeta[k] != expected.Metadata[k] {
+			if !core.ValueEquals(machine.TxMeta[k], expected.Metadata[k]) {
 				t.Error(fmt.Errorf(
 					"unexpected metadata output value for key %s, got: %v, expected: %v",
 					k,
@@ -209,14 +213,13 @@ func testimpl(t *testing.T, code string, expected CaseResult, exec func(*Machine
 		}
 	}
 
-	wg.Wait()
-
+	printed := machine.Printed
 	if len(printed) != len(expected.Printed) {
 		t.Error(fmt.Errorf("unexpected print output: %v", printed))
 		return
 	} else {
 		for i := range printed {
-			if printed[i] != expected.Printed[i] {
+			if !core.ValueEquals(printed[i], expected.Printed[i]) {
 				t.Error(fmt.Errorf("unexpected print output: %v", printed[i]))
 				return
 			}
@@ -224,15 +227,22 @@ func testimpl(t *testing.T, code string, expected CaseResult, exec func(*Machine
 	}
 }
 
+func postingEquals(lhs, rhs Posting) bool {
+	return lhs.Source == rhs.Source &&
+		lhs.Destination == rhs.Destination &&
+		lhs.Asset == rhs.Asset &&
+		lhs.Amount.Equal(rhs.Amount)
+}
+
 func TestFail(t *testing.T) {
 	test(t,
 		"fail",
 		map[string]core.Value{},
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{},
+		map[string]map[string]*core.MonetaryInt{},
 		CaseResult{
 			Printed:  []core.Value{},
-			Postings: []ledger.Posting{},
+			Postings: []Posting{},
 			ExitCode: EXIT_FAIL,
 		},
 	)
@@ -243,15 +253,104 @@ func TestPrint(t *testing.T) {
 		"print 29 + 15 - 2",
 		map[string]core.Value{},
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{},
+		map[string]map[string]*core.MonetaryInt{},
 		CaseResult{
 			Printed:  []core.Value{core.Number(42)},
-			Postings: []ledger.Posting{},
+			Postings: []Posting{},
 			ExitCode: EXIT_OK,
 		},
 	)
 }
 
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestPrintOutput(t *testing.T) {
+	p, err := compiler.Compile(`print 1
+	print "two"
+	print [COIN 3]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	execute := func(m *Machine) (byte, error) {
+		{
+			ch, _ := m.ResolveResources()
+			for range ch {
+			}
+		}
+		{
+			ch, _ := m.ResolveBalances()
+			for range ch {
+			}
+		}
+		return m.Execute()
+	}
+
+	var out strings.Builder
+	var printer_out []core.Value
+	m := NewMachine(p)
+	m.Output = &out
+	m.Printer = func(c chan core.Value) {
+		for v := range c {
+			printer_out = append(printer_out, v)
+		}
+	}
+	exit_code, err := execute(m)
+	if err != nil || exit_code != EXIT_OK {
+		t.Fatalf("unexpected result: %v, %v", exit_code, err)
+	}
+	if out.String() != "OUT: 1\nOUT: \"two\"\nOUT: [COIN 3]\n" {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+	if len(m.Printed) != 3 || !core.ValueEquals(m.Printed[1], core.String("two")) {
+		t.Fatalf("unexpected printed values: %v", m.Printed)
+	}
+	// the printer has handled every value when Execute returns
+	if len(printer_out) != 3 {
+		t.Fatalf("unexpected printer output: %v", printer_out)
+	}
+
+	m = NewMachine(p)
+	m.Output = failingWriter{}
+	exit_code, err = execute(m)
+	if exit_code != EXIT_FAIL || err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("unexpected result: %v, %v", exit_code, err)
+	}
+}
+
+func TestIntegerUnderflow(t *testing.T) {
+	test(t,
+		"print 1 - 2",
+		map[string]core.Value{},
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{},
+		CaseResult{
+			Printed:  []core.Value{},
+			Postings: []Posting{},
+			ExitCode: EXIT_FAIL_OVERFLOW,
+			Error:    "integer underflow at instruction 18 (OP_ISUB 1, 2)",
+		},
+	)
+}
+
+func TestIntegerOverflow(t *testing.T) {
+	test(t,
+		"print 18446744073709551615 + 1",
+		map[string]core.Value{},
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{},
+		CaseResult{
+			Printed:  []core.Value{},
+			Postings: []Posting{},
+			ExitCode: EXIT_FAIL_OVERFLOW,
+			Error:    "integer overflow",
+		},
+	)
+}
+
 func TestSend(t *testing.T) {
 	test(t,
 		`send [EUR/2 100] (
@@ -260,20 +359,59 @@ func TestSend(t *testing.T) {
 		)`,
 		map[string]core.Value{},
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"alice": {
-				"EUR/2": 100,
+				"EUR/2": core.NewMonetaryInt(100),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "EUR/2",
-					Amount:      100,
+					Amount:      core.NewMonetaryInt(100),
+					Source:      "alice",
+					Destination: "bob",
+				},
+			},
+			ExitCode: EXIT_OK,
+		},
+	)
+}
+
+func TestSendBigAmount(t *testing.T) {
+	balance, _ := core.ParseMonetaryInt("200000000000000000000000000000")
+	half, _ := core.ParseMonetaryInt("50000000000000000000000000000")
+	test(t,
+		`send [ETH/18 100000000000000000000000000000] (
+			source=@alice
+			destination={
+				50% to @bob
+				remaining to @carol
+			}
+		)`,
+		map[string]core.Value{},
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{
+			"alice": {
+				"ETH/18": balance,
+			},
+		},
+		CaseResult{
+			Printed: []core.Value{},
+			Postings: []Posting{
+				{
+					Asset:       "ETH/18",
+					Amount:      half,
 					Source:      "alice",
 					Destination: "bob",
 				},
+				{
+					Asset:       "ETH/18",
+					Amount:      half,
+					Source:      "alice",
+					Destination: "carol",
+				},
 			},
 			ExitCode: EXIT_OK,
 		},
@@ -295,17 +433,17 @@ func TestVariables(t *testing.T) {
 			"driver": core.Account("users:002"),
 		},
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"users:001": {
-				"EUR/2": 1000,
+				"EUR/2": core.NewMonetaryInt(1000),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "EUR/2",
-					Amount:      999,
+					Amount:      core.NewMonetaryInt(999),
 					Source:      "users:001",
 					Destination: "users:002",
 				},
@@ -333,17 +471,17 @@ func TestVariablesJSON(t *testing.T) {
 			"description": "midnight ride"
 		}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"users:001": {
-				"EUR/2": 1000,
+				"EUR/2": core.NewMonetaryInt(1000),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "EUR/2",
-					Amount:      999,
+					Amount:      core.NewMonetaryInt(999),
 					Source:      "users:001",
 					Destination: "users:002",
 				},
@@ -356,6 +494,288 @@ func TestVariablesJSON(t *testing.T) {
 	)
 }
 
+func TestIf(t *testing.T) {
+	script := `vars {
+		monetary $amount
+		bool $waived
+	}
+	if $waived || $amount < [EUR/2 1000] {
+		set_tx_meta("fee", "waived")
+	} else if $amount <= [EUR/2 10000] {
+		send [EUR/2 100] (
+			source = @users:001
+			destination = @fees
+		)
+	} else {
+		fail
+	}`
+	for _, c := range []struct {
+		Variables string
+		Expected  CaseResult
+	}{
+		{
+			Variables: `{"amount": {"asset": "EUR/2", "amount": 5000}, "waived": true}`,
+			Expected: CaseResult{
+				Printed:  []core.Value{},
+				Postings: []Posting{},
+				Metadata: map[string]core.Value{
+					"fee": core.String("waived"),
+				},
+				ExitCode: EXIT_OK,
+			},
+		},
+		{
+			Variables: `{"amount": {"asset": "EUR/2", "amount": 10000}, "waived": false}`,
+			Expected: CaseResult{
+				Printed: []core.Value{},
+				Postings: []Posting{
+					{
+						Asset:       "EUR/2",
+						Amount:      core.NewMonetaryInt(100),
+						Source:      "users:001",
+						Destination: "fees",
+					},
+				},
+				ExitCode: EXIT_OK,
+			},
+		},
+		{
+			Variables: `{"amount": {"asset": "EUR/2", "amount": 10001}, "waived": false}`,
+			Expected: CaseResult{
+				Printed:  []core.Value{},
+				Postings: []Posting{},
+				ExitCode: EXIT_FAIL,
+			},
+		},
+	} {
+		testJSON(t, script, c.Variables,
+			map[string]map[string]core.Value{},
+			map[string]map[string]*core.MonetaryInt{
+				"users:001": {
+					"EUR/2": core.NewMonetaryInt(1000),
+				},
+			},
+			c.Expected,
+		)
+	}
+}
+
+func TestAssert(t *testing.T) {
+	script := `vars {
+		monetary $amount
+	}
+	assert $amount <= [USD/2 100000], "amount above limit"
+	print $amount`
+	testJSON(t, script, `{"amount": {"asset": "USD/2", "amount": 100000}}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{},
+		CaseResult{
+			Printed: []core.Value{
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(100000)},
+			},
+			Postings: []Posting{},
+			ExitCode: EXIT_OK,
+		},
+	)
+	testJSON(t, script, `{"amount": {"asset": "USD/2", "amount": 100001}}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{},
+		CaseResult{
+			ExitCode: EXIT_FAIL,
+			Error:    "amount above limit",
+		},
+	)
+}
+
+func TestFailReason(t *testing.T) {
+	p, err := compiler.Compile(`print "before"
+	fail "out of stock"
+	print "after"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Run(context.Background(), p, map[string]core.Value{}, mapStore{})
+	if res.ExitCode != EXIT_FAIL {
+		t.Fatalf("unexpected exit code: %v", res.ExitCode)
+	}
+	if !errors.Is(err, ErrFailed) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var fail_err *FailError
+	if !errors.As(err, &fail_err) || fail_err.Reason != "out of stock" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.FailReason != "out of stock" || len(res.Printed) != 1 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+}
+
+func TestCompareDifferentAssets(t *testing.T) {
+	testJSON(t,
+		`vars {
+			monetary $amount
+		}
+		if $amount == [EUR/2 100] {
+			print "equal"
+		}
+		if $amount < [EUR/2 100] {
+			print "less"
+		}`,
+		`{"amount": {"asset": "USD/2", "amount": 10}}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{},
+		CaseResult{
+			ExitCode: EXIT_FAIL_ASSET_MISMATCH,
+			Error:    "asset mismatch at instruction",
+		},
+	)
+}
+
+func TestMonetaryArithmetic(t *testing.T) {
+	testJSON(t,
+		`vars {
+			monetary $amount
+		}
+		print $amount + [USD/2 5]
+		print $amount - [USD/2 5]
+		print $amount * 3
+		print $amount * 1/3 rounded down
+		print $amount * 1/3 rounded up
+		print $amount * 50% rounded up`,
+		`{"amount": {"asset": "USD/2", "amount": 100}}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{},
+		CaseResult{
+			Printed: []core.Value{
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(105)},
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(95)},
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(300)},
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(33)},
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(34)},
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(50)},
+			},
+			Postings: []Posting{},
+			ExitCode: EXIT_OK,
+		},
+	)
+}
+
+func TestSendFee(t *testing.T) {
+	testJSON(t,
+		`vars {
+			monetary $amount
+		}
+		send $amount - $amount * 2.5% rounded up (
+			source = @users:001
+			destination = @merchant
+		)
+		send $amount * 2.5% rounded up (
+			source = @users:001
+			destination = @fees
+		)`,
+		`{"amount": {"asset": "EUR/2", "amount": 1001}}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{
+			"users:001": {
+				"EUR/2": core.NewMonetaryInt(2000),
+			},
+		},
+		CaseResult{
+			Printed: []core.Value{},
+			Postings: []Posting{
+				{
+					Asset:       "EUR/2",
+					Amount:      core.NewMonetaryInt(975),
+					Source:      "users:001",
+					Destination: "merchant",
+				},
+				{
+					Asset:       "EUR/2",
+					Amount:      core.NewMonetaryInt(26),
+					Source:      "users:001",
+					Destination: "fees",
+				},
+			},
+			ExitCode: EXIT_OK,
+		},
+	)
+}
+
+func TestMonetaryArithmeticNegative(t *testing.T) {
+	test(t,
+		`print balance(@a, USD/2) + [USD/2 10]
+		print balance(@a, USD/2) - [USD/2 5]
+		print [USD/2 5] - [USD/2 20]
+		print balance(@a, USD/2) * 3
+		print balance(@a, USD/2) * 1/3 rounded down
+		print balance(@a, USD/2) * 1/3 rounded up`,
+		map[string]core.Value{},
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{
+			"a": {
+				"USD/2": core.NewMonetaryInt(-10),
+			},
+		},
+		CaseResult{
+			Printed: []core.Value{
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(0)},
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(-15)},
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(-15)},
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(-30)},
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(-4)},
+				core.Monetary{Asset: "USD/2", Amount: core.NewMonetaryInt(-3)},
+			},
+			Postings: []Posting{},
+			ExitCode: EXIT_OK,
+		},
+	)
+}
+
+func TestMonetaryArithmeticFailures(t *testing.T) {
+	testJSON(t,
+		`vars {
+			monetary $amount
+		}
+		send $amount - [USD/2 5] (
+			source = @world
+			destination = @a
+		)`,
+		`{"amount": {"asset": "USD/2", "amount": 4}}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{},
+		CaseResult{
+			ExitCode: EXIT_FAIL_OVERFLOW,
+			Error:    "negative monetary amount",
+		},
+	)
+	testJSON(t,
+		`vars {
+			monetary $amount
+		}
+		print $amount + [USD/2 5]`,
+		`{"amount": {"asset": "EUR/2", "amount": 4}}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{},
+		CaseResult{
+			ExitCode: EXIT_FAIL_ASSET_MISMATCH,
+			Error:    "(OP_MONETARY_ADD): EUR/2 + USD/2",
+		},
+	)
+	testJSON(t,
+		`vars {
+			monetary $amount
+		}
+		print $amount - [USD/2 5]`,
+		`{"amount": {"asset": "EUR/2", "amount": 4}}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{},
+		CaseResult{
+			ExitCode: EXIT_FAIL_ASSET_MISMATCH,
+			Error:    "(OP_MONETARY_SUB): EUR/2 - USD/2",
+		},
+	)
+}
+
 func TestVariablesJSONInvalid(t *testing.T) {
 	testJSON(t,
 		`vars {
@@ -372,7 +792,7 @@ func TestVariablesJSONInvalid(t *testing.T) {
 			"p": "3/2"
 		}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{},
+		map[string]map[string]*core.MonetaryInt{},
 		CaseResult{
 			Error: "portion must be",
 		},
@@ -399,26 +819,26 @@ send [GEM 15] (
 			"seller": "users:002"
 		}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"users:001": {
-				"GEM": 3,
+				"GEM": core.NewMonetaryInt(3),
 			},
 			"payments:001": {
-				"GEM": 12,
+				"GEM": core.NewMonetaryInt(12),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "GEM",
-					Amount:      3,
+					Amount:      core.NewMonetaryInt(3),
 					Source:      "users:001",
 					Destination: "users:002",
 				},
 				{
 					Asset:       "GEM",
-					Amount:      12,
+					Amount:      core.NewMonetaryInt(12),
 					Source:      "payments:001",
 					Destination: "users:002",
 				},
@@ -447,29 +867,29 @@ send [GEM 15] (
 			"driver": "users:002"
 		}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"users:001": {
-				"GEM": 15,
+				"GEM": core.NewMonetaryInt(15),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "GEM",
-					Amount:      13,
+					Amount:      core.NewMonetaryInt(13),
 					Source:      "users:001",
 					Destination: "users:002",
 				},
 				{
 					Asset:       "GEM",
-					Amount:      1,
+					Amount:      core.NewMonetaryInt(1),
 					Source:      "users:001",
 					Destination: "a",
 				},
 				{
 					Asset:       "GEM",
-					Amount:      1,
+					Amount:      core.NewMonetaryInt(1),
 					Source:      "users:001",
 					Destination: "b",
 				},
@@ -496,23 +916,23 @@ send [GEM 15] (
 			"p": "15%"
 		}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"a": {
-				"GEM": 15,
+				"GEM": core.NewMonetaryInt(15),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "GEM",
-					Amount:      13,
+					Amount:      core.NewMonetaryInt(13),
 					Source:      "a",
 					Destination: "b",
 				},
 				{
 					Asset:       "GEM",
-					Amount:      2,
+					Amount:      core.NewMonetaryInt(2),
 					Source:      "a",
 					Destination: "c",
 				},
@@ -530,17 +950,17 @@ func TestSendAll(t *testing.T) {
 )`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"users:001": {
-				"USD/2": 17,
+				"USD/2": core.NewMonetaryInt(17),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "USD/2",
-					Amount:      17,
+					Amount:      core.NewMonetaryInt(17),
 					Source:      "users:001",
 					Destination: "platform",
 				},
@@ -562,26 +982,26 @@ func TestSendAllMulti(t *testing.T) {
 		  `,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"users:001:wallet": {
-				"USD/2": 19,
+				"USD/2": core.NewMonetaryInt(19),
 			},
 			"users:001:credit": {
-				"USD/2": 22,
+				"USD/2": core.NewMonetaryInt(22),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "USD/2",
-					Amount:      19,
+					Amount:      core.NewMonetaryInt(19),
 					Source:      "users:001:wallet",
 					Destination: "platform",
 				},
 				{
 					Asset:       "USD/2",
-					Amount:      22,
+					Amount:      core.NewMonetaryInt(22),
 					Source:      "users:001:credit",
 					Destination: "platform",
 				},
@@ -611,22 +1031,120 @@ send [GEM 16] (
 			"seller": "users:002"
 		}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"users:001": {
-				"GEM": 3,
+				"GEM": core.NewMonetaryInt(3),
 			},
 			"payments:001": {
-				"GEM": 12,
+				"GEM": core.NewMonetaryInt(12),
 			},
 		},
 		CaseResult{
 			Printed:  []core.Value{},
-			Postings: []ledger.Posting{},
+			Postings: []Posting{},
 			ExitCode: EXIT_FAIL_INSUFFICIENT_FUNDS,
+			Error:    "insufficient funds",
 		},
 	)
 }
 
+func TestFailingSourceSpan(t *testing.T) {
+	code := `send [GEM 10] (
+	source = @world
+	destination = @users:001
+)
+send [GEM 20] (
+	source = {
+		@users:001
+		@payments:001
+	}
+	destination = @users:002
+)`
+	p, err := compiler.Compile(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMachine(p)
+	{
+		ch, _ := m.ResolveResources()
+		for range ch {
+		}
+	}
+	{
+		ch, _ := m.ResolveBalances()
+		for req := range ch {
+			req.Response <- core.NewMonetaryInt(3)
+		}
+	}
+	exit_code, err := m.Execute()
+	if !errors.Is(err, ErrInsufficientFunds) || exit_code != EXIT_FAIL_INSUFFICIENT_FUNDS {
+		t.Fatalf("unexpected result: %v, %v", exit_code, err)
+	}
+	span, ok := m.SourceSpan()
+	if !ok {
+		t.Fatal("expected a source span")
+	}
+	expected := program.SourceSpan{Startl: 6, Startc: 10, Endl: 9, Endc: 2}
+	if span != expected {
+		t.Fatalf("unexpected span: %v", span)
+	}
+	text := span.Text(code)
+	if !strings.HasPrefix(text, "{") || !strings.Contains(text, "@payments:001") {
+		t.Fatalf("unexpected source: %q", text)
+	}
+}
+
+func TestInsufficientFundsError(t *testing.T) {
+	p, err := compiler.Compile(`send [GEM 16] (
+	source = {
+		@users:001
+		max [GEM 2] from @users:002
+		@payments:001
+	}
+	destination = @users:003
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMachine(p)
+	{
+		ch, _ := m.ResolveResources()
+		for range ch {
+		}
+	}
+	{
+		ch, _ := m.ResolveBalances()
+		for req := range ch {
+			req.Response <- core.NewMonetaryInt(5)
+		}
+	}
+	exit_code, err := m.Execute()
+	if exit_code != EXIT_FAIL_INSUFFICIENT_FUNDS {
+		t.Fatalf("unexpected exit code: %v", exit_code)
+	}
+	var exec_err *ExecutionError
+	if !errors.As(err, &exec_err) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exec_err.Asset != "GEM" ||
+		!exec_err.Requested.Equal(core.NewMonetaryInt(16)) ||
+		!exec_err.Available.Equal(core.NewMonetaryInt(12)) {
+		t.Fatalf("unexpected error: %v", exec_err)
+	}
+	expected := []core.Account{"users:001", "users:002", "payments:001"}
+	if len(exec_err.Accounts) != len(expected) {
+		t.Fatalf("unexpected accounts: %v", exec_err.Accounts)
+	}
+	for i := range expected {
+		if exec_err.Accounts[i] != expected[i] {
+			t.Fatalf("unexpected accounts: %v", exec_err.Accounts)
+		}
+	}
+	if !strings.Contains(err.Error(), "requested [GEM 16] from @users:001, @users:002, @payments:001, but only [GEM 12] available") {
+		t.Fatalf("unexpected error message: %v", err)
+	}
+}
+
 func TestWorldSource(t *testing.T) {
 	testJSON(t,
 		`send [GEM 15] (
@@ -638,23 +1156,23 @@ func TestWorldSource(t *testing.T) {
 		)`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"a": {
-				"GEM": 1,
+				"GEM": core.NewMonetaryInt(1),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "GEM",
-					Amount:      1,
+					Amount:      core.NewMonetaryInt(1),
 					Source:      "a",
 					Destination: "b",
 				},
 				{
 					Asset:       "GEM",
-					Amount:      14,
+					Amount:      core.NewMonetaryInt(14),
 					Source:      "world",
 					Destination: "b",
 				},
@@ -664,6 +1182,90 @@ func TestWorldSource(t *testing.T) {
 	)
 }
 
+func TestOverdraft(t *testing.T) {
+	testJSON(t,
+		`send [GEM 100] (
+			source = {
+				@a allowing overdraft up to [GEM 50]
+				@b allowing unbounded overdraft
+			}
+			destination = @c
+		)`,
+		`{}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{
+			"a": {
+				"GEM": core.NewMonetaryInt(30),
+			},
+			"b": {
+				"GEM": core.NewMonetaryInt(5),
+			},
+		},
+		CaseResult{
+			Printed: []core.Value{},
+			Postings: []Posting{
+				{
+					Asset:       "GEM",
+					Amount:      core.NewMonetaryInt(80),
+					Source:      "a",
+					Destination: "c",
+				},
+				{
+					Asset:       "GEM",
+					Amount:      core.NewMonetaryInt(20),
+					Source:      "b",
+					Destination: "c",
+				},
+			},
+			ExitCode: EXIT_OK,
+		},
+	)
+}
+
+func TestOverdraftInsufficientFunds(t *testing.T) {
+	testJSON(t,
+		`send [GEM 100] (
+			source = @a allowing overdraft up to [GEM 50]
+			destination = @c
+		)`,
+		`{}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{
+			"a": {
+				"GEM": core.NewMonetaryInt(30),
+			},
+		},
+		CaseResult{
+			Printed:  []core.Value{},
+			Postings: []Posting{},
+			ExitCode: EXIT_FAIL_INSUFFICIENT_FUNDS,
+			Error:    "insufficient funds",
+		},
+	)
+}
+
+func TestOverdraftAlreadyOverdrawn(t *testing.T) {
+	testJSON(t,
+		`send [GEM 1] (
+			source = @a allowing overdraft up to [GEM 50]
+			destination = @c
+		)`,
+		`{}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{
+			"a": {
+				"GEM": core.NewMonetaryInt(-60),
+			},
+		},
+		CaseResult{
+			Printed:  []core.Value{},
+			Postings: []Posting{},
+			ExitCode: EXIT_FAIL_INSUFFICIENT_FUNDS,
+			Error:    "insufficient funds",
+		},
+	)
+}
+
 func TestNoEmptyPostings(t *testing.T) {
 	testJSON(t,
 		`send [GEM 2] (
@@ -675,13 +1277,13 @@ func TestNoEmptyPostings(t *testing.T) {
 		)`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{},
+		map[string]map[string]*core.MonetaryInt{},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "GEM",
-					Amount:      2,
+					Amount:      core.NewMonetaryInt(2),
 					Source:      "world",
 					Destination: "a",
 				},
@@ -699,14 +1301,14 @@ func TestNoEmptyPostings2(t *testing.T) {
 		)`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"foo": {
-				"GEM": 0,
+				"GEM": core.NewMonetaryInt(0),
 			},
 		},
 		CaseResult{
 			Printed:  []core.Value{},
-			Postings: []ledger.Posting{},
+			Postings: []Posting{},
 			ExitCode: EXIT_OK,
 		},
 	)
@@ -726,26 +1328,26 @@ func TestAllocateDontTakeTooMuch(t *testing.T) {
 		)`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"users:001": {
-				"CREDIT": 100,
+				"CREDIT": core.NewMonetaryInt(100),
 			},
 			"users:002": {
-				"CREDIT": 110,
+				"CREDIT": core.NewMonetaryInt(110),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "CREDIT",
-					Amount:      100,
+					Amount:      core.NewMonetaryInt(100),
 					Source:      "users:001",
 					Destination: "foo",
 				},
 				{
 					Asset:       "CREDIT",
-					Amount:      100,
+					Amount:      core.NewMonetaryInt(100),
 					Source:      "users:002",
 					Destination: "bar",
 				},
@@ -781,26 +1383,26 @@ func TestMetadata(t *testing.T) {
 				"commission": *commission,
 			},
 		},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"sales:042": {
-				"EUR/2": 2500,
+				"EUR/2": core.NewMonetaryInt(2500),
 			},
 			"users:053": {
-				"EUR/2": 500,
+				"EUR/2": core.NewMonetaryInt(500),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "EUR/2",
-					Amount:      88,
+					Amount:      core.NewMonetaryInt(88),
 					Source:      "sales:042",
 					Destination: "users:053",
 				},
 				{
 					Asset:       "EUR/2",
-					Amount:      12,
+					Amount:      core.NewMonetaryInt(12),
 					Source:      "sales:042",
 					Destination: "platform",
 				},
@@ -823,23 +1425,23 @@ func TestTrackBalances(t *testing.T) {
 		)`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"a": {
-				"COIN": 50,
+				"COIN": core.NewMonetaryInt(50),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "COIN",
-					Amount:      50,
+					Amount:      core.NewMonetaryInt(50),
 					Source:      "world",
 					Destination: "a",
 				},
 				{
 					Asset:       "COIN",
-					Amount:      100,
+					Amount:      core.NewMonetaryInt(100),
 					Source:      "a",
 					Destination: "b",
 				},
@@ -862,15 +1464,16 @@ func TestTrackBalances2(t *testing.T) {
 		)`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"a": {
-				"COIN": 60,
+				"COIN": core.NewMonetaryInt(60),
 			},
 		},
 		CaseResult{
 			Printed:  []core.Value{},
-			Postings: []ledger.Posting{},
+			Postings: []Posting{},
 			ExitCode: EXIT_FAIL_INSUFFICIENT_FUNDS,
+			Error:    "insufficient funds",
 		},
 	)
 }
@@ -890,24 +1493,24 @@ func TestTrackBalances3(t *testing.T) {
 		)`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"foo": {
-				"COIN": 2000,
+				"COIN": core.NewMonetaryInt(2000),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "COIN",
-					Amount:      1000,
+					Amount:      core.NewMonetaryInt(1000),
 					Source:      "foo",
 					Destination: "bar",
 				},
 
 				{
 					Asset:       "COIN",
-					Amount:      1000,
+					Amount:      core.NewMonetaryInt(1000),
 					Source:      "foo",
 					Destination: "bar",
 				},
@@ -931,35 +1534,35 @@ func TestSourceAllotment(t *testing.T) {
 		`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"a": {
-				"COIN": 100,
+				"COIN": core.NewMonetaryInt(100),
 			},
 			"b": {
-				"COIN": 100,
+				"COIN": core.NewMonetaryInt(100),
 			},
 			"c": {
-				"COIN": 100,
+				"COIN": core.NewMonetaryInt(100),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "COIN",
-					Amount:      61,
+					Amount:      core.NewMonetaryInt(61),
 					Source:      "a",
 					Destination: "d",
 				},
 				{
 					Asset:       "COIN",
-					Amount:      35,
+					Amount:      core.NewMonetaryInt(35),
 					Source:      "b",
 					Destination: "d",
 				},
 				{
 					Asset:       "COIN",
-					Amount:      4,
+					Amount:      core.NewMonetaryInt(4),
 					Source:      "c",
 					Destination: "d",
 				},
@@ -986,26 +1589,26 @@ func TestSourceOverlapping(t *testing.T) {
 		`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"a": {
-				"COIN": 99,
+				"COIN": core.NewMonetaryInt(99),
 			},
 			"b": {
-				"COIN": 3,
+				"COIN": core.NewMonetaryInt(3),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "COIN",
-					Amount:      3,
+					Amount:      core.NewMonetaryInt(3),
 					Source:      "b",
 					Destination: "world",
 				},
 				{
 					Asset:       "COIN",
-					Amount:      96,
+					Amount:      core.NewMonetaryInt(96),
 					Source:      "a",
 					Destination: "world",
 				},
@@ -1040,44 +1643,44 @@ func TestSourceComplex(t *testing.T) {
 			}
 		}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{
+		map[string]map[string]*core.MonetaryInt{
 			"a": {
-				"COIN": 1000,
+				"COIN": core.NewMonetaryInt(1000),
 			},
 			"b": {
-				"COIN": 40,
+				"COIN": core.NewMonetaryInt(40),
 			},
 			"c": {
-				"COIN": 1000,
+				"COIN": core.NewMonetaryInt(1000),
 			},
 			"d": {
-				"COIN": 1000,
+				"COIN": core.NewMonetaryInt(1000),
 			},
 		},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "COIN",
-					Amount:      4,
+					Amount:      core.NewMonetaryInt(4),
 					Source:      "a",
 					Destination: "platform",
 				},
 				{
 					Asset:       "COIN",
-					Amount:      40,
+					Amount:      core.NewMonetaryInt(40),
 					Source:      "b",
 					Destination: "platform",
 				},
 				{
 					Asset:       "COIN",
-					Amount:      56,
+					Amount:      core.NewMonetaryInt(56),
 					Source:      "c",
 					Destination: "platform",
 				},
 				{
 					Asset:       "COIN",
-					Amount:      100,
+					Amount:      core.NewMonetaryInt(100),
 					Source:      "d",
 					Destination: "platform",
 				},
@@ -1104,25 +1707,25 @@ func TestDestinationComplex(t *testing.T) {
 		`,
 		`{}`,
 		map[string]map[string]core.Value{},
-		map[string]map[string]uint64{},
+		map[string]map[string]*core.MonetaryInt{},
 		CaseResult{
 			Printed: []core.Value{},
-			Postings: []ledger.Posting{
+			Postings: []Posting{
 				{
 					Asset:       "COIN",
-					Amount:      20,
+					Amount:      core.NewMonetaryInt(20),
 					Source:      "world",
 					Destination: "a",
 				},
 				{
 					Asset:       "COIN",
-					Amount:      10,
+					Amount:      core.NewMonetaryInt(10),
 					Source:      "world",
 					Destination: "b",
 				},
 				{
 					Asset:       "COIN",
-					Amount:      50,
+					Amount:      core.NewMonetaryInt(50),
 					Source:      "world",
 					Destination: "c",
 				},
@@ -1189,7 +1792,7 @@ func TestNeededBalances(t *testing.T) {
 				if len(expected[req.Account]) == 0 {
 					delete(expected, req.Account)
 				}
-				req.Response <- 0
+				req.Response <- core.NewMonetaryInt(0)
 			} else {
 				t.Fatalf("did not expect to need %v balance of %v", req.Asset, req.Account)
 			}
@@ -1200,6 +1803,195 @@ func TestNeededBalances(t *testing.T) {
 	}
 }
 
+func TestSetBalances(t *testing.T) {
+	p, err := compiler.Compile(`vars {
+		account $a
+	}
+	send [GEM 15] (
+		source = {
+			$a
+			@a
+			@b
+		}
+		destination = @c
+	)
+	send [COIN 1] (
+		source = @a
+		destination = @world
+	)`)
+	if err != nil {
+		t.Fatalf("did not expect error on Compile, got: %v", err)
+	}
+
+	m := NewMachine(p)
+	err = m.SetVars(map[string]core.Value{
+		"a": core.Account("a"),
+	})
+	if err != nil {
+		t.Fatalf("did not expect error on SetVars, got: %v", err)
+	}
+
+	_, err = m.NeededBalances()
+	if err == nil {
+		t.Fatalf("expected an error before resources are resolved")
+	}
+	{
+		ch, _ := m.ResolveResources()
+		for range ch {
+			t.Fatalf("did not expect to need any metadata")
+		}
+	}
+
+	query, err := m.NeededBalances()
+	if err != nil {
+		t.Fatalf("did not expect error on NeededBalances, got: %v", err)
+	}
+	if !reflect.DeepEqual(query, BalanceQuery{
+		"a": {"COIN", "GEM"},
+		"b": {"GEM"},
+	}) {
+		t.Fatalf("unexpected needed balances: %v", query)
+	}
+
+	err = m.SetBalances(map[string]map[string]*core.MonetaryInt{
+		"a": {"GEM": core.NewMonetaryInt(10)},
+		"b": {"GEM": core.NewMonetaryInt(10)},
+	})
+	if err == nil || err.Error() != "missing COIN balance of @a" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m = NewMachine(p)
+	m.SetVars(map[string]core.Value{
+		"a": core.Account("a"),
+	})
+	{
+		ch, _ := m.ResolveResources()
+		for range ch {
+		}
+	}
+	err = m.SetBalances(map[string]map[string]*core.MonetaryInt{
+		"a": {"GEM": core.NewMonetaryInt(10), "COIN": core.NewMonetaryInt(1)},
+		"b": {"GEM": core.NewMonetaryInt(10)},
+	})
+	if err != nil {
+		t.Fatalf("did not expect error on SetBalances, got: %v", err)
+	}
+	_, err = m.ResolveBalances()
+	if err == nil {
+		t.Fatalf("expected an error when resolving balances twice")
+	}
+	exit_code, err := m.Execute()
+	if err != nil || exit_code != EXIT_OK {
+		t.Fatalf("unexpected result: %v, %v", exit_code, err)
+	}
+	if len(m.Postings) != 3 {
+		t.Fatalf("unexpected postings: %v", m.Postings)
+	}
+}
+
+func TestBalance(t *testing.T) {
+	testJSON(t,
+		`vars {
+			monetary $initial = balance(@a, GEM)
+		}
+		send [GEM 10] (
+			source = @a
+			destination = @c
+		)
+		send [GEM *] (
+			source = max balance(@a, GEM) from @b
+			destination = @c
+		)
+		print $initial`,
+		`{}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{
+			"a": {
+				"GEM": core.NewMonetaryInt(30),
+			},
+			"b": {
+				"GEM": core.NewMonetaryInt(100),
+			},
+		},
+		CaseResult{
+			// balances are read as they were before execution
+			Printed: []core.Value{
+				core.Monetary{Asset: "GEM", Amount: core.NewMonetaryInt(30)},
+			},
+			Postings: []Posting{
+				{
+					Asset:       "GEM",
+					Amount:      core.NewMonetaryInt(10),
+					Source:      "a",
+					Destination: "c",
+				},
+				{
+					Asset:       "GEM",
+					Amount:      core.NewMonetaryInt(30),
+					Source:      "b",
+					Destination: "c",
+				},
+			},
+			ExitCode: EXIT_OK,
+		},
+	)
+}
+
+func TestWorldVariableSource(t *testing.T) {
+	testJSON(t,
+		`vars {
+			account $src
+		}
+		send [USD/2 100] (
+			source = $src
+			destination = @b
+		)`,
+		`{"src": "world"}`,
+		map[string]map[string]core.Value{},
+		map[string]map[string]*core.MonetaryInt{},
+		CaseResult{
+			Printed: []core.Value{},
+			Postings: []Posting{
+				{
+					Asset:       "USD/2",
+					Amount:      core.NewMonetaryInt(100),
+					Source:      "world",
+					Destination: "b",
+				},
+			},
+			ExitCode: EXIT_OK,
+		},
+	)
+}
+
+func TestBalanceOfWorld(t *testing.T) {
+	p, err := compiler.Compile(`vars {
+		account $acc
+	}
+	print balance($acc, GEM)`)
+	if err != nil {
+		t.Fatalf("did not expect error on Compile, got: %v", err)
+	}
+	m := NewMachine(p)
+	err = m.SetVars(map[string]core.Value{
+		"acc": core.Account("world"),
+	})
+	if err != nil {
+		t.Fatalf("did not expect error on SetVars, got: %v", err)
+	}
+	{
+		ch, _ := m.ResolveResources()
+		for range ch {
+			t.Fatalf("did not expect to need any metadata")
+		}
+	}
+	err = m.SetBalances(map[string]map[string]*core.MonetaryInt{})
+	if err == nil || err.Error() != "cannot read the GEM balance of @world" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestSetTxMeta(t *testing.T) {
 	p, err := compiler.Compile(`
 	set_tx_meta("aaa", @platform)
@@ -1238,7 +2030,7 @@ func TestSetTxMeta(t *testing.T) {
 		"bbb": json.RawMessage(`{"type":"asset","value":"GEM"}`),
 		"ccc": json.RawMessage(`{"type":"number","value":45}`),
 		"ddd": json.RawMessage(`{"type":"string","value":"hello"}`),
-		"eee": json.RawMessage(`{"type":"monetary","value":{"asset":"COIN","amount":30}}`),
+		"eee": json.RawMessage(`{"type":"monetary","value":{"asset":"COIN","amount":"30"}}`),
 	}
 
 	meta := m.GetTxMetaJson()
@@ -1259,3 +2051,155 @@ func TestSetTxMeta(t *testing.T) {
 		}
 	}
 }
+
+func TestSetAccountMeta(t *testing.T) {
+	p, err := compiler.Compile(`
+	vars {
+		account $order
+	}
+	set_account_meta($order, "status", "paid")
+	set_account_meta($order, "total", [COIN 30])
+	set_account_meta(@platform, "last_order", $order)
+	`)
+	if err != nil {
+		t.Fatalf("did not expect error on Compile, got: %v", err)
+	}
+
+	m := NewMachine(p)
+	err = m.SetVars(map[string]core.Value{
+		"order": core.Account("orders:123"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	{
+		ch, _ := m.ResolveResources()
+		for range ch {
+		}
+	}
+	{
+		ch, _ := m.ResolveBalances()
+		for range ch {
+		}
+	}
+	_, err = m.Execute()
+	if err != nil {
+		t.Fatalf("did not expect error on Execute, got: %v", err)
+	}
+
+	if len(m.TxMeta) != 0 || len(m.AccountMeta) != 2 || len(m.AccountMeta["orders:123"]) != 2 {
+		t.Fatalf("unexpected account metadata: %v", m.AccountMeta)
+	}
+	if !core.ValueEquals(m.AccountMeta["platform"]["last_order"], core.Account("orders:123")) {
+		t.Fatalf("unexpected account metadata: %v", m.AccountMeta)
+	}
+
+	expected_meta := map[string]map[string]json.RawMessage{
+		"orders:123": {
+			"status": json.RawMessage(`{"type":"string","value":"paid"}`),
+			"total":  json.RawMessage(`{"type":"monetary","value":{"asset":"COIN","amount":"30"}}`),
+		},
+		"platform": {
+			"last_order": json.RawMessage(`{"type":"account","value":"orders:123"}`),
+		},
+	}
+	meta := m.GetAccountMetaJson()
+	if len(meta) != len(expected_meta) {
+		t.Fatalf("unexpected account metadata: %v", meta)
+	}
+	for account, expected := range expected_meta {
+		if len(meta[account]) != len(expected) {
+			t.Fatalf("unexpected metadata of %v: %v", account, meta[account])
+		}
+		for k, v := range expected {
+			if string(meta[account][k]) != string(v) {
+				t.Fatalf("unexpected metadata %q of %v: %s", k, account, meta[account][k])
+			}
+		}
+	}
+}
+
+func TestInvalidProgram(t *testing.T) {
+	for _, c := range []struct {
+		Instructions []byte
+		Msg          string
+	}{
+		{
+			Instructions: []byte{
+				program.OP_APUSH, 00, 00,
+				program.OP_IPUSH, 01, 00, 00, 00, 00, 00, 00, 00,
+				program.OP_IADD,
+			},
+			Msg: "expected number, got account",
+		},
+		{
+			Instructions: []byte{program.OP_PRINT},
+			Msg:          "empty stack",
+		},
+		{
+			Instructions: []byte{program.OP_APUSH, 05, 00},
+			Msg:          "invalid resource address",
+		},
+		{
+			Instructions: []byte{program.OP_IPUSH, 01},
+			Msg:          "truncated operand",
+		},
+		{
+			Instructions: []byte{program.OP_JUMP, 00, 00},
+			Msg:          "invalid jump target",
+		},
+		{
+			Instructions: []byte{
+				program.OP_IPUSH, 00, 00, 00, 00, 00, 01, 00, 00,
+				program.OP_MAKE_ALLOTMENT,
+			},
+			Msg: "tried to make an allotment of 1099511627776 portions on a stack of 0",
+		},
+		{
+			Instructions: []byte{
+				program.OP_IPUSH, 00, 00, 00, 00, 00, 01, 00, 00,
+				program.OP_FUNDING_ASSEMBLE,
+			},
+			Msg: "tried to assemble 1099511627776 fundings on a stack of 0",
+		},
+		{
+			Instructions: []byte{
+				program.OP_APUSH, 00, 00,
+				program.OP_ASSET,
+			},
+			Msg: "cannot get the asset of @a",
+		},
+		{
+			Instructions: []byte{0xff},
+			Msg:          "unknown opcode",
+		},
+	} {
+		p := program.Program{
+			Instructions: c.Instructions,
+			Resources: []program.Resource{
+				program.Constant{Inner: core.Account("a")},
+			},
+		}
+		m := NewMachine(&p)
+		{
+			ch, _ := m.ResolveResources()
+			for range ch {
+			}
+		}
+		{
+			ch, _ := m.ResolveBalances()
+			for range ch {
+			}
+		}
+		exit_code, err := m.Execute()
+		if exit_code != EXIT_FAIL_INVALID {
+			t.Fatalf("unexpected exit code: %v", exit_code)
+		}
+		if !errors.Is(err, ErrInvalidProgram) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(err.Error(), c.Msg) {
+			t.Fatalf("unexpected error message: %v", err)
+		}
+	}
+}
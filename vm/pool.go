@@ -0,0 +1,43 @@
+package vm
+
+import (
+	"sync"
+
+	"github.com/numary/machine/vm/program"
+)
+
+// Keeps machines executing the same program for reuse, safe for concurrent use
+type MachinePool struct {
+	program *program.Program
+	pool    sync.Pool
+}
+
+func NewMachinePool(p *program.Program) *MachinePool {
+	return &MachinePool{
+		program: p,
+		pool: sync.Pool{
+			New: func() interface{} {
+				return NewMachine(p)
+			},
+		},
+	}
+}
+
+// Returns a machine in the same state as one returned by NewMachine
+func (mp *MachinePool) Get() *Machine {
+	return mp.pool.Get().(*Machine)
+}
+
+// Resets m and gives it back to the pool, m must not be used afterwards.
+// Machines of other programs are ignored.
+func (mp *MachinePool) Put(m *Machine) {
+	if m == nil || m.Program != mp.program {
+		return
+	}
+	m.Reset()
+	m.Output = nil
+	m.Printer = nil
+	m.Tracer = nil
+	m.Limits = Limits{}
+	mp.pool.Put(m)
+}
@@ -0,0 +1,162 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/script/compiler"
+	"github.com/numary/machine/vm/program"
+)
+
+const poolCode = `vars {
+	monetary $amount
+	account $dest
+}
+send $amount (
+	source = {
+		@a
+		@world
+	}
+	destination = $dest
+)
+set_tx_meta("dest", $dest)
+print $amount`
+
+func executeVars(m *Machine, amount int64, dest string) error {
+	vars := map[string]core.Value{
+		"amount": core.Monetary{Asset: "COIN", Amount: core.NewMonetaryInt(amount)},
+		"dest":   core.Account(dest),
+	}
+	err := m.SetVars(vars)
+	if err != nil {
+		return err
+	}
+	if len(vars) != 2 {
+		return fmt.Errorf("variables were modified: %v", vars)
+	}
+	ch, err := m.ResolveResources()
+	if err != nil {
+		return err
+	}
+	for range ch {
+	}
+	err = m.SetBalances(map[string]map[string]*core.MonetaryInt{
+		"a": {"COIN": core.NewMonetaryInt(15)},
+	})
+	if err != nil {
+		return err
+	}
+	exit_code, err := m.Execute()
+	if err != nil || exit_code != EXIT_OK {
+		return fmt.Errorf("unexpected result: %v, %v", exit_code, err)
+	}
+	return nil
+}
+
+func checkPoolResult(res *ExecutionResult, amount int64, dest string) error {
+	total := core.NewMonetaryInt(0)
+	for _, posting := range res.Postings {
+		if posting.Destination != dest {
+			return fmt.Errorf("unexpected postings: %v", res.Postings)
+		}
+		total = total.Add(posting.Amount)
+	}
+	if !total.Equal(core.NewMonetaryInt(amount)) {
+		return fmt.Errorf("unexpected postings: %v", res.Postings)
+	}
+	if !core.ValueEquals(res.TxMeta["dest"], core.Account(dest)) || len(res.Printed) != 1 {
+		return fmt.Errorf("unexpected result: %v, %v", res.TxMeta, res.Printed)
+	}
+	return nil
+}
+
+func TestReset(t *testing.T) {
+	p, err := compiler.Compile(poolCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMachine(p)
+	m.Limits = Limits{MaxPostings: 2}
+	err = executeVars(m, 10, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := m.Result()
+
+	m.Reset()
+	if m.Limits.MaxPostings != 2 {
+		t.Fatalf("limits were not kept: %+v", m.Limits)
+	}
+	err = executeVars(m, 20, "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := m.Result()
+
+	if err := checkPoolResult(first, 10, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkPoolResult(second, 20, "c"); err != nil {
+		t.Fatal(err)
+	}
+	if second.Usage.Instructions != first.Usage.Instructions || second.Usage.Printed != 1 {
+		t.Fatalf("usage was not reset: %+v != %+v", second.Usage, first.Usage)
+	}
+}
+
+func TestMachinePool(t *testing.T) {
+	p, err := compiler.Compile(poolCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := NewMachinePool(p)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 32)
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				amount := int64(i*20 + j)
+				dest := fmt.Sprintf("users:%d", i)
+				m := pool.Get()
+				err := executeVars(m, amount, dest)
+				if err == nil {
+					err = checkPoolResult(m.Result(), amount, dest)
+				}
+				pool.Put(m)
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	after, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Fatal("program was modified by executions")
+	}
+
+	other := NewMachine(&program.Program{})
+	pool.Put(other)
+	if m := pool.Get(); m.Program != p {
+		t.Fatal("pool returned a machine of another program")
+	}
+}
@@ -0,0 +1,116 @@
+package program
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// Describes a resource the way it is written in Numscript
+func (p *Program) describeResource(addr int) string {
+	if addr >= len(p.Resources) {
+		return "<invalid resource>"
+	}
+	switch res := p.Resources[addr].(type) {
+	case Constant:
+		return fmt.Sprint(res.Inner)
+	case Parameter:
+		return fmt.Sprintf("<%v $%v>", res.Typ, res.Name)
+	case Metadata:
+		account := "<invalid resource>"
+		if int(res.SourceAccount) < addr {
+			account = p.describeOperand(int(res.SourceAccount))
+		}
+		return fmt.Sprintf("meta(%v, %q)", account, res.Key)
+	case AccountBalance:
+		account, asset := "<invalid resource>", "<invalid resource>"
+		if int(res.Account) < addr {
+			account = p.describeOperand(int(res.Account))
+		}
+		if int(res.Asset) < addr {
+			asset = p.describeOperand(int(res.Asset))
+		}
+		return fmt.Sprintf("balance(%v, %v)", account, asset)
+	default:
+		return "<unknown resource>"
+	}
+}
+
+// Describes a resource used by another one, naming variables rather than their type
+func (p *Program) describeOperand(addr int) string {
+	if param, ok := p.Resources[addr].(Parameter); ok {
+		return "$" + param.Name
+	}
+	return p.describeResource(addr)
+}
+
+// Returns a listing of the program with one instruction per line, along with
+// the resource pushed by each OP_APUSH and the depth of the stack after each
+// instruction, or "?" past the first instruction that fails verification.
+// If source is the Numscript the program was compiled from and the program has
+// a source map, source lines are interleaved with the instructions compiled from them.
+func (p *Program) Disassemble(source string) string {
+	var lines []string
+	if source != "" && len(p.SourceMap) > 0 {
+		lines = strings.Split(source, "\n")
+	}
+	v := verifier{
+		p:     p,
+		stack: make([]stackSlot, 0),
+	}
+	verified := v.verifyResources() == nil && v.verifyNeededBalances() == nil
+
+	var out strings.Builder
+	last_line := 0
+	for v.pc = 0; v.pc < len(p.Instructions); v.pc++ {
+		start := v.pc
+		v.op = p.Instructions[v.pc]
+
+		if span, ok := p.SourceMap.Lookup(start); ok && lines != nil {
+			for ; last_line < span.Startl && last_line < len(lines); last_line++ {
+				printSourceLine(&out, lines, last_line+1)
+			}
+		}
+
+		instr := OpcodeName(v.op)
+		switch v.op {
+		case OP_APUSH:
+			if start+3 <= len(p.Instructions) {
+				addr := int(binary.LittleEndian.Uint16(p.Instructions[start+1 : start+3]))
+				instr = fmt.Sprintf("%v #%d ; %v", instr, addr, p.describeResource(addr))
+			}
+		case OP_IPUSH:
+			if start+9 <= len(p.Instructions) {
+				instr = fmt.Sprintf("%v %d", instr, binary.LittleEndian.Uint64(p.Instructions[start+1:start+9]))
+			}
+		case OP_JUMP, OP_JUMP_IF_FALSE:
+			if start+3 <= len(p.Instructions) {
+				instr = fmt.Sprintf("%v %04d", instr, binary.LittleEndian.Uint16(p.Instructions[start+1:start+3]))
+			}
+		}
+
+		depth := "?"
+		if verified && v.join() == nil && v.step() == nil {
+			depth = fmt.Sprint(len(v.stack))
+		} else {
+			verified = false
+			switch v.op {
+			case OP_APUSH, OP_JUMP, OP_JUMP_IF_FALSE:
+				v.pc += 2
+			case OP_IPUSH:
+				v.pc += 8
+			}
+		}
+		fmt.Fprintf(&out, "%04d [%v] %v\n", start, depth, instr)
+	}
+	for ; lines != nil && last_line < len(lines); last_line++ {
+		printSourceLine(&out, lines, last_line+1)
+	}
+	return out.String()
+}
+
+func printSourceLine(out *strings.Builder, lines []string, l int) {
+	if line := strings.TrimSpace(lines[l-1]); line != "" {
+		fmt.Fprintf(out, "      ; %d: %v\n", l, line)
+	}
+}
@@ -0,0 +1,87 @@
+package program_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/numary/machine/script/compiler"
+	"github.com/numary/machine/vm/program"
+)
+
+func TestDisassemble(t *testing.T) {
+	src := `vars {
+	account $a
+	portion $fee = meta($a, "fee")
+}
+print $fee
+send [COIN 10] (
+	source = @users:001
+	destination = $a
+)`
+	p, err := compiler.Compile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := p.Disassemble("")
+	for _, expected := range []string{
+		"0000 [1] OP_APUSH #1 ; meta($a, \"fee\")\n0003 [0] OP_PRINT\n",
+		"; @users:001\n",
+		"; [COIN 10]\n",
+		"; <account $a>\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Fatalf("expected %q in disassembly:\n%v", expected, out)
+		}
+	}
+	if strings.Contains(out, "; 5: ") {
+		t.Fatalf("unexpected source lines in disassembly:\n%v", out)
+	}
+	if !strings.HasSuffix(out, "0032 [1] OP_SEND\n0033 [0] OP_REPAY\n") {
+		t.Fatalf("expected program to end with an empty stack:\n%v", out)
+	}
+
+	out = p.Disassemble(src)
+	print_line := strings.Index(out, "; 5: print $fee\n")
+	send_line := strings.Index(out, "; 6: send [COIN 10] (\n")
+	if print_line < 0 || send_line < 0 || print_line > send_line {
+		t.Fatalf("expected interleaved source lines:\n%v", out)
+	}
+	if !strings.Contains(out, "; 8: destination = $a\n") {
+		t.Fatalf("expected every line of the send statement:\n%v", out)
+	}
+}
+
+func TestDisassembleIf(t *testing.T) {
+	p, err := compiler.Compile(`if 1 == 2 {
+		print 1
+	} else {
+		print 2
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := p.Disassemble("")
+	for _, expected := range []string{
+		"0019 [0] OP_JUMP_IF_FALSE 0035\n",
+		"0032 [0] OP_JUMP 0045\n0035 [1] OP_IPUSH 2\n",
+		"0044 [0] OP_PRINT\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Fatalf("expected %q in disassembly:\n%v", expected, out)
+		}
+	}
+}
+
+func TestDisassembleInvalid(t *testing.T) {
+	p, err := compiler.Compile(`print 1 + 2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a second OP_PRINT pops from an empty stack
+	p.Instructions = append(p.Instructions, program.OP_PRINT, program.OP_FAIL)
+	out := p.Disassemble("")
+	if !strings.HasSuffix(out, "[0] OP_PRINT\n0020 [?] OP_PRINT\n0021 [?] OP_FAIL\n") {
+		t.Fatalf("expected unknown depth after the invalid instruction:\n%v", out)
+	}
+}
@@ -3,25 +3,43 @@ package program
 const (
 	OP_APUSH = byte(iota + 1)
 	OP_IPUSH
-	OP_BUMP             // <value_to_bump: any> <any>*N <int N> => <any>*N <value_to_bump>
-	OP_IADD             // <number> <number> => <number>
-	OP_ISUB             // <number> <number> => <number>
-	OP_PRINT            // <any>
-	OP_FAIL             //
-	OP_ASSET            // <asset | monetary | funding> => <asset>
-	OP_MONETARY_NEW     // <asset> <number> => <monetary>
-	OP_MONETARY_ADD     // <monetary> <monetary> => <monetary>   // panics if not same asset
-	OP_MAKE_ALLOTMENT   // <portion>*N <int N> => <allotment(N)>
-	OP_TAKE_ALL         // <account> <asset> => <funding>
-	OP_TAKE             // <funding> <monetary> => <remaining: funding> <taken: funding>
-	OP_TAKE_MAX         // <funding> <monetary> => <remaining: funding> <taken: funding> (doesn't fail on insufficient funds)
-	OP_FUNDING_ASSEMBLE // <funding>*N <int N> => <funding>
-	OP_FUNDING_SUM      // <funding> => <funding> <sum: monetary>
-	OP_FUNDING_REVERSE  // <funding> => <funding>
-	OP_REPAY            // <funding>
-	OP_ALLOC            // <monetary> <allotment(N)> => <monetary>*N
-	OP_SEND             // <funding> <account>
-	OP_TX_META          //
+	OP_BUMP               // <value_to_bump: any> <any>*N <int N> => <any>*N <value_to_bump>
+	OP_IADD               // <number> <number> => <number>
+	OP_ISUB               // <number> <number> => <number>
+	OP_PRINT              // <any>
+	OP_FAIL               //
+	OP_ASSET              // <asset | monetary | funding> => <asset>
+	OP_MONETARY_NEW       // <asset> <number> => <monetary>
+	OP_MONETARY_ADD       // <monetary> <monetary> => <monetary>   // fails if not same asset
+	OP_MAKE_ALLOTMENT     // <portion>*N <int N> => <allotment(N)>
+	OP_TAKE_ALL           // <account> <asset> => <funding>
+	OP_TAKE               // <funding> <monetary> => <remaining: funding> <taken: funding>
+	OP_TAKE_MAX           // <funding> <monetary> => <remaining: funding> <taken: funding> (doesn't fail on insufficient funds)
+	OP_FUNDING_ASSEMBLE   // <funding>*N <int N> => <funding>
+	OP_FUNDING_SUM        // <funding> => <funding> <sum: monetary>
+	OP_FUNDING_REVERSE    // <funding> => <funding>
+	OP_REPAY              // <funding>
+	OP_ALLOC              // <monetary> <allotment(N)> => <monetary>*N
+	OP_SEND               // <funding> <account>
+	OP_TX_META            //
+	OP_TAKE_ALL_OVERDRAFT // <account> <asset> <overdraft: monetary> => <funding>
+	OP_TAKE_ALL_UNBOUNDED // <account> <asset> => <funding>
+	OP_ACCOUNT_META       // <value: any> <account> <key: string>
+	OP_JUMP               // jumps forward to the instruction at its operand
+	OP_JUMP_IF_FALSE      // <bool> (jumps forward to the instruction at its operand if false)
+	OP_EQ                 // <any> <any> => <bool>
+	OP_LT                 // <number | monetary> <number | monetary> => <bool>   // fails if not same asset
+	OP_GT                 // <number | monetary> <number | monetary> => <bool>   // fails if not same asset
+	OP_NOT                // <bool> => <bool>
+	OP_AND                // <bool> <bool> => <bool>
+	OP_OR                 // <bool> <bool> => <bool>
+	OP_FAIL_REASON        // <reason: string>
+	OP_ASSERT             // <bool> <reason: string>   // fails with reason if false
+	OP_MONETARY_SUB       // <monetary> <monetary> => <monetary>   // fails if not same asset
+	OP_MONETARY_MUL       // <monetary> <number> => <monetary>
+	OP_MONETARY_MUL_DOWN  // <monetary> <portion> => <monetary>   // rounded down
+	OP_MONETARY_MUL_UP    // <monetary> <portion> => <monetary>   // rounded up
+	// new opcodes go at the end, OpcodeFromName stops at the last one
 )
 
 func OpcodeName(op byte) string {
@@ -68,7 +86,51 @@ func OpcodeName(op byte) string {
 		return "OP_SEND"
 	case OP_TX_META:
 		return "OP_TX_META"
+	case OP_TAKE_ALL_OVERDRAFT:
+		return "OP_TAKE_ALL_OVERDRAFT"
+	case OP_TAKE_ALL_UNBOUNDED:
+		return "OP_TAKE_ALL_UNBOUNDED"
+	case OP_ACCOUNT_META:
+		return "OP_ACCOUNT_META"
+	case OP_JUMP:
+		return "OP_JUMP"
+	case OP_JUMP_IF_FALSE:
+		return "OP_JUMP_IF_FALSE"
+	case OP_EQ:
+		return "OP_EQ"
+	case OP_LT:
+		return "OP_LT"
+	case OP_GT:
+		return "OP_GT"
+	case OP_NOT:
+		return "OP_NOT"
+	case OP_AND:
+		return "OP_AND"
+	case OP_OR:
+		return "OP_OR"
+	case OP_FAIL_REASON:
+		return "OP_FAIL_REASON"
+	case OP_ASSERT:
+		return "OP_ASSERT"
+	case OP_MONETARY_SUB:
+		return "OP_MONETARY_SUB"
+	case OP_MONETARY_MUL:
+		return "OP_MONETARY_MUL"
+	case OP_MONETARY_MUL_DOWN:
+		return "OP_MONETARY_MUL_DOWN"
+	case OP_MONETARY_MUL_UP:
+		return "OP_MONETARY_MUL_UP"
 	default:
 		return "Unknown opcode"
 	}
 }
+
+// Returns the opcode with the given name, or false if no defined opcode has it
+func OpcodeFromName(name string) (byte, bool) {
+	for op := OP_APUSH; op <= OP_MONETARY_MUL_UP; op++ {
+		if OpcodeName(op) == name {
+			return op, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,201 @@
+package program
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/numary/machine/core"
+)
+
+type instructionJSON struct {
+	Op      string  `json:"op"`
+	Operand *uint64 `json:"operand,omitempty"` // for jumps, an offset in the binary instructions like in source maps
+}
+
+type resourceJSON struct {
+	Kind    string          `json:"kind"`
+	Type    string          `json:"type"`
+	Value   json.RawMessage `json:"value,omitempty"`
+	Name    string          `json:"name,omitempty"`
+	Account *core.Address   `json:"account,omitempty"`
+	Key     string          `json:"key,omitempty"`
+	Asset   *core.Address   `json:"asset,omitempty"`
+}
+
+type programJSON struct {
+	Instructions   []instructionJSON               `json:"instructions"`
+	Resources      []resourceJSON                  `json:"resources"`
+	Parameters     map[string]core.Address         `json:"parameters,omitempty"`
+	NeededBalances map[core.Address][]core.Address `json:"needed_balances"`
+	SourceMap      SourceMap                       `json:"source_map,omitempty"`
+}
+
+func (p Program) MarshalJSON() ([]byte, error) {
+	out := programJSON{
+		Instructions:   make([]instructionJSON, 0),
+		Resources:      make([]resourceJSON, 0, len(p.Resources)),
+		Parameters:     p.Parameters,
+		NeededBalances: make(map[core.Address][]core.Address, len(p.NeededBalances)),
+		SourceMap:      p.SourceMap,
+	}
+
+	for i := 0; i < len(p.Instructions); i++ {
+		op := p.Instructions[i]
+		instr := instructionJSON{
+			Op: OpcodeName(op),
+		}
+		switch op {
+		case OP_APUSH, OP_JUMP, OP_JUMP_IF_FALSE:
+			if i+3 > len(p.Instructions) {
+				return nil, fmt.Errorf("truncated operand at instruction %d", i)
+			}
+			operand := uint64(binary.LittleEndian.Uint16(p.Instructions[i+1 : i+3]))
+			instr.Operand = &operand
+			i += 2
+		case OP_IPUSH:
+			if i+9 > len(p.Instructions) {
+				return nil, fmt.Errorf("truncated operand at instruction %d", i)
+			}
+			operand := binary.LittleEndian.Uint64(p.Instructions[i+1 : i+9])
+			instr.Operand = &operand
+			i += 8
+		}
+		out.Instructions = append(out.Instructions, instr)
+	}
+
+	for i, res := range p.Resources {
+		switch res := res.(type) {
+		case Constant:
+			value, err := json.Marshal(res.Inner)
+			if err != nil {
+				return nil, fmt.Errorf("resource #%d: %v", i, err)
+			}
+			out.Resources = append(out.Resources, resourceJSON{
+				Kind:  "constant",
+				Type:  res.GetType().String(),
+				Value: value,
+			})
+		case Parameter:
+			out.Resources = append(out.Resources, resourceJSON{
+				Kind: "parameter",
+				Type: res.Typ.String(),
+				Name: res.Name,
+			})
+		case Metadata:
+			account := res.SourceAccount
+			out.Resources = append(out.Resources, resourceJSON{
+				Kind:    "metadata",
+				Type:    res.Typ.String(),
+				Account: &account,
+				Key:     res.Key,
+			})
+		case AccountBalance:
+			account, asset := res.Account, res.Asset
+			out.Resources = append(out.Resources, resourceJSON{
+				Kind:    "balance",
+				Type:    res.GetType().String(),
+				Account: &account,
+				Asset:   &asset,
+			})
+		default:
+			return nil, fmt.Errorf("resource #%d: unknown kind of resource", i)
+		}
+	}
+
+	for account, assets := range p.NeededBalances {
+		list := make([]core.Address, 0, len(assets))
+		for asset := range assets {
+			list = append(list, asset)
+		}
+		sortAddresses(list)
+		out.NeededBalances[account] = list
+	}
+
+	return json.Marshal(out)
+}
+
+func (p *Program) UnmarshalJSON(data []byte) error {
+	var in programJSON
+	err := json.Unmarshal(data, &in)
+	if err != nil {
+		return err
+	}
+
+	instructions := make([]byte, 0)
+	for i, instr := range in.Instructions {
+		op, ok := OpcodeFromName(instr.Op)
+		if !ok {
+			return fmt.Errorf("instruction %d: unknown opcode: %q", i, instr.Op)
+		}
+		instructions = append(instructions, op)
+		switch op {
+		case OP_APUSH, OP_JUMP, OP_JUMP_IF_FALSE:
+			if instr.Operand == nil || *instr.Operand > 0xffff {
+				return fmt.Errorf("instruction %d: invalid operand for %v", i, instr.Op)
+			}
+			instructions = append(instructions, core.Address(*instr.Operand).ToBytes()...)
+		case OP_IPUSH:
+			if instr.Operand == nil {
+				return fmt.Errorf("instruction %d: missing operand for %v", i, instr.Op)
+			}
+			bytes := make([]byte, 8)
+			binary.LittleEndian.PutUint64(bytes, *instr.Operand)
+			instructions = append(instructions, bytes...)
+		default:
+			if instr.Operand != nil {
+				return fmt.Errorf("instruction %d: unexpected operand for %v", i, instr.Op)
+			}
+		}
+	}
+
+	resources := make([]Resource, len(in.Resources))
+	for i, res := range in.Resources {
+		typ, ok := core.TypenameToType(res.Type)
+		if !ok {
+			return fmt.Errorf("resource #%d: unknown type: %q", i, res.Type)
+		}
+		switch res.Kind {
+		case "constant":
+			value, err := core.NewValueFromJSON(typ, res.Value)
+			if err != nil {
+				return fmt.Errorf("resource #%d: %v", i, err)
+			}
+			resources[i] = Constant{Inner: *value}
+		case "parameter":
+			resources[i] = Parameter{Typ: typ, Name: res.Name}
+		case "metadata":
+			if res.Account == nil {
+				return fmt.Errorf("resource #%d: missing account of metadata", i)
+			}
+			resources[i] = Metadata{SourceAccount: *res.Account, Key: res.Key, Typ: typ}
+		case "balance":
+			if res.Account == nil || res.Asset == nil {
+				return fmt.Errorf("resource #%d: missing account or asset of balance", i)
+			}
+			if typ != core.TYPE_MONETARY {
+				return fmt.Errorf("resource #%d: balance must be monetary", i)
+			}
+			resources[i] = AccountBalance{Account: *res.Account, Asset: *res.Asset}
+		default:
+			return fmt.Errorf("resource #%d: unknown kind of resource: %q", i, res.Kind)
+		}
+	}
+
+	needed_balances := make(map[core.Address]map[core.Address]struct{}, len(in.NeededBalances))
+	for account, assets := range in.NeededBalances {
+		needed_balances[account] = make(map[core.Address]struct{}, len(assets))
+		for _, asset := range assets {
+			needed_balances[account][asset] = struct{}{}
+		}
+	}
+
+	*p = Program{
+		Instructions:   instructions,
+		Resources:      resources,
+		Parameters:     in.Parameters,
+		NeededBalances: needed_balances,
+		SourceMap:      in.SourceMap,
+	}
+	return nil
+}
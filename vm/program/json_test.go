@@ -0,0 +1,129 @@
+package program_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/script/compiler"
+	"github.com/numary/machine/vm/program"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	p, err := compiler.Compile(`vars {
+		account $a
+		portion $fee = meta($a, "fee")
+		monetary $m
+		monetary $b = balance($a, COIN)
+	}
+	print 1 + 2
+	set_tx_meta("key", "value")
+	send $m (
+		source = {
+			$fee from $a
+			remaining from @world
+		}
+		destination = {
+			1/3 to @c
+			remaining kept
+		}
+	)
+	if $m > [COIN 5] && true {
+		print "big"
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Resources = append(p.Resources,
+		program.Constant{Inner: core.Allotment{*big.NewRat(1, 3), *big.NewRat(2, 3)}},
+		program.Constant{Inner: core.Funding{
+			Asset: "COIN",
+			Parts: []core.FundingPart{
+				{Account: "a", Amount: core.NewMonetaryInt(12)},
+			},
+		}},
+	)
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `{"kind":"metadata","type":"portion","account":0,"key":"fee"}`) {
+		t.Fatalf("unexpected json: %v", string(data))
+	}
+	if !strings.Contains(string(data), `{"op":"OP_IPUSH","operand":2}`) {
+		t.Fatalf("unexpected json: %v", string(data))
+	}
+
+	var decoded program.Program
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(decoded.Instructions, p.Instructions) {
+		t.Fatalf("unexpected instructions: %v", decoded.Instructions)
+	}
+	if len(decoded.Resources) != len(p.Resources) {
+		t.Fatalf("unexpected resources: %v", decoded.Resources)
+	}
+	for i := range p.Resources {
+		switch res := p.Resources[i].(type) {
+		case program.Constant:
+			c, ok := decoded.Resources[i].(program.Constant)
+			if !ok || !core.ValueEquals(c.Inner, res.Inner) {
+				t.Fatalf("unexpected resource #%d: %v", i, decoded.Resources[i])
+			}
+		default:
+			if decoded.Resources[i] != res {
+				t.Fatalf("unexpected resource #%d: %v", i, decoded.Resources[i])
+			}
+		}
+	}
+	for account, assets := range p.NeededBalances {
+		if len(decoded.NeededBalances[account]) != len(assets) {
+			t.Fatalf("unexpected needed balances: %v", decoded.NeededBalances)
+		}
+		for asset := range assets {
+			if _, ok := decoded.NeededBalances[account][asset]; !ok {
+				t.Fatalf("unexpected needed balances: %v", decoded.NeededBalances)
+			}
+		}
+	}
+	if len(p.SourceMap) == 0 || !reflect.DeepEqual(decoded.SourceMap, p.SourceMap) {
+		t.Fatalf("unexpected source map: %v", decoded.SourceMap)
+	}
+
+	again, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(again, data) {
+		t.Fatalf("encoding is not stable:\n%v\n%v", string(data), string(again))
+	}
+}
+
+func TestJSONInvalid(t *testing.T) {
+	for _, c := range []struct {
+		JSON string
+		Msg  string
+	}{
+		{JSON: `{"instructions":[{"op":"OP_NOPE"}]}`, Msg: "unknown opcode"},
+		{JSON: `{"instructions":[{"op":"Unknown opcode"}]}`, Msg: "unknown opcode"},
+		{JSON: `{"instructions":[{"op":"OP_APUSH"}]}`, Msg: "invalid operand"},
+		{JSON: `{"instructions":[{"op":"OP_IADD","operand":1}]}`, Msg: "unexpected operand"},
+		{JSON: `{"resources":[{"kind":"nope","type":"account"}]}`, Msg: "unknown kind of resource"},
+		{JSON: `{"resources":[{"kind":"constant","type":"nope"}]}`, Msg: "unknown type"},
+		{JSON: `{"resources":[{"kind":"metadata","type":"account","key":"k"}]}`, Msg: "missing account"},
+	} {
+		var p program.Program
+		err := json.Unmarshal([]byte(c.JSON), &p)
+		if err == nil || !strings.Contains(err.Error(), c.Msg) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,581 @@
+package program
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/numary/machine/core"
+)
+
+// Binary format of a compiled program:
+//   magic "NMPG", version byte
+//   instructions: uvarint length, bytes
+//   resources: uvarint count, then for each: kind byte, payload
+//   parameters: uvarint count, then for each: name, address
+//   needed balances: uvarint count, then for each: account address, uvarint count, asset addresses
+//   source map: uvarint count, then for each: offset, start line,
+//   start column, end line and end column as uvarints
+// Fundings are encoded as their asset, an infinite byte, the account an infinite
+// funding draws on, and their parts.
+// Strings are encoded as a uvarint length followed by their bytes,
+// addresses as little-endian uint16, like in the instructions.
+
+var binaryMagic = []byte("NMPG")
+
+const binaryVersion = byte(1)
+
+const (
+	resourceConstant = byte(iota + 1)
+	resourceParameter
+	resourceMetadata
+	resourceAccountBalance
+)
+
+type encoder struct {
+	buf bytes.Buffer
+}
+
+func (e *encoder) byte(b byte) {
+	e.buf.WriteByte(b)
+}
+
+func (e *encoder) uvarint(n uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	l := binary.PutUvarint(tmp[:], n)
+	e.buf.Write(tmp[:l])
+}
+
+func (e *encoder) bytes(b []byte) {
+	e.uvarint(uint64(len(b)))
+	e.buf.Write(b)
+}
+
+func (e *encoder) string(s string) {
+	e.bytes([]byte(s))
+}
+
+func (e *encoder) address(a core.Address) {
+	e.buf.Write(a.ToBytes())
+}
+
+func (e *encoder) bigInt(i *big.Int) {
+	e.byte(byte(i.Sign() + 1))
+	e.bytes(i.Bytes())
+}
+
+func (e *encoder) rat(r *big.Rat) {
+	e.bigInt(r.Num())
+	e.bigInt(r.Denom())
+}
+
+func (e *encoder) value(v core.Value) error {
+	e.byte(byte(v.GetType()))
+	switch v := v.(type) {
+	case core.Account:
+		e.string(string(v))
+	case core.Asset:
+		e.string(string(v))
+	case core.String:
+		e.string(string(v))
+	case core.Number:
+		e.uvarint(uint64(v))
+	case core.Bool:
+		if v {
+			e.byte(1)
+		} else {
+			e.byte(0)
+		}
+	case core.Monetary:
+		e.string(string(v.Asset))
+		e.bigInt(v.Amount.BigInt())
+	case core.Portion:
+		if v.Remaining {
+			e.byte(1)
+		} else {
+			e.byte(0)
+			e.rat(v.Specific)
+		}
+	case core.Allotment:
+		e.uvarint(uint64(len(v)))
+		for i := range v {
+			e.rat(&v[i])
+		}
+	case core.Funding:
+		e.string(string(v.Asset))
+		if v.Infinite {
+			e.byte(1)
+		} else {
+			e.byte(0)
+		}
+		e.string(string(v.InfiniteAccount))
+		e.uvarint(uint64(len(v.Parts)))
+		for _, part := range v.Parts {
+			e.string(string(part.Account))
+			e.bigInt(part.Amount.BigInt())
+		}
+	default:
+		return fmt.Errorf("cannot encode value of type %v", v.GetType())
+	}
+	return nil
+}
+
+func (p Program) MarshalBinary() ([]byte, error) {
+	e := encoder{}
+	e.buf.Write(binaryMagic)
+	e.byte(binaryVersion)
+
+	e.bytes(p.Instructions)
+
+	e.uvarint(uint64(len(p.Resources)))
+	for i, res := range p.Resources {
+		switch res := res.(type) {
+		case Constant:
+			e.byte(resourceConstant)
+			if err := e.value(res.Inner); err != nil {
+				return nil, fmt.Errorf("resource #%d: %v", i, err)
+			}
+		case Parameter:
+			e.byte(resourceParameter)
+			e.byte(byte(res.Typ))
+			e.string(res.Name)
+		case Metadata:
+			e.byte(resourceMetadata)
+			e.address(res.SourceAccount)
+			e.string(res.Key)
+			e.byte(byte(res.Typ))
+		case AccountBalance:
+			e.byte(resourceAccountBalance)
+			e.address(res.Account)
+			e.address(res.Asset)
+		default:
+			return nil, fmt.Errorf("resource #%d: unknown kind of resource", i)
+		}
+	}
+
+	names := make([]string, 0, len(p.Parameters))
+	for name := range p.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	e.uvarint(uint64(len(names)))
+	for _, name := range names {
+		e.string(name)
+		e.address(p.Parameters[name])
+	}
+
+	accounts := make([]core.Address, 0, len(p.NeededBalances))
+	for account := range p.NeededBalances {
+		accounts = append(accounts, account)
+	}
+	sortAddresses(accounts)
+	e.uvarint(uint64(len(accounts)))
+	for _, account := range accounts {
+		e.address(account)
+		assets := make([]core.Address, 0, len(p.NeededBalances[account]))
+		for asset := range p.NeededBalances[account] {
+			assets = append(assets, asset)
+		}
+		sortAddresses(assets)
+		e.uvarint(uint64(len(assets)))
+		for _, asset := range assets {
+			e.address(asset)
+		}
+	}
+
+	e.uvarint(uint64(len(p.SourceMap)))
+	for _, entry := range p.SourceMap {
+		for _, n := range []int{entry.Offset, entry.Span.Startl, entry.Span.Startc, entry.Span.Endl, entry.Span.Endc} {
+			if n < 0 {
+				return nil, fmt.Errorf("source map: negative position: %d", n)
+			}
+			e.uvarint(uint64(n))
+		}
+	}
+
+	return e.buf.Bytes(), nil
+}
+
+func sortAddresses(addrs []core.Address) {
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i] < addrs[j] })
+}
+
+var errTruncated = errors.New("unexpected end of data")
+
+type decoder struct {
+	data []byte
+}
+
+func (d *decoder) byte() (byte, error) {
+	if len(d.data) < 1 {
+		return 0, errTruncated
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b, nil
+}
+
+func (d *decoder) uvarint() (uint64, error) {
+	n, l := binary.Uvarint(d.data)
+	if l <= 0 {
+		return 0, errTruncated
+	}
+	d.data = d.data[l:]
+	return n, nil
+}
+
+// reads a length and checks that at least that many bytes remain,
+// so that corrupted data can't make us allocate huge slices
+func (d *decoder) length() (int, error) {
+	n, err := d.uvarint()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.data)) {
+		return 0, errTruncated
+	}
+	return int(n), nil
+}
+
+func (d *decoder) int() (int, error) {
+	n, err := d.uvarint()
+	if err != nil {
+		return 0, err
+	}
+	if n > math.MaxInt32 {
+		return 0, fmt.Errorf("integer out of range: %d", n)
+	}
+	return int(n), nil
+}
+
+func (d *decoder) bytes() ([]byte, error) {
+	n, err := d.length()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	copy(b, d.data[:n])
+	d.data = d.data[n:]
+	return b, nil
+}
+
+func (d *decoder) string() (string, error) {
+	b, err := d.bytes()
+	return string(b), err
+}
+
+func (d *decoder) address() (core.Address, error) {
+	if len(d.data) < 2 {
+		return 0, errTruncated
+	}
+	a := core.Address(binary.LittleEndian.Uint16(d.data[:2]))
+	d.data = d.data[2:]
+	return a, nil
+}
+
+func (d *decoder) bigInt() (*big.Int, error) {
+	sign, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+	if sign > 2 {
+		return nil, fmt.Errorf("invalid sign: %d", sign)
+	}
+	b, err := d.bytes()
+	if err != nil {
+		return nil, err
+	}
+	i := new(big.Int).SetBytes(b)
+	if sign == 0 {
+		i.Neg(i)
+	}
+	return i, nil
+}
+
+func (d *decoder) rat() (*big.Rat, error) {
+	num, err := d.bigInt()
+	if err != nil {
+		return nil, err
+	}
+	denom, err := d.bigInt()
+	if err != nil {
+		return nil, err
+	}
+	if denom.Sign() == 0 {
+		return nil, errors.New("zero denominator")
+	}
+	return new(big.Rat).SetFrac(num, denom), nil
+}
+
+func (d *decoder) value() (core.Value, error) {
+	typ, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+	switch core.Type(typ) {
+	case core.TYPE_ACCOUNT:
+		s, err := d.string()
+		return core.Account(s), err
+	case core.TYPE_ASSET:
+		s, err := d.string()
+		return core.Asset(s), err
+	case core.TYPE_STRING:
+		s, err := d.string()
+		return core.String(s), err
+	case core.TYPE_NUMBER:
+		n, err := d.uvarint()
+		return core.Number(n), err
+	case core.TYPE_BOOL:
+		b, err := d.byte()
+		return core.Bool(b == 1), err
+	case core.TYPE_MONETARY:
+		asset, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		amount, err := d.bigInt()
+		if err != nil {
+			return nil, err
+		}
+		return core.Monetary{
+			Asset:  core.Asset(asset),
+			Amount: core.NewMonetaryIntFromBigInt(amount),
+		}, nil
+	case core.TYPE_PORTION:
+		remaining, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		if remaining == 1 {
+			return core.NewPortionRemaining(), nil
+		}
+		r, err := d.rat()
+		if err != nil {
+			return nil, err
+		}
+		portion, err := core.NewPortionSpecific(*r)
+		if err != nil {
+			return nil, err
+		}
+		return *portion, nil
+	case core.TYPE_ALLOTMENT:
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+		allotment := make(core.Allotment, n)
+		for i := 0; i < n; i++ {
+			r, err := d.rat()
+			if err != nil {
+				return nil, err
+			}
+			allotment[i] = *r
+		}
+		return allotment, nil
+	case core.TYPE_FUNDING:
+		asset, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		infinite, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		infinite_account, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+		funding := core.Funding{
+			Asset:           core.Asset(asset),
+			Parts:           make([]core.FundingPart, n),
+			Infinite:        infinite == 1,
+			InfiniteAccount: core.Account(infinite_account),
+		}
+		for i := 0; i < n; i++ {
+			account, err := d.string()
+			if err != nil {
+				return nil, err
+			}
+			amount, err := d.bigInt()
+			if err != nil {
+				return nil, err
+			}
+			funding.Parts[i] = core.FundingPart{
+				Account: core.Account(account),
+				Amount:  core.NewMonetaryIntFromBigInt(amount),
+			}
+		}
+		return funding, nil
+	default:
+		return nil, fmt.Errorf("invalid type: %d", typ)
+	}
+}
+
+func (d *decoder) resource() (Resource, error) {
+	kind, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case resourceConstant:
+		v, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		return Constant{Inner: v}, nil
+	case resourceParameter:
+		typ, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		name, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		return Parameter{Typ: core.Type(typ), Name: name}, nil
+	case resourceMetadata:
+		account, err := d.address()
+		if err != nil {
+			return nil, err
+		}
+		key, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		typ, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		return Metadata{SourceAccount: account, Key: key, Typ: core.Type(typ)}, nil
+	case resourceAccountBalance:
+		account, err := d.address()
+		if err != nil {
+			return nil, err
+		}
+		asset, err := d.address()
+		if err != nil {
+			return nil, err
+		}
+		return AccountBalance{Account: account, Asset: asset}, nil
+	default:
+		return nil, fmt.Errorf("unknown kind of resource: %d", kind)
+	}
+}
+
+func (p *Program) UnmarshalBinary(data []byte) error {
+	if len(data) < len(binaryMagic)+1 || !bytes.Equal(data[:len(binaryMagic)], binaryMagic) {
+		return errors.New("not a compiled program")
+	}
+	version := data[len(binaryMagic)]
+	if version != binaryVersion {
+		return fmt.Errorf("unsupported program version: %d", version)
+	}
+	d := decoder{data: data[len(binaryMagic)+1:]}
+
+	instructions, err := d.bytes()
+	if err != nil {
+		return fmt.Errorf("instructions: %v", err)
+	}
+
+	n, err := d.length()
+	if err != nil {
+		return fmt.Errorf("resources: %v", err)
+	}
+	resources := make([]Resource, n)
+	for i := 0; i < n; i++ {
+		resources[i], err = d.resource()
+		if err != nil {
+			return fmt.Errorf("resource #%d: %v", i, err)
+		}
+	}
+
+	n, err = d.length()
+	if err != nil {
+		return fmt.Errorf("parameters: %v", err)
+	}
+	var parameters map[string]core.Address
+	if n > 0 {
+		parameters = make(map[string]core.Address, n)
+	}
+	for i := 0; i < n; i++ {
+		name, err := d.string()
+		if err != nil {
+			return fmt.Errorf("parameters: %v", err)
+		}
+		addr, err := d.address()
+		if err != nil {
+			return fmt.Errorf("parameters: %v", err)
+		}
+		parameters[name] = addr
+	}
+
+	n, err = d.length()
+	if err != nil {
+		return fmt.Errorf("needed balances: %v", err)
+	}
+	needed_balances := make(map[core.Address]map[core.Address]struct{}, n)
+	for i := 0; i < n; i++ {
+		account, err := d.address()
+		if err != nil {
+			return fmt.Errorf("needed balances: %v", err)
+		}
+		m, err := d.length()
+		if err != nil {
+			return fmt.Errorf("needed balances: %v", err)
+		}
+		assets := make(map[core.Address]struct{}, m)
+		for j := 0; j < m; j++ {
+			asset, err := d.address()
+			if err != nil {
+				return fmt.Errorf("needed balances: %v", err)
+			}
+			assets[asset] = struct{}{}
+		}
+		needed_balances[account] = assets
+	}
+
+	var source_map SourceMap
+	n, err = d.length()
+	if err != nil {
+		return fmt.Errorf("source map: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		var fields [5]int
+		for j := range fields {
+			fields[j], err = d.int()
+			if err != nil {
+				return fmt.Errorf("source map: %v", err)
+			}
+		}
+		source_map = append(source_map, SourceMapEntry{
+			Offset: fields[0],
+			Span: SourceSpan{
+				Startl: fields[1],
+				Startc: fields[2],
+				Endl:   fields[3],
+				Endc:   fields[4],
+			},
+		})
+	}
+
+	if len(d.data) != 0 {
+		return fmt.Errorf("%d trailing bytes", len(d.data))
+	}
+
+	*p = Program{
+		Instructions:   instructions,
+		Resources:      resources,
+		Parameters:     parameters,
+		NeededBalances: needed_balances,
+		SourceMap:      source_map,
+	}
+	return nil
+}
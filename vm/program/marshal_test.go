@@ -0,0 +1,145 @@
+package program_test
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/script/compiler"
+	"github.com/numary/machine/vm/program"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	p, err := compiler.Compile(`vars {
+		account $a
+		portion $p = meta($a, "fee")
+		monetary $m
+		monetary $b = balance($a, COIN)
+	}
+	set_tx_meta("key", "value")
+	send $m (
+		source = {
+			$p from $a
+			remaining from {
+				max [COIN 10] from @b
+				@world
+			}
+		}
+		destination = {
+			1/3 to @c
+			remaining kept
+		}
+	)
+	if $m > [COIN 5] && true {
+		print "big"
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Parameters = map[string]core.Address{"a": 0}
+	big_amount, _ := core.ParseMonetaryInt("-123456789012345678901234567890")
+	p.Resources = append(p.Resources,
+		program.Constant{Inner: core.Number(42)},
+		program.Constant{Inner: core.Allotment{*big.NewRat(1, 3), *big.NewRat(2, 3)}},
+		program.Constant{Inner: core.Monetary{Asset: "ETH/18", Amount: big_amount}},
+		program.Constant{Inner: core.Funding{
+			Asset: "COIN",
+			Parts: []core.FundingPart{
+				{Account: "a", Amount: core.NewMonetaryInt(12)},
+			},
+			Infinite:        true,
+			InfiniteAccount: "a",
+		}},
+	)
+
+	data, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("NMPG\x01")) {
+		t.Fatalf("unexpected header: %v", data[:5])
+	}
+
+	var decoded program.Program
+	err = decoded.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(decoded.Instructions, p.Instructions) {
+		t.Fatalf("unexpected instructions: %v", decoded.Instructions)
+	}
+	if len(decoded.Resources) != len(p.Resources) {
+		t.Fatalf("unexpected resources: %v", decoded.Resources)
+	}
+	for i := range p.Resources {
+		switch res := p.Resources[i].(type) {
+		case program.Constant:
+			c, ok := decoded.Resources[i].(program.Constant)
+			if !ok || !core.ValueEquals(c.Inner, res.Inner) {
+				t.Fatalf("unexpected resource #%d: %v", i, decoded.Resources[i])
+			}
+		default:
+			if decoded.Resources[i] != res {
+				t.Fatalf("unexpected resource #%d: %v", i, decoded.Resources[i])
+			}
+		}
+	}
+	funding := decoded.Resources[len(decoded.Resources)-1].(program.Constant).Inner.(core.Funding)
+	if !funding.Infinite || funding.InfiniteAccount != "a" {
+		t.Fatalf("unexpected funding: %v", funding)
+	}
+	if len(decoded.NeededBalances) != len(p.NeededBalances) {
+		t.Fatalf("unexpected needed balances: %v", decoded.NeededBalances)
+	}
+	for account, assets := range p.NeededBalances {
+		for asset := range assets {
+			if _, ok := decoded.NeededBalances[account][asset]; !ok {
+				t.Fatalf("unexpected needed balances: %v", decoded.NeededBalances)
+			}
+		}
+	}
+	if decoded.Parameters["a"] != 0 || len(decoded.Parameters) != 1 {
+		t.Fatalf("unexpected parameters: %v", decoded.Parameters)
+	}
+	if len(p.SourceMap) == 0 || !reflect.DeepEqual(decoded.SourceMap, p.SourceMap) {
+		t.Fatalf("unexpected source map: %v", decoded.SourceMap)
+	}
+
+	again, err := decoded.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(again, data) {
+		t.Fatal("encoding is not stable")
+	}
+}
+
+func TestBinaryInvalid(t *testing.T) {
+	p, err := compiler.Compile(`print 1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		Data []byte
+		Msg  string
+	}{
+		{Data: []byte("NOPE\x01"), Msg: "not a compiled program"},
+		{Data: append([]byte("NMPG\x02"), data[5:]...), Msg: "unsupported program version"},
+		{Data: data[:len(data)-1], Msg: "unexpected end of data"},
+		{Data: append(append([]byte{}, data...), 0), Msg: "trailing bytes"},
+	} {
+		var decoded program.Program
+		err := decoded.UnmarshalBinary(c.Data)
+		if err == nil || !strings.Contains(err.Error(), c.Msg) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
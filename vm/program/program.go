@@ -8,11 +8,14 @@ import (
 	"github.com/numary/machine/core"
 )
 
+// A compiled program is never modified after compilation: executions only read
+// it, so a single program can be shared by any number of concurrent machines.
 type Program struct {
 	Instructions   []byte
 	Resources      []Resource
 	Parameters     map[string]core.Address
 	NeededBalances map[core.Address]map[core.Address]struct{}
+	SourceMap      SourceMap // empty unless compiled from Numscript
 }
 
 func (p Program) String() string {
@@ -29,6 +32,10 @@ func (p Program) String() string {
 			out += "OP_IPUSH "
 			out += fmt.Sprintf("%d\n", binary.LittleEndian.Uint64(p.Instructions[i+1:i+9]))
 			i += 8
+		case OP_JUMP, OP_JUMP_IF_FALSE:
+			out += OpcodeName(p.Instructions[i]) + " "
+			out += fmt.Sprintf("%02d\n", binary.LittleEndian.Uint16(p.Instructions[i+1:i+3]))
+			i += 2
 		default:
 			out += OpcodeName(p.Instructions[i]) + "\n"
 		}
@@ -67,14 +74,15 @@ func (p *Program) ParseVariables(vars map[string]core.Value) (map[string]core.Va
 		if param, ok := res.(Parameter); ok {
 			if val, ok := vars[param.Name]; ok && val.GetType() == param.Typ {
 				variables[param.Name] = val
-				delete(vars, param.Name)
 			} else {
 				return nil, fmt.Errorf("missing variables: %q", param.Name)
 			}
 		}
 	}
 	for name := range vars {
-		return nil, fmt.Errorf("extraneous variable: %q", name)
+		if _, ok := variables[name]; !ok {
+			return nil, fmt.Errorf("extraneous variable: %q", name)
+		}
 	}
 	return variables, nil
 }
@@ -92,11 +100,39 @@ func (p *Program) ParseVariablesJSON(vars map[string]json.RawMessage) (map[strin
 				return nil, fmt.Errorf("invalid json for variable of %v of type %v: %v", param.Name, param.Typ, err)
 			}
 			variables[param.Name] = *value
-			delete(vars, param.Name)
 		}
 	}
 	for name := range vars {
-		return nil, fmt.Errorf("extraneous variable: %q", name)
+		if _, ok := variables[name]; !ok {
+			return nil, fmt.Errorf("extraneous variable: %q", name)
+		}
 	}
 	return variables, nil
 }
+
+// Groups the metadata resources in waves: the account of a metadata resource
+// is either a constant, a parameter, or a metadata resource of an earlier wave,
+// so the lookups of a wave are independent from each other.
+func (p *Program) MetadataWaves() ([][]core.Address, error) {
+	depths := make([]int, len(p.Resources))
+	waves := [][]core.Address{}
+	for i, res := range p.Resources {
+		md, ok := res.(Metadata)
+		if !ok {
+			continue
+		}
+		if int(md.SourceAccount) >= i {
+			return nil, fmt.Errorf("account #%d of metadata #%d is not declared before it", md.SourceAccount, i)
+		}
+		depth := 0
+		if _, ok := p.Resources[md.SourceAccount].(Metadata); ok {
+			depth = depths[md.SourceAccount] + 1
+		}
+		depths[i] = depth
+		if depth == len(waves) {
+			waves = append(waves, []core.Address{})
+		}
+		waves[depth] = append(waves[depth], core.Address(i))
+	}
+	return waves, nil
+}
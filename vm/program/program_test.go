@@ -0,0 +1,47 @@
+package program_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/numary/machine/script/compiler"
+	"github.com/numary/machine/vm/program"
+)
+
+func TestMetadataWaves(t *testing.T) {
+	p, err := compiler.Compile(`vars {
+		account $sale
+		account $seller = meta($sale, "seller")
+		account $platform = meta(@config, "platform")
+		portion $fee = meta($seller, "fee")
+		portion $tax = meta($platform, "tax")
+		account $bank = meta($seller, "bank")
+		string $iban = meta($bank, "iban")
+	}
+	set_tx_meta("fee", $fee)
+	set_tx_meta("tax", $tax)
+	set_tx_meta("iban", $iban)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waves, err := p.MetadataWaves()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := [][]string{}
+	for _, wave := range waves {
+		wave_keys := []string{}
+		for _, addr := range wave {
+			wave_keys = append(wave_keys, p.Resources[addr].(program.Metadata).Key)
+		}
+		keys = append(keys, wave_keys)
+	}
+	expected := [][]string{
+		{"seller", "platform"},
+		{"fee", "tax", "bank"},
+		{"iban"},
+	}
+	if fmt.Sprint(keys) != fmt.Sprint(expected) {
+		t.Fatalf("unexpected waves: %v", keys)
+	}
+}
@@ -39,3 +39,15 @@ func (m Metadata) GetType() core.Type { return m.Typ }
 func (m Metadata) String() string {
 	return fmt.Sprintf("<%v meta(%v, %v)>", m.Typ, m.SourceAccount, m.Key)
 }
+
+// Balance of an account, as resolved before execution
+type AccountBalance struct {
+	Account core.Address
+	Asset   core.Address
+}
+
+func (AccountBalance) isResource()          {}
+func (b AccountBalance) GetType() core.Type { return core.TYPE_MONETARY }
+func (b AccountBalance) String() string {
+	return fmt.Sprintf("<%v balance(%v, %v)>", core.TYPE_MONETARY, b.Account, b.Asset)
+}
@@ -0,0 +1,59 @@
+package program
+
+import (
+	"sort"
+	"strings"
+)
+
+// Span of Numscript source code, lines start at 1 and columns at 0
+type SourceSpan struct {
+	Startl int `json:"start_line"`
+	Startc int `json:"start_column"`
+	Endl   int `json:"end_line"`
+	Endc   int `json:"end_column"`
+}
+
+// Returns the source code covered by the span
+func (s SourceSpan) Text(source string) string {
+	lines := strings.Split(source, "\n")
+	if s.Startl < 1 || s.Endl > len(lines) || s.Startl > s.Endl {
+		return ""
+	}
+	if s.Startl == s.Endl {
+		line := lines[s.Startl-1]
+		if s.Startc < 0 || s.Endc > len(line) || s.Startc > s.Endc {
+			return ""
+		}
+		return line[s.Startc:s.Endc]
+	}
+	first := lines[s.Startl-1]
+	last := lines[s.Endl-1]
+	if s.Startc < 0 || s.Startc > len(first) || s.Endc < 0 || s.Endc > len(last) {
+		return ""
+	}
+	text := []string{first[s.Startc:]}
+	text = append(text, lines[s.Startl:s.Endl-1]...)
+	text = append(text, last[:s.Endc])
+	return strings.Join(text, "\n")
+}
+
+// Instructions from Offset onwards, up to the offset of the next entry,
+// were compiled from the source code in Span.
+type SourceMapEntry struct {
+	Offset int        `json:"offset"`
+	Span   SourceSpan `json:"span"`
+}
+
+// Sorted by offset
+type SourceMap []SourceMapEntry
+
+// Returns the span of source code an instruction was compiled from
+func (s SourceMap) Lookup(offset int) (SourceSpan, bool) {
+	i := sort.Search(len(s), func(i int) bool {
+		return s[i].Offset > offset
+	})
+	if i == 0 {
+		return SourceSpan{}, false
+	}
+	return s[i-1].Span, true
+}
@@ -0,0 +1,517 @@
+package program
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/numary/machine/core"
+)
+
+type VerificationError struct {
+	P      int
+	Opcode byte
+	Msg    string
+}
+
+func (e *VerificationError) Error() string {
+	if e.P < 0 {
+		return fmt.Sprintf("invalid program: %v", e.Msg)
+	}
+	return fmt.Sprintf("invalid program at instruction %d (%v): %v", e.P, OpcodeName(e.Opcode), e.Msg)
+}
+
+// what is statically known of a value on the stack
+type stackSlot struct {
+	typ    core.Type
+	number *uint64 // value of a number, if known
+	size   int     // number of portions of an allotment
+}
+
+type verifier struct {
+	p           *Program
+	pc          int
+	op          byte
+	stack       []stackSlot
+	jumps       map[int][]stackSlot // stacks at the targets of the jumps seen so far
+	unreachable bool                // the previous instruction jumped unconditionally
+}
+
+func (v *verifier) fail(format string, args ...interface{}) *VerificationError {
+	return &VerificationError{
+		P:      v.pc,
+		Opcode: v.op,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+func (v *verifier) push(s stackSlot) {
+	v.stack = append(v.stack, s)
+}
+
+func (v *verifier) pop() (stackSlot, *VerificationError) {
+	l := len(v.stack)
+	if l == 0 {
+		return stackSlot{}, v.fail("stack underflow")
+	}
+	s := v.stack[l-1]
+	v.stack = v.stack[:l-1]
+	return s, nil
+}
+
+func (v *verifier) popType(types ...core.Type) (stackSlot, *VerificationError) {
+	s, err := v.pop()
+	if err != nil {
+		return s, err
+	}
+	for _, t := range types {
+		if s.typ == t {
+			return s, nil
+		}
+	}
+	return s, v.fail("expected %v on the stack, got %v", types, s.typ)
+}
+
+// pops a number whose value must be known statically
+func (v *verifier) popCount() (int, *VerificationError) {
+	s, err := v.popType(core.TYPE_NUMBER)
+	if err != nil {
+		return 0, err
+	}
+	if s.number == nil {
+		return 0, v.fail("count is not a constant")
+	}
+	if *s.number > uint64(len(v.stack)) {
+		return 0, v.fail("count exceeds stack size: %d > %d", *s.number, len(v.stack))
+	}
+	return int(*s.number), nil
+}
+
+// Records the stack at the target of a jump, which must be forward
+func (v *verifier) jump(target int) *VerificationError {
+	if target <= v.pc+2 || target > len(v.p.Instructions) {
+		return v.fail("invalid jump target: %d", target)
+	}
+	stack := append(make([]stackSlot, 0, len(v.stack)), v.stack...)
+	if other, ok := v.jumps[target]; ok {
+		var err *VerificationError
+		if stack, err = v.merge(other, stack); err != nil {
+			return err
+		}
+	}
+	if v.jumps == nil {
+		v.jumps = map[int][]stackSlot{}
+	}
+	v.jumps[target] = stack
+	return nil
+}
+
+// Merges the stacks of the jumps to the current instruction with the one falling through to it
+func (v *verifier) join() *VerificationError {
+	stack, ok := v.jumps[v.pc]
+	if !ok {
+		if v.unreachable && v.pc < len(v.p.Instructions) {
+			return v.fail("unreachable instruction")
+		}
+		return nil
+	}
+	delete(v.jumps, v.pc)
+	if v.unreachable {
+		v.stack = stack
+		v.unreachable = false
+		return nil
+	}
+	var err *VerificationError
+	v.stack, err = v.merge(v.stack, stack)
+	return err
+}
+
+// Merges two stacks reaching the same instruction, which must hold the same types
+func (v *verifier) merge(a []stackSlot, b []stackSlot) ([]stackSlot, *VerificationError) {
+	if len(a) != len(b) {
+		return nil, v.fail("stacks of different depths join at %d: %d and %d", v.pc, len(a), len(b))
+	}
+	for i := range a {
+		if a[i].typ != b[i].typ {
+			return nil, v.fail("stacks of different types join at %d: %v and %v", v.pc, a[i].typ, b[i].typ)
+		}
+		if a[i].number != nil && (b[i].number == nil || *a[i].number != *b[i].number) {
+			a[i].number = nil
+		}
+		if a[i].size != b[i].size {
+			a[i].size = 0
+		}
+	}
+	return a, nil
+}
+
+func (v *verifier) resourceType(addr core.Address) (core.Type, bool) {
+	if int(addr) >= len(v.p.Resources) {
+		return 0, false
+	}
+	return v.p.Resources[addr].GetType(), true
+}
+
+func (v *verifier) verifyResources() *VerificationError {
+	for i, res := range v.p.Resources {
+		switch res := res.(type) {
+		case Constant:
+			if res.Inner == nil {
+				return &VerificationError{P: -1, Msg: fmt.Sprintf("resource #%d: constant has no value", i)}
+			}
+		case Parameter:
+			if res.Typ.String() == "invalid type" {
+				return &VerificationError{P: -1, Msg: fmt.Sprintf("resource #%d: invalid type", i)}
+			}
+		case Metadata:
+			if int(res.SourceAccount) >= i {
+				return &VerificationError{P: -1, Msg: fmt.Sprintf("resource #%d: account #%d of metadata is not declared before it", i, res.SourceAccount)}
+			}
+			if v.p.Resources[res.SourceAccount].GetType() != core.TYPE_ACCOUNT {
+				return &VerificationError{P: -1, Msg: fmt.Sprintf("resource #%d: metadata source #%d is not an account", i, res.SourceAccount)}
+			}
+			if res.Typ.String() == "invalid type" {
+				return &VerificationError{P: -1, Msg: fmt.Sprintf("resource #%d: invalid type", i)}
+			}
+		case AccountBalance:
+			if int(res.Account) >= i || int(res.Asset) >= i {
+				return &VerificationError{P: -1, Msg: fmt.Sprintf("resource #%d: account or asset of balance is not declared before it", i)}
+			}
+			if v.p.Resources[res.Account].GetType() != core.TYPE_ACCOUNT {
+				return &VerificationError{P: -1, Msg: fmt.Sprintf("resource #%d: balance account #%d is not an account", i, res.Account)}
+			}
+			if v.p.Resources[res.Asset].GetType() != core.TYPE_ASSET {
+				return &VerificationError{P: -1, Msg: fmt.Sprintf("resource #%d: balance asset #%d is not an asset", i, res.Asset)}
+			}
+			if _, ok := v.p.NeededBalances[res.Account][res.Asset]; !ok {
+				return &VerificationError{P: -1, Msg: fmt.Sprintf("resource #%d: balance is not a needed balance", i)}
+			}
+		default:
+			return &VerificationError{P: -1, Msg: fmt.Sprintf("resource #%d: unknown kind of resource", i)}
+		}
+	}
+	return nil
+}
+
+func (v *verifier) verifyNeededBalances() *VerificationError {
+	for account, assets := range v.p.NeededBalances {
+		typ, ok := v.resourceType(account)
+		if !ok {
+			return &VerificationError{P: -1, Msg: fmt.Sprintf("needed balances: invalid account address #%d", account)}
+		}
+		if typ != core.TYPE_ACCOUNT {
+			return &VerificationError{P: -1, Msg: fmt.Sprintf("needed balances: #%d is not an account", account)}
+		}
+		for asset := range assets {
+			typ, ok := v.resourceType(asset)
+			if !ok {
+				return &VerificationError{P: -1, Msg: fmt.Sprintf("needed balances: invalid asset address #%d", asset)}
+			}
+			if typ != core.TYPE_ASSET && typ != core.TYPE_MONETARY {
+				return &VerificationError{P: -1, Msg: fmt.Sprintf("needed balances: #%d doesn't hold an asset", asset)}
+			}
+			if _, ok := v.p.Resources[asset].(AccountBalance); ok {
+				return &VerificationError{P: -1, Msg: fmt.Sprintf("needed balances: asset of #%d is only known after balances are resolved", asset)}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *verifier) step() *VerificationError {
+	instr := v.p.Instructions
+	switch v.op {
+	case OP_APUSH:
+		if v.pc+3 > len(instr) {
+			return v.fail("truncated operand")
+		}
+		addr := core.Address(binary.LittleEndian.Uint16(instr[v.pc+1 : v.pc+3]))
+		typ, ok := v.resourceType(addr)
+		if !ok {
+			return v.fail("invalid resource address: #%d", addr)
+		}
+		v.push(stackSlot{typ: typ})
+		v.pc += 2
+	case OP_IPUSH:
+		if v.pc+9 > len(instr) {
+			return v.fail("truncated operand")
+		}
+		n := binary.LittleEndian.Uint64(instr[v.pc+1 : v.pc+9])
+		v.push(stackSlot{typ: core.TYPE_NUMBER, number: &n})
+		v.pc += 8
+	case OP_BUMP:
+		n, err := v.popCount()
+		if err != nil {
+			return err
+		}
+		if n >= len(v.stack) {
+			return v.fail("count exceeds stack size: %d >= %d", n, len(v.stack))
+		}
+		idx := len(v.stack) - n - 1
+		s := v.stack[idx]
+		v.stack = append(v.stack[:idx], v.stack[idx+1:]...)
+		v.push(s)
+	case OP_IADD, OP_ISUB:
+		b, err := v.popType(core.TYPE_NUMBER)
+		if err != nil {
+			return err
+		}
+		a, err := v.popType(core.TYPE_NUMBER)
+		if err != nil {
+			return err
+		}
+		res := stackSlot{typ: core.TYPE_NUMBER}
+		if a.number != nil && b.number != nil {
+			var n uint64
+			if v.op == OP_IADD && *a.number+*b.number >= *a.number {
+				n = *a.number + *b.number
+				res.number = &n
+			} else if v.op == OP_ISUB && *b.number <= *a.number {
+				n = *a.number - *b.number
+				res.number = &n
+			}
+		}
+		v.push(res)
+	case OP_PRINT:
+		if _, err := v.pop(); err != nil {
+			return err
+		}
+	case OP_FAIL:
+	case OP_ASSET:
+		if _, err := v.popType(core.TYPE_ASSET, core.TYPE_MONETARY, core.TYPE_FUNDING); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_ASSET})
+	case OP_MONETARY_NEW:
+		if _, err := v.popType(core.TYPE_NUMBER); err != nil {
+			return err
+		}
+		if _, err := v.popType(core.TYPE_ASSET); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_MONETARY})
+	case OP_MONETARY_ADD, OP_MONETARY_SUB:
+		if _, err := v.popType(core.TYPE_MONETARY); err != nil {
+			return err
+		}
+		if _, err := v.popType(core.TYPE_MONETARY); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_MONETARY})
+	case OP_MAKE_ALLOTMENT:
+		n, err := v.popCount()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if _, err := v.popType(core.TYPE_PORTION); err != nil {
+				return err
+			}
+		}
+		v.push(stackSlot{typ: core.TYPE_ALLOTMENT, size: n})
+	case OP_TAKE_ALL, OP_TAKE_ALL_OVERDRAFT, OP_TAKE_ALL_UNBOUNDED:
+		if v.op == OP_TAKE_ALL_OVERDRAFT {
+			if _, err := v.popType(core.TYPE_MONETARY); err != nil {
+				return err
+			}
+		}
+		if _, err := v.popType(core.TYPE_ASSET); err != nil {
+			return err
+		}
+		if _, err := v.popType(core.TYPE_ACCOUNT); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_FUNDING})
+	case OP_TAKE, OP_TAKE_MAX:
+		if _, err := v.popType(core.TYPE_MONETARY); err != nil {
+			return err
+		}
+		if _, err := v.popType(core.TYPE_FUNDING); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_FUNDING})
+		v.push(stackSlot{typ: core.TYPE_FUNDING})
+	case OP_FUNDING_ASSEMBLE:
+		n, err := v.popCount()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return v.fail("cannot assemble zero fundings")
+		}
+		for i := 0; i < n; i++ {
+			if _, err := v.popType(core.TYPE_FUNDING); err != nil {
+				return err
+			}
+		}
+		v.push(stackSlot{typ: core.TYPE_FUNDING})
+	case OP_FUNDING_SUM:
+		if _, err := v.popType(core.TYPE_FUNDING); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_FUNDING})
+		v.push(stackSlot{typ: core.TYPE_MONETARY})
+	case OP_FUNDING_REVERSE:
+		if _, err := v.popType(core.TYPE_FUNDING); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_FUNDING})
+	case OP_REPAY:
+		if _, err := v.popType(core.TYPE_FUNDING); err != nil {
+			return err
+		}
+	case OP_ALLOC:
+		allotment, err := v.popType(core.TYPE_ALLOTMENT)
+		if err != nil {
+			return err
+		}
+		if _, err := v.popType(core.TYPE_MONETARY); err != nil {
+			return err
+		}
+		if allotment.size == 0 {
+			return v.fail("size of allotment is not known")
+		}
+		for i := 0; i < allotment.size; i++ {
+			v.push(stackSlot{typ: core.TYPE_MONETARY})
+		}
+	case OP_SEND:
+		if _, err := v.popType(core.TYPE_ACCOUNT); err != nil {
+			return err
+		}
+		if _, err := v.popType(core.TYPE_FUNDING); err != nil {
+			return err
+		}
+	case OP_TX_META:
+		if _, err := v.popType(core.TYPE_STRING); err != nil {
+			return err
+		}
+		if _, err := v.pop(); err != nil {
+			return err
+		}
+	case OP_ACCOUNT_META:
+		if _, err := v.popType(core.TYPE_STRING); err != nil {
+			return err
+		}
+		if _, err := v.popType(core.TYPE_ACCOUNT); err != nil {
+			return err
+		}
+		if _, err := v.pop(); err != nil {
+			return err
+		}
+	case OP_JUMP, OP_JUMP_IF_FALSE:
+		if v.pc+3 > len(instr) {
+			return v.fail("truncated operand")
+		}
+		if v.op == OP_JUMP_IF_FALSE {
+			if _, err := v.popType(core.TYPE_BOOL); err != nil {
+				return err
+			}
+		}
+		if err := v.jump(int(binary.LittleEndian.Uint16(instr[v.pc+1 : v.pc+3]))); err != nil {
+			return err
+		}
+		v.unreachable = v.op == OP_JUMP
+		v.pc += 2
+	case OP_EQ:
+		b, err := v.pop()
+		if err != nil {
+			return err
+		}
+		if _, err := v.popType(b.typ); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_BOOL})
+	case OP_LT, OP_GT:
+		b, err := v.popType(core.TYPE_NUMBER, core.TYPE_MONETARY)
+		if err != nil {
+			return err
+		}
+		if _, err := v.popType(b.typ); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_BOOL})
+	case OP_NOT:
+		if _, err := v.popType(core.TYPE_BOOL); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_BOOL})
+	case OP_AND, OP_OR:
+		if _, err := v.popType(core.TYPE_BOOL); err != nil {
+			return err
+		}
+		if _, err := v.popType(core.TYPE_BOOL); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_BOOL})
+	case OP_FAIL_REASON:
+		if _, err := v.popType(core.TYPE_STRING); err != nil {
+			return err
+		}
+	case OP_ASSERT:
+		if _, err := v.popType(core.TYPE_STRING); err != nil {
+			return err
+		}
+		if _, err := v.popType(core.TYPE_BOOL); err != nil {
+			return err
+		}
+	case OP_MONETARY_MUL:
+		if _, err := v.popType(core.TYPE_NUMBER); err != nil {
+			return err
+		}
+		if _, err := v.popType(core.TYPE_MONETARY); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_MONETARY})
+	case OP_MONETARY_MUL_DOWN, OP_MONETARY_MUL_UP:
+		if _, err := v.popType(core.TYPE_PORTION); err != nil {
+			return err
+		}
+		if _, err := v.popType(core.TYPE_MONETARY); err != nil {
+			return err
+		}
+		v.push(stackSlot{typ: core.TYPE_MONETARY})
+	default:
+		return v.fail("unknown opcode: %d", v.op)
+	}
+	return nil
+}
+
+// Checks that a program is well-formed before it is executed:
+// that the operands and resource addresses of its instructions are valid,
+// that every instruction finds the types it expects on the stack,
+// that jumps go forward to instructions reached with stacks of the same types,
+// that the stack is empty at the end of the program,
+// and that the needed balances reference accounts and assets.
+func Verify(p *Program) error {
+	v := verifier{
+		p:     p,
+		stack: make([]stackSlot, 0),
+	}
+	if err := v.verifyResources(); err != nil {
+		return err
+	}
+	if err := v.verifyNeededBalances(); err != nil {
+		return err
+	}
+	for v.pc = 0; v.pc < len(p.Instructions); v.pc++ {
+		v.op = p.Instructions[v.pc]
+		if err := v.join(); err != nil {
+			return err
+		}
+		if err := v.step(); err != nil {
+			return err
+		}
+	}
+	if err := v.join(); err != nil {
+		return err
+	}
+	if len(v.jumps) != 0 {
+		return &VerificationError{P: -1, Msg: "jump into the operand of an instruction"}
+	}
+	if len(v.stack) != 0 {
+		return &VerificationError{
+			P:   -1,
+			Msg: fmt.Sprintf("%d values left on the stack at the end of the program", len(v.stack)),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package program_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/script/compiler"
+	"github.com/numary/machine/vm/program"
+)
+
+func TestVerifyCompiledPrograms(t *testing.T) {
+	for _, src := range []string{
+		`print 29 + 15 - 2`,
+		`fail`,
+		`set_tx_meta("key", [COIN 30])`,
+		`vars {
+			account $a
+			portion $p = meta($a, "fee")
+		}
+		send [COIN 100] (
+			source = {
+				$p from $a
+				remaining from {
+					max [COIN 10] from @b
+					@world
+				}
+			}
+			destination = {
+				max [COIN 10] to @c
+				remaining to {
+					50% to @d
+					50% kept
+				}
+			}
+		)`,
+		`send [COIN *] (
+			source = {
+				@a
+				@b
+			}
+			destination = @c
+		)`,
+		`vars {
+			number $n
+		}
+		if $n > 10 {
+			print $n
+		} else if $n == 0 {
+			fail
+		}
+		if !($n < 5 || $n != 7) {
+			print "seven"
+		} else {
+			print "other"
+		}`,
+		`vars {
+			number $n
+		}
+		assert $n >= 1, "n must be positive"
+		fail "n is positive"`,
+		`vars {
+			monetary $m
+		}
+		send ($m - [COIN 1]) * 2 + $m * 1/3 rounded down (
+			source = @a
+			destination = @b
+		)`,
+	} {
+		p, err := compiler.Compile(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = program.Verify(p)
+		if err != nil {
+			t.Fatalf("unexpected verification error: %v\n%v", err, p)
+		}
+	}
+}
+
+func TestVerifyInvalidPrograms(t *testing.T) {
+	resources := []program.Resource{
+		program.Constant{Inner: core.Account("a")},
+		program.Constant{Inner: core.Asset("COIN")},
+	}
+	for _, c := range []struct {
+		Program program.Program
+		Msg     string
+	}{
+		{
+			Program: program.Program{
+				Instructions: []byte{
+					program.OP_APUSH, 00, 00,
+					program.OP_IPUSH, 01, 00, 00, 00, 00, 00, 00, 00,
+					program.OP_IADD,
+				},
+				Resources: resources,
+			},
+			Msg: "expected [number] on the stack, got account",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{program.OP_PRINT},
+				Resources:    resources,
+			},
+			Msg: "stack underflow",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{program.OP_APUSH, 05, 00},
+				Resources:    resources,
+			},
+			Msg: "invalid resource address",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{program.OP_IPUSH, 01},
+				Resources:    resources,
+			},
+			Msg: "truncated operand",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{program.OP_APUSH, 00, 00},
+				Resources:    resources,
+			},
+			Msg: "1 values left on the stack",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{0xff},
+				Resources:    resources,
+			},
+			Msg: "unknown opcode",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{
+					program.OP_APUSH, 00, 00,
+					program.OP_IPUSH, 01, 00, 00, 00, 00, 00, 00, 00,
+					program.OP_IPUSH, 01, 00, 00, 00, 00, 00, 00, 00,
+					program.OP_IADD,
+					program.OP_BUMP,
+				},
+				Resources: resources,
+			},
+			Msg: "count exceeds stack size",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{program.OP_FAIL},
+				Resources:    resources,
+				NeededBalances: map[core.Address]map[core.Address]struct{}{
+					1: {0: {}},
+				},
+			},
+			Msg: "#1 is not an account",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{program.OP_FAIL},
+				Resources:    resources,
+				NeededBalances: map[core.Address]map[core.Address]struct{}{
+					0: {0: {}},
+				},
+			},
+			Msg: "#0 doesn't hold an asset",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{program.OP_FAIL},
+				Resources: []program.Resource{
+					program.Metadata{SourceAccount: 0, Key: "k", Typ: core.TYPE_ACCOUNT},
+				},
+			},
+			Msg: "not declared before it",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{program.OP_FAIL},
+				Resources: []program.Resource{
+					program.Constant{Inner: core.Account("a")},
+					program.Constant{Inner: core.Asset("COIN")},
+					program.AccountBalance{Account: 0, Asset: 1},
+				},
+			},
+			Msg: "balance is not a needed balance",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{
+					program.OP_FAIL,
+					program.OP_JUMP, 00, 00,
+				},
+				Resources: resources,
+			},
+			Msg: "invalid jump target",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{
+					program.OP_APUSH, 00, 00,
+					program.OP_APUSH, 00, 00,
+					program.OP_EQ,
+					program.OP_JUMP_IF_FALSE, 11, 00,
+					program.OP_APUSH, 00, 00,
+					program.OP_PRINT,
+				},
+				Resources: resources,
+			},
+			Msg: "jump into the operand",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{
+					program.OP_JUMP, 06, 00,
+					program.OP_APUSH, 00, 00,
+					program.OP_PRINT,
+				},
+				Resources: resources,
+			},
+			Msg: "unreachable instruction",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{
+					program.OP_APUSH, 00, 00,
+					program.OP_APUSH, 00, 00,
+					program.OP_EQ,
+					program.OP_JUMP_IF_FALSE, 13, 00,
+					program.OP_APUSH, 00, 00,
+					program.OP_PRINT,
+				},
+				Resources: resources,
+			},
+			Msg: "stacks of different depths",
+		},
+		{
+			Program: program.Program{
+				Instructions: []byte{
+					program.OP_APUSH, 00, 00,
+					program.OP_JUMP_IF_FALSE, 06, 00,
+				},
+				Resources: resources,
+			},
+			Msg: "expected [bool] on the stack",
+		},
+	} {
+		err := program.Verify(&c.Program)
+		if err == nil {
+			t.Fatalf("expected error for program:\n%v", c.Program)
+		}
+		if !strings.Contains(err.Error(), c.Msg) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package vm
+
+import (
+	"encoding/json"
+	"errors"
+
+	ledger "github.com/numary/ledger/pkg/core"
+	"github.com/numary/machine/core"
+)
+
+// Returns a short name for an exit code, e.g. "insufficient_funds"
+func ExitCodeName(exit_code byte) string {
+	switch exit_code {
+	case EXIT_OK:
+		return "ok"
+	case EXIT_FAIL:
+		return "fail"
+	case EXIT_FAIL_INVALID:
+		return "invalid"
+	case EXIT_FAIL_INSUFFICIENT_FUNDS:
+		return "insufficient_funds"
+	case EXIT_FAIL_OVERFLOW:
+		return "overflow"
+	case EXIT_FAIL_INSTRUCTIONS_LIMIT:
+		return "instructions_limit"
+	case EXIT_FAIL_STACK_LIMIT:
+		return "stack_limit"
+	case EXIT_FAIL_POSTINGS_LIMIT:
+		return "postings_limit"
+	case EXIT_FAIL_FUNDING_LIMIT:
+		return "funding_limit"
+	case EXIT_FAIL_PRINT_LIMIT:
+		return "print_limit"
+	case EXIT_FAIL_ASSET_MISMATCH:
+		return "asset_mismatch"
+	default:
+		return "unknown"
+	}
+}
+
+// Everything an execution produced, see Machine.ExecuteResult
+type ExecutionResult struct {
+	ExitCode          byte
+	Err               error
+	FailReason        string // reason given by the program when it failed, if any
+	Postings          []Posting
+	TxMeta            map[string]core.Value
+	LedgerTxMeta      ledger.Metadata // TxMeta as stored by the ledger
+	AccountMeta       map[string]map[string]core.Value
+	LedgerAccountMeta map[string]ledger.Metadata // AccountMeta as stored by the ledger
+	Printed           []core.Value
+	Balances          map[string]map[string]*core.MonetaryInt // final balances of the accounts whose balances were resolved
+	BalanceDeltas     map[string]map[string]*core.MonetaryInt // net effect of the postings on each account, by asset
+	Usage             Usage                                   // including the number of instructions executed
+}
+
+// Returns the result of the last execution, as returned by ExecuteResult
+func (m *Machine) Result() *ExecutionResult {
+	deltas := map[string]map[string]*core.MonetaryInt{}
+	add := func(account, asset string, amount *core.MonetaryInt) {
+		if _, ok := deltas[account]; !ok {
+			deltas[account] = map[string]*core.MonetaryInt{}
+		}
+		deltas[account][asset] = deltas[account][asset].Add(amount)
+	}
+	for _, posting := range m.Postings {
+		add(posting.Source, posting.Asset, posting.Amount.Neg())
+		add(posting.Destination, posting.Asset, posting.Amount)
+	}
+	var fail_reason string
+	var fail_err *FailError
+	if errors.As(m.err, &fail_err) {
+		fail_reason = fail_err.Reason
+	}
+	return &ExecutionResult{
+		ExitCode:          m.exit_code,
+		Err:               m.err,
+		FailReason:        fail_reason,
+		Postings:          m.Postings,
+		TxMeta:            m.TxMeta,
+		LedgerTxMeta:      m.GetTxMetaJson(),
+		AccountMeta:       m.AccountMeta,
+		LedgerAccountMeta: m.GetAccountMetaJson(),
+		Printed:           m.Printed,
+		Balances:          copyBalances(m.Balances),
+		BalanceDeltas:     deltas,
+		Usage:             m.Usage,
+	}
+}
+
+type executionResultJSON struct {
+	ExitCode      byte                                    `json:"exit_code"`
+	Status        string                                  `json:"status"`
+	Error         string                                  `json:"error,omitempty"`
+	FailReason    string                                  `json:"fail_reason,omitempty"`
+	Postings      []Posting                               `json:"postings"`
+	TxMeta        ledger.Metadata                         `json:"tx_meta"`
+	AccountMeta   map[string]ledger.Metadata              `json:"account_meta"`
+	Printed       []core.ValueJSON                        `json:"printed"`
+	Balances      map[string]map[string]*core.MonetaryInt `json:"balances"`
+	BalanceDeltas map[string]map[string]*core.MonetaryInt `json:"balance_deltas"`
+	Usage         Usage                                   `json:"usage"`
+}
+
+func (r ExecutionResult) MarshalJSON() ([]byte, error) {
+	out := executionResultJSON{
+		ExitCode:      r.ExitCode,
+		Status:        ExitCodeName(r.ExitCode),
+		FailReason:    r.FailReason,
+		Postings:      r.Postings,
+		TxMeta:        r.LedgerTxMeta,
+		AccountMeta:   r.LedgerAccountMeta,
+		Printed:       stackJSON(r.Printed),
+		Balances:      r.Balances,
+		BalanceDeltas: r.BalanceDeltas,
+		Usage:         r.Usage,
+	}
+	if r.Err != nil {
+		out.Error = r.Err.Error()
+	}
+	if out.Postings == nil {
+		out.Postings = []Posting{}
+	}
+	if out.TxMeta == nil {
+		out.TxMeta = ledger.Metadata{}
+	}
+	if out.AccountMeta == nil {
+		out.AccountMeta = map[string]ledger.Metadata{}
+	}
+	return json.Marshal(out)
+}
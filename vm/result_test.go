@@ -0,0 +1,237 @@
+package vm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/script/compiler"
+)
+
+func TestExecutionResult(t *testing.T) {
+	p, err := compiler.Compile(`print "hello"
+send [COIN 30] (
+	source = {
+		@a
+		@world
+	}
+	destination = {
+		max [COIN 10] to @b
+		remaining to @a
+	}
+)
+set_tx_meta("ref", 42)
+set_account_meta(@b, "ref", 42)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Run(context.Background(), p, map[string]core.Value{}, mapStore{
+		balances: map[string]map[string]*core.MonetaryInt{
+			"a": {"COIN": core.NewMonetaryInt(20)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExitCode != EXIT_OK || res.Usage.Instructions == 0 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	expected_deltas := map[string]map[string]*core.MonetaryInt{
+		"a":     {"COIN": core.NewMonetaryInt(0)},
+		"b":     {"COIN": core.NewMonetaryInt(10)},
+		"world": {"COIN": core.NewMonetaryInt(-10)},
+	}
+	if len(res.BalanceDeltas) != len(expected_deltas) {
+		t.Fatalf("unexpected deltas: %v", res.BalanceDeltas)
+	}
+	for account, assets := range expected_deltas {
+		if !res.BalanceDeltas[account]["COIN"].Equal(assets["COIN"]) {
+			t.Fatalf("unexpected deltas: %v", res.BalanceDeltas)
+		}
+	}
+	if !res.Balances["a"]["COIN"].Equal(core.NewMonetaryInt(20)) {
+		t.Fatalf("unexpected balances: %v", res.Balances)
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]interface{}
+	err = json.Unmarshal(data, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out["status"] != "ok" || out["exit_code"] != float64(EXIT_OK) {
+		t.Fatalf("unexpected status: %s", data)
+	}
+	if _, ok := out["error"]; ok {
+		t.Fatalf("unexpected error: %s", data)
+	}
+	expected_meta := map[string]interface{}{
+		"ref": map[string]interface{}{"type": "number", "value": float64(42)},
+	}
+	if !reflect.DeepEqual(out["tx_meta"], expected_meta) {
+		t.Fatalf("unexpected tx meta: %s", data)
+	}
+	if !reflect.DeepEqual(out["account_meta"], map[string]interface{}{"b": expected_meta}) {
+		t.Fatalf("unexpected account meta: %s", data)
+	}
+	expected_printed := []interface{}{
+		map[string]interface{}{"type": "string", "value": "hello"},
+	}
+	if !reflect.DeepEqual(out["printed"], expected_printed) {
+		t.Fatalf("unexpected printed values: %s", data)
+	}
+	if len(out["postings"].([]interface{})) != 3 {
+		t.Fatalf("unexpected postings: %s", data)
+	}
+	if out["usage"].(map[string]interface{})["instructions"] != float64(res.Usage.Instructions) {
+		t.Fatalf("unexpected usage: %s", data)
+	}
+}
+
+func TestExecutionResultFailure(t *testing.T) {
+	p, err := compiler.Compile(`send [COIN 30] (
+	source = @a
+	destination = @b
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Run(context.Background(), p, map[string]core.Value{}, mapStore{
+		balances: map[string]map[string]*core.MonetaryInt{
+			"a": {"COIN": core.NewMonetaryInt(20)},
+		},
+	})
+	if err == nil || res.Err != err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]interface{}
+	err = json.Unmarshal(data, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out["status"] != "insufficient_funds" || out["error"] != res.Err.Error() {
+		t.Fatalf("unexpected result: %s", data)
+	}
+	if len(out["postings"].([]interface{})) != 0 || len(out["balance_deltas"].(map[string]interface{})) != 0 {
+		t.Fatalf("unexpected result: %s", data)
+	}
+}
+
+func TestExecutionResultFailReason(t *testing.T) {
+	p, err := compiler.Compile(`fail "account frozen"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Run(context.Background(), p, map[string]core.Value{}, mapStore{})
+	if err == nil || res.Err != err || res.FailReason != "account frozen" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]interface{}
+	err = json.Unmarshal(data, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out["status"] != "fail" || out["fail_reason"] != "account frozen" || out["error"] != res.Err.Error() {
+		t.Fatalf("unexpected result: %s", data)
+	}
+}
+
+func TestExecuteResult(t *testing.T) {
+	p, err := compiler.Compile(`print 1 + 2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMachine(p)
+	{
+		ch, _ := m.ResolveResources()
+		for range ch {
+		}
+	}
+	err = m.SetBalances(map[string]map[string]*core.MonetaryInt{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := m.ExecuteResult(context.Background())
+	if err != nil || res.ExitCode != EXIT_OK {
+		t.Fatalf("unexpected result: %+v, %v", res, err)
+	}
+	if len(res.Printed) != 1 || !core.ValueEquals(res.Printed[0], core.Number(3)) {
+		t.Fatalf("unexpected printed values: %v", res.Printed)
+	}
+}
+
+func TestExecutionResultAssetMismatch(t *testing.T) {
+	p, err := compiler.Compile(`vars {
+	monetary $m
+}
+print $m + [EUR 1]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Run(context.Background(), p, map[string]core.Value{
+		"m": core.Monetary{Asset: "USD", Amount: core.NewMonetaryInt(1)},
+	}, mapStore{})
+	if !errors.Is(err, ErrAssetMismatch) || res.Err != err || res.ExitCode != EXIT_FAIL_ASSET_MISMATCH {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(err.Error(), "asset mismatch at instruction 6 (OP_MONETARY_ADD): USD + EUR") {
+		t.Fatalf("unexpected error message: %v", err)
+	}
+}
+
+func TestExecutionResultOverdraft(t *testing.T) {
+	p, err := compiler.Compile(`send [COIN 40] (
+	source = @a allowing overdraft up to [COIN 20]
+	destination = @b
+)
+send [COIN 15] (
+	source = @a allowing unbounded overdraft
+	destination = @b
+)
+send [COIN 5] (
+	source = @world
+	destination = @a
+)
+send [COIN 10] (
+	source = {
+		max [COIN 7] from @a allowing unbounded overdraft
+		@world
+	}
+	destination = @b
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Run(context.Background(), p, map[string]core.Value{}, mapStore{
+		balances: map[string]map[string]*core.MonetaryInt{
+			"a": {"COIN": core.NewMonetaryInt(30)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExitCode != EXIT_OK {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if !res.Balances["a"]["COIN"].Equal(core.NewMonetaryInt(-27)) {
+		t.Fatalf("unexpected balances: %v", res.Balances)
+	}
+	if !res.BalanceDeltas["a"]["COIN"].Equal(core.NewMonetaryInt(-57)) {
+		t.Fatalf("unexpected deltas: %v", res.BalanceDeltas)
+	}
+}
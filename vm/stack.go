@@ -1,78 +1,133 @@
 package vm
 
-import "github.com/numary/machine/core"
+import (
+	"fmt"
+
+	"github.com/numary/machine/core"
+)
+
+// Aborts execution, the panic is recovered in Execute
+func (m *Machine) invalidProgram(msg string) {
+	panic(m.invalidProgramError(msg))
+}
+
+// Like invalidProgram, but returned to end the execution rather than raised
+func (m *Machine) invalidProgramError(msg string) *InvalidProgramError {
+	err := &InvalidProgramError{
+		P:   m.P,
+		Msg: msg,
+	}
+	if int(m.P) < len(m.Program.Instructions) {
+		err.Opcode = m.Program.Instructions[m.P]
+	}
+	return err
+}
+
+func (m *Machine) unexpectedType(expected core.Type, v core.Value) {
+	err := &InvalidProgramError{
+		P:        m.P,
+		Opcode:   m.Program.Instructions[m.P],
+		Expected: expected.String(),
+		Actual:   v.GetType().String(),
+		Msg:      fmt.Sprintf("unexpected type on stack: %v", v),
+	}
+	panic(err)
+}
 
 func (m *Machine) popValue() core.Value {
 	l := len(m.Stack)
+	if l == 0 {
+		m.invalidProgram("tried to pop from an empty stack")
+	}
 	x := m.Stack[l-1]
 	m.Stack = m.Stack[:l-1]
 	return x
 }
 
 func (m *Machine) popAccount() core.Account {
-	if a, ok := m.popValue().(core.Account); ok {
+	v := m.popValue()
+	if a, ok := v.(core.Account); ok {
 		return a
-	} else {
-		panic("unexpected type on stack")
 	}
+	m.unexpectedType(core.TYPE_ACCOUNT, v)
+	return ""
 }
 
 func (m *Machine) popNumber() uint64 {
-	if n, ok := m.popValue().(core.Number); ok {
+	v := m.popValue()
+	if n, ok := v.(core.Number); ok {
 		return uint64(n)
-	} else {
-		panic("unexpected type on stack")
 	}
+	m.unexpectedType(core.TYPE_NUMBER, v)
+	return 0
 }
 
 func (m *Machine) popString() core.String {
-	if s, ok := m.popValue().(core.String); ok {
+	v := m.popValue()
+	if s, ok := v.(core.String); ok {
 		return s
-	} else {
-		panic("unexpected type on stack")
 	}
+	m.unexpectedType(core.TYPE_STRING, v)
+	return ""
 }
 
 func (m *Machine) popMonetary() core.Monetary {
-	if m, ok := m.popValue().(core.Monetary); ok {
-		return m
-	} else {
-		panic("unexpected type on stack")
+	v := m.popValue()
+	if mon, ok := v.(core.Monetary); ok {
+		return mon
 	}
+	m.unexpectedType(core.TYPE_MONETARY, v)
+	return core.Monetary{}
 }
 
 func (m *Machine) popAsset() core.Asset {
-	if v, ok := m.popValue().(core.Asset); ok {
-		return v
-	} else {
-		panic("unexpected type on stack")
+	v := m.popValue()
+	if a, ok := v.(core.Asset); ok {
+		return a
 	}
+	m.unexpectedType(core.TYPE_ASSET, v)
+	return ""
 }
 
 func (m *Machine) popFunding() core.Funding {
-	if v, ok := m.popValue().(core.Funding); ok {
-		return v
-	} else {
-		panic("unexpected type on stack")
+	v := m.popValue()
+	if f, ok := v.(core.Funding); ok {
+		return f
 	}
+	m.unexpectedType(core.TYPE_FUNDING, v)
+	return core.Funding{}
 }
 
 func (m *Machine) popAllotment() core.Allotment {
-	if m, ok := m.popValue().(core.Allotment); ok {
-		return m
-	} else {
-		panic("unexpected type on stack")
+	v := m.popValue()
+	if a, ok := v.(core.Allotment); ok {
+		return a
 	}
+	m.unexpectedType(core.TYPE_ALLOTMENT, v)
+	return nil
 }
 
 func (m *Machine) popPortion() core.Portion {
-	if m, ok := m.popValue().(core.Portion); ok {
-		return m
-	} else {
-		panic("unexpected type on stack")
+	v := m.popValue()
+	if p, ok := v.(core.Portion); ok {
+		return p
 	}
+	m.unexpectedType(core.TYPE_PORTION, v)
+	return core.Portion{}
+}
+
+func (m *Machine) popBool() core.Bool {
+	v := m.popValue()
+	if b, ok := v.(core.Bool); ok {
+		return b
+	}
+	m.unexpectedType(core.TYPE_BOOL, v)
+	return false
 }
 
 func (m *Machine) pushValue(v core.Value) {
+	if f, ok := v.(core.Funding); ok && uint(len(f.Parts)) > m.Usage.FundingParts {
+		m.Usage.FundingParts = uint(len(f.Parts))
+	}
 	m.Stack = append(m.Stack, v)
 }
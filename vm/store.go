@@ -0,0 +1,91 @@
+package vm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/vm/program"
+)
+
+// Provides the balances and account metadata a program needs
+type Store interface {
+	GetBalance(ctx context.Context, account core.Account, asset core.Asset) (*core.MonetaryInt, error)
+	GetAccountMetadata(ctx context.Context, account core.Account, key string) (core.Value, error)
+}
+
+// Implemented by stores that can fetch many balances in one query,
+// Run then uses it instead of calling GetBalance for each balance
+type BatchBalanceStore interface {
+	GetBalances(ctx context.Context, query BalanceQuery) (map[string]map[string]*core.MonetaryInt, error)
+}
+
+// Implemented by stores that can fetch many account metadata in one query,
+// Run then uses it instead of calling GetAccountMetadata for each metadata
+type BatchMetadataStore interface {
+	GetAccountsMetadata(ctx context.Context, query MetadataQuery) (map[string]map[string]core.Value, error)
+}
+
+// Resolves the resources and balances of a program from store and executes it.
+// Errors from Execute are returned along with the result, which holds the exit code.
+func Run(ctx context.Context, p *program.Program, vars map[string]core.Value, store Store) (*ExecutionResult, error) {
+	if store == nil {
+		return nil, errors.New("no store provided")
+	}
+	m := NewMachine(p)
+	err := m.SetVars(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	if batch_store, ok := store.(BatchMetadataStore); ok {
+		err = m.ResolveResourcesInWaves(func(query MetadataQuery) (map[string]map[string]core.Value, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return batch_store.GetAccountsMetadata(ctx, query)
+		})
+	} else {
+		m.resolve_called = true
+		err = m.resolveResources(func(account core.Account, key string) (core.Value, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return store.GetAccountMetadata(ctx, account, key)
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if batch_store, ok := store.(BatchBalanceStore); ok {
+		query, err := m.NeededBalances()
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		balances, err := batch_store.GetBalances(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		err = m.SetBalances(balances)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		m.set_balance_called = true
+		err = m.resolveBalances(func(account core.Account, asset core.Asset) (*core.MonetaryInt, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return store.GetBalance(ctx, account, asset)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m.ExecuteResult(ctx)
+}
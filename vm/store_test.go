@@ -0,0 +1,235 @@
+package vm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/script/compiler"
+)
+
+type mapStore struct {
+	balances map[string]map[string]*core.MonetaryInt
+	meta     map[string]map[string]core.Value
+}
+
+func (s mapStore) GetBalance(ctx context.Context, account core.Account, asset core.Asset) (*core.MonetaryInt, error) {
+	balance, ok := s.balances[string(account)][string(asset)]
+	if !ok {
+		return nil, fmt.Errorf("missing %v balance of %v", asset, account)
+	}
+	return balance, nil
+}
+
+func (s mapStore) GetAccountMetadata(ctx context.Context, account core.Account, key string) (core.Value, error) {
+	val, ok := s.meta[string(account)][key]
+	if !ok {
+		return nil, fmt.Errorf("missing metadata %q of %v", key, account)
+	}
+	return val, nil
+}
+
+type batchStore struct {
+	mapStore
+	queries      []BalanceQuery
+	meta_queries []MetadataQuery
+}
+
+func (s *batchStore) GetAccountsMetadata(ctx context.Context, query MetadataQuery) (map[string]map[string]core.Value, error) {
+	s.meta_queries = append(s.meta_queries, query)
+	return s.meta, nil
+}
+
+func (s *batchStore) GetAccountMetadata(ctx context.Context, account core.Account, key string) (core.Value, error) {
+	return nil, errors.New("metadata should be fetched in batch")
+}
+
+func (s *batchStore) GetBalances(ctx context.Context, query BalanceQuery) (map[string]map[string]*core.MonetaryInt, error) {
+	s.queries = append(s.queries, query)
+	return s.balances, nil
+}
+
+func (s *batchStore) GetBalance(ctx context.Context, account core.Account, asset core.Asset) (*core.MonetaryInt, error) {
+	return nil, errors.New("balances should be fetched in batch")
+}
+
+func TestRunBatchBalances(t *testing.T) {
+	p, err := compiler.Compile(`send [EUR 100] (
+	source = {
+		@a
+		@b
+	}
+	destination = @c
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &batchStore{
+		mapStore: mapStore{
+			balances: map[string]map[string]*core.MonetaryInt{
+				"a": {"EUR": core.NewMonetaryInt(60)},
+				"b": {"EUR": core.NewMonetaryInt(60)},
+			},
+		},
+	}
+	res, err := Run(context.Background(), p, map[string]core.Value{}, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExitCode != EXIT_OK || len(res.Postings) != 2 {
+		t.Fatalf("unexpected result: %v", res)
+	}
+	if len(store.queries) != 1 || len(store.queries[0]) != 2 {
+		t.Fatalf("unexpected queries: %v", store.queries)
+	}
+}
+
+func TestRunBatchMetadata(t *testing.T) {
+	p, err := compiler.Compile(`vars {
+	account $sale
+	account $seller = meta($sale, "seller")
+	account $platform = meta(@config, "platform")
+	portion $fee = meta($seller, "fee")
+	portion $seller_fee = meta($seller, "fee")
+}
+send [EUR 100] (
+	source = $sale
+	destination = {
+		$fee to $platform
+		remaining to $seller
+	}
+)
+set_tx_meta("fee", $seller_fee)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fee, _ := core.NewPortionSpecific(*big.NewRat(1, 10))
+	store := &batchStore{
+		mapStore: mapStore{
+			balances: map[string]map[string]*core.MonetaryInt{
+				"sales:042": {"EUR": core.NewMonetaryInt(100)},
+			},
+			meta: map[string]map[string]core.Value{
+				"sales:042": {"seller": core.Account("users:053")},
+				"config":    {"platform": core.Account("platform")},
+				"users:053": {"fee": *fee},
+			},
+		},
+	}
+	res, err := Run(context.Background(), p, map[string]core.Value{
+		"sale": core.Account("sales:042"),
+	}, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExitCode != EXIT_OK || len(res.Postings) != 2 {
+		t.Fatalf("unexpected result: %v", res)
+	}
+	expected := []MetadataQuery{
+		{"sales:042": {"seller"}, "config": {"platform"}},
+		{"users:053": {"fee"}},
+	}
+	if !reflect.DeepEqual(store.meta_queries, expected) {
+		t.Fatalf("unexpected queries: %v", store.meta_queries)
+	}
+
+	store.meta["users:053"] = map[string]core.Value{}
+	_, err = Run(context.Background(), p, map[string]core.Value{
+		"sale": core.Account("sales:042"),
+	}, store)
+	if err == nil || err.Error() != `missing metadata "fee" of @users:053` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	p, err := compiler.Compile(`vars {
+	account $sale
+	account $seller = meta($sale, "seller")
+	portion $fee = meta($seller, "fee")
+}
+send [EUR 100] (
+	source = $sale
+	destination = {
+		$fee to @platform
+		remaining to $seller
+	}
+)
+set_tx_meta("sale", $sale)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fee, _ := core.NewPortionSpecific(*big.NewRat(1, 10))
+	store := mapStore{
+		balances: map[string]map[string]*core.MonetaryInt{
+			"sales:042": {"EUR": core.NewMonetaryInt(100)},
+		},
+		meta: map[string]map[string]core.Value{
+			"sales:042": {"seller": core.Account("users:053")},
+			"users:053": {"fee": *fee},
+		},
+	}
+	res, err := Run(context.Background(), p, map[string]core.Value{
+		"sale": core.Account("sales:042"),
+	}, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExitCode != EXIT_OK {
+		t.Fatalf("unexpected exit code: %v", res.ExitCode)
+	}
+	expected := []Posting{
+		{Source: "sales:042", Destination: "platform", Amount: core.NewMonetaryInt(10), Asset: "EUR"},
+		{Source: "sales:042", Destination: "users:053", Amount: core.NewMonetaryInt(90), Asset: "EUR"},
+	}
+	if len(res.Postings) != len(expected) {
+		t.Fatalf("unexpected postings: %v", res.Postings)
+	}
+	for i := range expected {
+		if !postingEquals(res.Postings[i], expected[i]) {
+			t.Fatalf("unexpected postings: %v", res.Postings)
+		}
+	}
+	if !core.ValueEquals(res.TxMeta["sale"], core.Account("sales:042")) {
+		t.Fatalf("unexpected tx meta: %v", res.TxMeta)
+	}
+}
+
+func TestRunStoreError(t *testing.T) {
+	p, err := compiler.Compile(`send [EUR 100] (
+	source = @a
+	destination = @b
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Run(context.Background(), p, map[string]core.Value{}, mapStore{})
+	if err == nil || err.Error() != "missing EUR balance of @a" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRunInsufficientFunds(t *testing.T) {
+	p, err := compiler.Compile(`send [EUR 100] (
+	source = @a
+	destination = @b
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Run(context.Background(), p, map[string]core.Value{}, mapStore{
+		balances: map[string]map[string]*core.MonetaryInt{
+			"a": {"EUR": core.NewMonetaryInt(99)},
+		},
+	})
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res == nil || res.ExitCode != EXIT_FAIL_INSUFFICIENT_FUNDS {
+		t.Fatalf("unexpected result: %v", res)
+	}
+}
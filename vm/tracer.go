@@ -0,0 +1,166 @@
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/vm/program"
+)
+
+// Balance changes made by an instruction, by account and asset
+type BalanceDiff map[string]map[string]*core.MonetaryInt
+
+// Observes an execution, set it on Machine.Tracer.
+// The values passed to the hooks must not be modified.
+type Tracer interface {
+	BeforeInstruction(p uint, op byte, stack []core.Value, balances map[string]map[string]*core.MonetaryInt)
+	AfterInstruction(p uint, op byte, stack []core.Value, diff BalanceDiff)
+	Posting(posting Posting)
+	SetTxMeta(key string, value core.Value)
+	SetAccountMeta(account string, key string, value core.Value)
+}
+
+func copyBalances(balances map[string]map[string]*core.MonetaryInt) map[string]map[string]*core.MonetaryInt {
+	res := make(map[string]map[string]*core.MonetaryInt, len(balances))
+	for account, assets := range balances {
+		res[account] = make(map[string]*core.MonetaryInt, len(assets))
+		for asset, balance := range assets {
+			res[account][asset] = balance
+		}
+	}
+	return res
+}
+
+func balanceDiff(before, after map[string]map[string]*core.MonetaryInt) BalanceDiff {
+	diff := BalanceDiff{}
+	for account, assets := range after {
+		for asset, balance := range assets {
+			delta := balance.Sub(before[account][asset])
+			if delta.IsZero() {
+				continue
+			}
+			if _, ok := diff[account]; !ok {
+				diff[account] = map[string]*core.MonetaryInt{}
+			}
+			diff[account][asset] = delta
+		}
+	}
+	return diff
+}
+
+// Prints the state of the machine before each instruction, with colors
+type TextTracer struct {
+	w io.Writer
+}
+
+func NewTextTracer(w io.Writer) *TextTracer {
+	return &TextTracer{w: w}
+}
+
+func (t *TextTracer) BeforeInstruction(p uint, op byte, stack []core.Value, balances map[string]map[string]*core.MonetaryInt) {
+	fmt.Fprintln(t.w, "STATE ---------------------------------------------------------------------")
+	fmt.Fprintf(t.w, "    %v\n", aurora.Blue(stack))
+	fmt.Fprintf(t.w, "    %v\n", aurora.Cyan(balances))
+	fmt.Fprintf(t.w, "    %v\n", program.OpcodeName(op))
+}
+
+func (t *TextTracer) AfterInstruction(p uint, op byte, stack []core.Value, diff BalanceDiff) {}
+func (t *TextTracer) Posting(posting Posting)                                                {}
+func (t *TextTracer) SetTxMeta(key string, value core.Value)                                 {}
+func (t *TextTracer) SetAccountMeta(account string, key string, value core.Value)            {}
+
+// Writes one JSON object per event
+type JSONTracer struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewJSONTracer(w io.Writer) *JSONTracer {
+	return &JSONTracer{enc: json.NewEncoder(w)}
+}
+
+type traceEvent struct {
+	Event   string           `json:"event"`
+	P       *uint            `json:"p,omitempty"`
+	Op      string           `json:"op,omitempty"`
+	Stack   []core.ValueJSON `json:"stack,omitempty"`
+	Diff    BalanceDiff      `json:"balance_diff,omitempty"`
+	Posting *Posting         `json:"posting,omitempty"`
+	Account string           `json:"account,omitempty"`
+	Key     string           `json:"key,omitempty"`
+	Value   *core.ValueJSON  `json:"value,omitempty"`
+}
+
+func valueJSON(v core.Value) core.ValueJSON {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(v))
+	}
+	return core.ValueJSON{
+		Type:  v.GetType().String(),
+		Value: data,
+	}
+}
+
+func stackJSON(stack []core.Value) []core.ValueJSON {
+	res := make([]core.ValueJSON, len(stack))
+	for i, v := range stack {
+		res[i] = valueJSON(v)
+	}
+	return res
+}
+
+func (t *JSONTracer) write(e traceEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.enc.Encode(e)
+}
+
+func (t *JSONTracer) BeforeInstruction(p uint, op byte, stack []core.Value, balances map[string]map[string]*core.MonetaryInt) {
+	t.write(traceEvent{
+		Event: "before",
+		P:     &p,
+		Op:    program.OpcodeName(op),
+		Stack: stackJSON(stack),
+	})
+}
+
+func (t *JSONTracer) AfterInstruction(p uint, op byte, stack []core.Value, diff BalanceDiff) {
+	t.write(traceEvent{
+		Event: "after",
+		P:     &p,
+		Op:    program.OpcodeName(op),
+		Stack: stackJSON(stack),
+		Diff:  diff,
+	})
+}
+
+func (t *JSONTracer) Posting(posting Posting) {
+	t.write(traceEvent{
+		Event:   "posting",
+		Posting: &posting,
+	})
+}
+
+func (t *JSONTracer) SetTxMeta(key string, value core.Value) {
+	v := valueJSON(value)
+	t.write(traceEvent{
+		Event: "set_tx_meta",
+		Key:   key,
+		Value: &v,
+	})
+}
+
+func (t *JSONTracer) SetAccountMeta(account string, key string, value core.Value) {
+	v := valueJSON(value)
+	t.write(traceEvent{
+		Event:   "set_account_meta",
+		Account: account,
+		Key:     key,
+		Value:   &v,
+	})
+}
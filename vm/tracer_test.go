@@ -0,0 +1,106 @@
+package vm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/numary/machine/core"
+	"github.com/numary/machine/script/compiler"
+)
+
+func executeTraced(t *testing.T, tracer Tracer) *Machine {
+	p, err := compiler.Compile(`send [COIN 10] (
+	source = @a
+	destination = @b
+)
+set_tx_meta("key", "value")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMachine(p)
+	m.Tracer = tracer
+	{
+		ch, _ := m.ResolveResources()
+		for range ch {
+		}
+	}
+	err = m.SetBalances(map[string]map[string]*core.MonetaryInt{
+		"a": {"COIN": core.NewMonetaryInt(15)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exit_code, err := m.Execute()
+	if err != nil || exit_code != EXIT_OK {
+		t.Fatalf("unexpected result: %v, %v", exit_code, err)
+	}
+	return m
+}
+
+func TestJSONTracer(t *testing.T) {
+	var buf bytes.Buffer
+	m := executeTraced(t, NewJSONTracer(&buf))
+
+	counts := map[string]uint{}
+	diffs := []BalanceDiff{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var event struct {
+			Event   string          `json:"event"`
+			Op      string          `json:"op"`
+			Diff    BalanceDiff     `json:"balance_diff"`
+			Posting *Posting        `json:"posting"`
+			Key     string          `json:"key"`
+			Value   *core.ValueJSON `json:"value"`
+		}
+		err := json.Unmarshal(scanner.Bytes(), &event)
+		if err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		counts[event.Event]++
+		switch event.Event {
+		case "after":
+			if event.Diff != nil {
+				diffs = append(diffs, event.Diff)
+			}
+		case "posting":
+			if !postingEquals(*event.Posting, Posting{
+				Source:      "a",
+				Destination: "b",
+				Amount:      core.NewMonetaryInt(10),
+				Asset:       "COIN",
+			}) {
+				t.Fatalf("unexpected posting: %v", event.Posting)
+			}
+		case "set_tx_meta":
+			if event.Key != "key" || event.Value.Type != "string" || string(event.Value.Value) != `"value"` {
+				t.Fatalf("unexpected metadata: %v %v", event.Key, event.Value)
+			}
+		}
+	}
+	if counts["before"] != m.Usage.Instructions || counts["after"] != m.Usage.Instructions {
+		t.Fatalf("unexpected number of instructions traced: %v", counts)
+	}
+	if counts["posting"] != 1 || counts["set_tx_meta"] != 1 {
+		t.Fatalf("unexpected events: %v", counts)
+	}
+	// @a is emptied, then the remainder is repaid
+	if len(diffs) != 2 || !diffs[0]["a"]["COIN"].Equal(core.NewMonetaryInt(-15)) || !diffs[1]["a"]["COIN"].Equal(core.NewMonetaryInt(5)) {
+		t.Fatalf("unexpected balance diffs: %v", diffs)
+	}
+}
+
+func TestTextTracer(t *testing.T) {
+	var buf bytes.Buffer
+	m := executeTraced(t, NewTextTracer(&buf))
+	out := buf.String()
+	if strings.Count(out, "STATE ---") != int(m.Usage.Instructions) {
+		t.Fatalf("unexpected output:\n%v", out)
+	}
+	if !strings.Contains(out, "OP_TAKE_ALL") {
+		t.Fatalf("unexpected output:\n%v", out)
+	}
+}